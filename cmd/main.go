@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"os"
 
+	"github.com/clarke94/roulette-service/cmd/rngtest"
 	"github.com/clarke94/roulette-service/cmd/serve"
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
@@ -33,6 +34,7 @@ func main() {
 
 	rootCmd.AddCommand(
 		serve.New(),
+		rngtest.New(),
 	)
 
 	if err := rootCmd.Execute(); err != nil {
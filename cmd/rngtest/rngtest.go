@@ -0,0 +1,207 @@
+// Package rngtest implements a statistical self-test of the random numbers that spin the wheel.
+package rngtest
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"text/tabwriter"
+
+	"github.com/clarke94/roulette-service/internal/pkg/bet"
+	"github.com/clarke94/roulette-service/internal/pkg/fair"
+	"github.com/clarke94/roulette-service/internal/pkg/rng"
+	"github.com/clarke94/roulette-service/internal/pkg/round"
+	"github.com/clarke94/roulette-service/internal/pkg/table"
+	"github.com/spf13/cobra"
+)
+
+const drawsPerMillion = 1000000
+
+const (
+	// SourceFair draws provably fair outcomes the way a provably fair round is spun, from a new server seed every spin.
+	SourceFair = "fair"
+	// fairClientSeed is the client seed of every spin drawn from the fair source.
+	fairClientSeed = "rng-test"
+)
+
+var (
+	ErrWheel  = errors.New("wheel is not supported")
+	ErrFailed = errors.New("random numbers failed the self-test")
+)
+
+// colors is every pocket color in the order they are reported.
+var colors = []string{bet.ColorRed, bet.ColorBlack, bet.ColorGreen}
+
+// Handler provides a Run method when the rng-test command is executed.
+type Handler struct {
+	Millions int
+	Wheel    string
+	Source   string
+	Seed     int64
+	Alpha    float64
+}
+
+// New initializes the rng-test command.
+func New() *cobra.Command {
+	handler := &Handler{}
+
+	cmd := &cobra.Command{
+		Use:   "rng-test",
+		Short: "Tests the random numbers that spin the wheel are uniform",
+		Long: `Draws millions of spins and runs chi-square and runs tests per pocket and per color, printing a pass or
+fail report. Each test is held to the significance level divided by the number of tests.`,
+		RunE: handler.Run,
+	}
+
+	cmd.Flags().IntVar(&handler.Millions, "millions", 1, "millions of spins to draw")
+	cmd.Flags().StringVar(&handler.Wheel, "wheel", table.WheelEuropean, "wheel to spin, european or american")
+	cmd.Flags().StringVar(&handler.Source, "source", rng.SourceCrypto, "source of random numbers, crypto, seeded or fair")
+	cmd.Flags().Int64Var(&handler.Seed, "seed", 0, "seed of the seeded source")
+	cmd.Flags().Float64Var(&handler.Alpha, "alpha", 0.01, "significance level of the self-test")
+
+	return cmd
+}
+
+// Run draws the spins, prints the report and fails when any test rejects the spins as uniformly random.
+func (h *Handler) Run(cmd *cobra.Command, _ []string) error {
+	var source bet.RandomProvider
+
+	switch h.Source {
+	case rng.SourceCrypto:
+		source = rng.NewCrypto()
+	case rng.SourceSeeded:
+		source = rng.NewSeeded(h.Seed)
+	case SourceFair:
+		source = newFair(fair.NewSeed)
+	default:
+		return rng.ErrSource
+	}
+
+	tests, err := h.test(source)
+	if err != nil {
+		return err
+	}
+
+	if !h.report(cmd.OutOrStdout(), tests) {
+		return ErrFailed
+	}
+
+	return nil
+}
+
+// test draws the spins from the source and returns the chi-square and runs tests of every pocket and color.
+func (h *Handler) test(source bet.RandomProvider) ([]rng.Test, error) {
+	pockets := table.Pockets(h.Wheel)
+	if pockets == 0 {
+		return nil, ErrWheel
+	}
+
+	pocketCounts := make([]int64, pockets)
+	colorCounts := make([]int64, len(colors))
+	pocketRuns := rng.NewRuns(pockets)
+	colorRuns := rng.NewRuns(len(colors))
+
+	colorIndex := make([]int, pockets)
+	colorPockets := make([]int, len(colors))
+
+	for n := range colorIndex {
+		for i := range colors {
+			if bet.Color(n) == colors[i] {
+				colorIndex[n] = i
+				colorPockets[i]++
+			}
+		}
+	}
+
+	for i := 0; i < h.Millions*drawsPerMillion; i++ {
+		n, err := source.Intn(pockets)
+		if err != nil {
+			return nil, err
+		}
+
+		pocketCounts[n]++
+		colorCounts[colorIndex[n]]++
+		pocketRuns.Add(n)
+		colorRuns.Add(colorIndex[n])
+	}
+
+	pocketProportions := make([]float64, pockets)
+	for n := range pocketProportions {
+		pocketProportions[n] = 1 / float64(pockets)
+	}
+
+	colorProportions := make([]float64, len(colors))
+	for i := range colorProportions {
+		colorProportions[i] = float64(colorPockets[i]) / float64(pockets)
+	}
+
+	tests := []rng.Test{
+		rng.ChiSquare("chi-square pockets", pocketCounts, pocketProportions),
+		rng.ChiSquare("chi-square colors", colorCounts, colorProportions),
+	}
+
+	for n := 0; n < pockets; n++ {
+		tests = append(tests, pocketRuns.Test("runs pocket "+round.Pocket(n), n))
+	}
+
+	for i := range colors {
+		tests = append(tests, colorRuns.Test("runs "+colors[i], i))
+	}
+
+	return tests, nil
+}
+
+// report writes a line for every test and the overall result, reporting whether every test passed.
+func (h *Handler) report(w io.Writer, tests []rng.Test) bool {
+	alpha := h.Alpha / float64(len(tests))
+	passed := true
+
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+
+	_, _ = fmt.Fprintf(tw, "rng-test: %d draws from %s on a %s wheel\n", h.Millions*drawsPerMillion, h.Source, h.Wheel)
+	_, _ = fmt.Fprintf(tw, "significance: %g, %.3g per test over %d tests\n\n", h.Alpha, alpha, len(tests))
+	_, _ = fmt.Fprintln(tw, "TEST\tSTATISTIC\tP-VALUE\tRESULT")
+
+	for i := range tests {
+		result := "PASS"
+		if !tests[i].Passed(alpha) {
+			result = "FAIL"
+			passed = false
+		}
+
+		_, _ = fmt.Fprintf(tw, "%s\t%.4f\t%.4f\t%s\n", tests[i].Name, tests[i].Statistic, tests[i].P, result)
+	}
+
+	result := "PASS"
+	if !passed {
+		result = "FAIL"
+	}
+
+	_, _ = fmt.Fprintf(tw, "\nRESULT: %s\n", result)
+	_ = tw.Flush()
+
+	return passed
+}
+
+// fairSource draws every spin with fair.Outcome from a new server seed, a client seed and a nonce of 0, just as the
+// spin of a provably fair round is derived.
+type fairSource struct {
+	seed func() (string, error)
+}
+
+// newFair initializes a fairSource that takes the server seed of each spin from seed.
+func newFair(seed func() (string, error)) fairSource {
+	return fairSource{
+		seed: seed,
+	}
+}
+
+// Intn returns the provably fair outcome in [0, n) of a new server seed.
+func (f fairSource) Intn(n int) (int, error) {
+	serverSeed, err := f.seed()
+	if err != nil {
+		return 0, err
+	}
+
+	return fair.Outcome(serverSeed, fairClientSeed, 0, n), nil
+}
@@ -0,0 +1,130 @@
+package rngtest
+
+import (
+	"bytes"
+	"math"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/clarke94/roulette-service/internal/pkg/bet"
+	"github.com/clarke94/roulette-service/internal/pkg/rng"
+	"github.com/clarke94/roulette-service/internal/pkg/table"
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+)
+
+func TestHandler_test(t *testing.T) {
+	sequence := make([]int, 37)
+	for n := range sequence {
+		sequence[n] = n
+	}
+
+	tests := []struct {
+		name       string
+		wheel      string
+		source     bet.RandomProvider
+		wantCount  int
+		wantPassed bool
+		wantErr    error
+	}{
+		{
+			name:       "expect pass given seeded source on a European wheel",
+			wheel:      table.WheelEuropean,
+			source:     rng.NewSeeded(42),
+			wantCount:  42,
+			wantPassed: true,
+			wantErr:    nil,
+		},
+		{
+			name:       "expect pass given seeded source on an American wheel",
+			wheel:      table.WheelAmerican,
+			source:     rng.NewSeeded(42),
+			wantCount:  43,
+			wantPassed: true,
+			wantErr:    nil,
+		},
+		{
+			name:       "expect pass given fair source on a European wheel",
+			wheel:      table.WheelEuropean,
+			source:     newFair(mockSeeds{GivenSource: rng.NewSeeded(42)}.Seed),
+			wantCount:  42,
+			wantPassed: true,
+			wantErr:    nil,
+		},
+		{
+			name:       "expect fail given every pocket drawn in turn",
+			wheel:      table.WheelEuropean,
+			source:     rng.NewScripted(sequence...),
+			wantCount:  42,
+			wantPassed: false,
+			wantErr:    nil,
+		},
+		{
+			name:       "expect fail given the last pocket is never drawn",
+			wheel:      table.WheelEuropean,
+			source:     mockRandom{GivenSource: rng.NewSeeded(7)},
+			wantCount:  42,
+			wantPassed: false,
+			wantErr:    nil,
+		},
+		{
+			name:    "expect error given unsupported wheel",
+			wheel:   "foo",
+			source:  rng.NewSeeded(42),
+			wantErr: ErrWheel,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := &Handler{Millions: 1, Wheel: tt.wheel, Source: rng.SourceSeeded, Alpha: 0.01}
+
+			got, err := h.test(tt.source)
+			if !cmp.Equal(err, tt.wantErr, cmpopts.EquateErrors()) {
+				t.Fatal(cmp.Diff(err, tt.wantErr, cmpopts.EquateErrors()))
+			}
+
+			if !cmp.Equal(len(got), tt.wantCount) {
+				t.Error(cmp.Diff(len(got), tt.wantCount))
+			}
+
+			if err != nil {
+				return
+			}
+
+			var out bytes.Buffer
+
+			passed := h.report(&out, got)
+			if !cmp.Equal(passed, tt.wantPassed) {
+				t.Error(out.String())
+			}
+
+			if !strings.Contains(out.String(), "RESULT: ") {
+				t.Error(out.String())
+			}
+		})
+	}
+}
+
+// mockRandom draws from the given source but can never land on the last pocket.
+type mockRandom struct {
+	GivenSource bet.RandomProvider
+}
+
+func (m mockRandom) Intn(n int) (int, error) {
+	return m.GivenSource.Intn(n - 1)
+}
+
+// mockSeeds returns repeatable server seeds drawn from the given source.
+type mockSeeds struct {
+	GivenSource bet.RandomProvider
+}
+
+func (m mockSeeds) Seed() (string, error) {
+	n, err := m.GivenSource.Intn(math.MaxInt32)
+	if err != nil {
+		return "", err
+	}
+
+	return strconv.Itoa(n), nil
+}
@@ -4,6 +4,8 @@ import (
 	"context"
 	"net/http"
 
+	"github.com/clarke94/roulette-service/cmd/serve/patch"
+	"github.com/clarke94/roulette-service/cmd/serve/response"
 	"github.com/clarke94/roulette-service/internal/pkg/bet"
 	"github.com/gin-gonic/gin"
 )
@@ -11,9 +13,11 @@ import (
 // ControllerProvider provides an interface for the domain controller.
 type ControllerProvider interface {
 	Create(ctx context.Context, model bet.Bet) (string, error)
-	List(ctx context.Context, tableID string) ([]bet.Bet, error)
+	List(ctx context.Context, tableID string, filter bet.Filter) ([]bet.Bet, string, error)
+	Get(ctx context.Context, tableID, id string) (bet.Bet, error)
+	ListPlayer(ctx context.Context, playerID string, filter bet.Filter) ([]bet.Bet, string, error)
 	Update(ctx context.Context, model bet.Bet) (string, error)
-	Delete(ctx context.Context, tableID, id string) (string, error)
+	Delete(ctx context.Context, tableID, id, playerID string) (string, error)
 	Play(ctx context.Context, tableID string) (bet.Result, error)
 }
 
@@ -32,18 +36,22 @@ func NewHandler(controller ControllerProvider) Handler {
 // Create invokes the Create controller and returns response.
 func (h Handler) Create(ctx *gin.Context) {
 	var params TableParam
-	if err := ctx.BindUri(&params); err != nil {
+	if err := ctx.ShouldBindUri(&params); err != nil {
+		response.AbortBinding(ctx, err)
+
 		return
 	}
 
 	var model Bet
-	if err := ctx.BindJSON(&model); err != nil {
+	if err := ctx.ShouldBindJSON(&model); err != nil {
+		response.AbortBinding(ctx, err)
+
 		return
 	}
 
 	id, err := h.Controller.Create(ctx, presentationToDomain(model, params.Table))
 	if err != nil {
-		ctx.AbortWithStatusJSON(http.StatusBadRequest, Error{Error: err.Error()})
+		response.Abort(ctx, err)
 
 		return
 	}
@@ -54,65 +62,219 @@ func (h Handler) Create(ctx *gin.Context) {
 // List invokes the List controller and returns response.
 func (h Handler) List(ctx *gin.Context) {
 	var params TableParam
-	if err := ctx.BindUri(&params); err != nil {
+	if err := ctx.ShouldBindUri(&params); err != nil {
+		response.AbortBinding(ctx, err)
+
+		return
+	}
+
+	var query Query
+	if err := ctx.ShouldBindQuery(&query); err != nil {
+		response.AbortBinding(ctx, err)
+
+		return
+	}
+
+	bets, next, err := h.Controller.List(ctx, params.Table, queryToDomain(query))
+	if err != nil {
+		response.Abort(ctx, err)
+
+		return
+	}
+
+	response.NextCursor(ctx, next)
+
+	ctx.JSON(http.StatusOK, domainListToPresentation(bets))
+}
+
+// Get invokes the Get controller and returns response, or 304 Not Modified when the client already has the bet.
+func (h Handler) Get(ctx *gin.Context) {
+	var tableParam TableParam
+	if err := ctx.ShouldBindUri(&tableParam); err != nil {
+		response.AbortBinding(ctx, err)
+
+		return
+	}
+
+	var betParam IDParam
+	if err := ctx.ShouldBindUri(&betParam); err != nil {
+		response.AbortBinding(ctx, err)
+
 		return
 	}
 
-	bets, err := h.Controller.List(ctx, params.Table)
+	model, err := h.Controller.Get(ctx, tableParam.Table, betParam.Bet)
 	if err != nil {
-		ctx.AbortWithStatusJSON(http.StatusBadRequest, Error{Error: err.Error()})
+		response.Abort(ctx, err)
+
+		return
+	}
+
+	response.JSONWithETag(ctx, http.StatusOK, model.Version, domainToPresentation(&model))
+}
+
+// ListPlayer invokes the ListPlayer controller and returns response.
+func (h Handler) ListPlayer(ctx *gin.Context) {
+	var params PlayerParam
+	if err := ctx.ShouldBindUri(&params); err != nil {
+		response.AbortBinding(ctx, err)
 
 		return
 	}
 
+	var query Query
+	if err := ctx.ShouldBindQuery(&query); err != nil {
+		response.AbortBinding(ctx, err)
+
+		return
+	}
+
+	bets, next, err := h.Controller.ListPlayer(ctx, params.Player, queryToDomain(query))
+	if err != nil {
+		response.Abort(ctx, err)
+
+		return
+	}
+
+	response.NextCursor(ctx, next)
+
 	ctx.JSON(http.StatusOK, domainListToPresentation(bets))
 }
 
-// Update invokes the Update controller and returns response.
+// Update invokes the Update controller with the version of the bet held in If-Match and returns response along with
+// the ETag of the new version.
 func (h Handler) Update(ctx *gin.Context) {
 	var params TableParam
-	if err := ctx.BindUri(&params); err != nil {
+	if err := ctx.ShouldBindUri(&params); err != nil {
+		response.AbortBinding(ctx, err)
+
 		return
 	}
 
 	var model Update
-	if err := ctx.BindJSON(&model); err != nil {
+	if err := ctx.ShouldBindJSON(&model); err != nil {
+		response.AbortBinding(ctx, err)
+
+		return
+	}
+
+	version, err := response.IfMatch(ctx)
+	if err != nil {
+		response.Abort(ctx, err)
+
+		return
+	}
+
+	model.Bet.ID = model.ID
+	domainModel := presentationToDomain(model.Bet, params.Table)
+	domainModel.Version = version
+
+	id, err := h.Controller.Update(ctx, domainModel)
+	if err != nil {
+		response.Abort(ctx, err)
+
+		return
+	}
+
+	ctx.Header(response.HeaderETag, response.ETag(version+1))
+	ctx.JSON(http.StatusOK, Upsert{ID: id})
+}
+
+// Patch applies the JSON Merge Patch in the request body to the bet, validates the result like a new bet and invokes
+// the Update controller with the version of the bet held in If-Match.
+// Only the player that placed the bet can patch it, so the player is taken from the query rather than from the bet.
+func (h Handler) Patch(ctx *gin.Context) {
+	var tableParam TableParam
+	if err := ctx.ShouldBindUri(&tableParam); err != nil {
+		response.AbortBinding(ctx, err)
+
+		return
+	}
+
+	var betParam IDParam
+	if err := ctx.ShouldBindUri(&betParam); err != nil {
+		response.AbortBinding(ctx, err)
+
+		return
+	}
+
+	var playerQuery PlayerQuery
+	if err := ctx.ShouldBindQuery(&playerQuery); err != nil {
+		response.AbortBinding(ctx, err)
+
+		return
+	}
+
+	version, err := response.IfMatch(ctx)
+	if err != nil {
+		response.Abort(ctx, err)
+
 		return
 	}
 
-	domainModel := presentationToDomain(Bet{
-		ID:       model.ID,
-		Bet:      model.Bet.Bet,
-		Type:     model.Bet.Type,
-		Amount:   model.Bet.Amount,
-		Currency: model.Bet.Currency,
-	}, params.Table)
+	current, err := h.Controller.Get(ctx, tableParam.Table, betParam.Bet)
+	if err != nil {
+		response.Abort(ctx, err)
+
+		return
+	}
+
+	if current.PlayerID != playerQuery.Player {
+		response.Abort(ctx, bet.ErrOwner)
+
+		return
+	}
+
+	var model Bet
+	if err := patch.Bind(ctx, domainToPresentation(&current), &model); err != nil {
+		response.AbortBinding(ctx, err)
+
+		return
+	}
+
+	model.ID = betParam.Bet
+	model.PlayerID = playerQuery.Player
+
+	domainModel := presentationToDomain(model, tableParam.Table)
+	domainModel.Version = version
 
 	id, err := h.Controller.Update(ctx, domainModel)
 	if err != nil {
-		ctx.AbortWithStatusJSON(http.StatusBadRequest, Error{Error: err.Error()})
+		response.Abort(ctx, err)
 
 		return
 	}
 
+	ctx.Header(response.HeaderETag, response.ETag(version+1))
 	ctx.JSON(http.StatusOK, Upsert{ID: id})
 }
 
 // Delete invokes the Delete controller and returns an id.
 func (h Handler) Delete(ctx *gin.Context) {
 	var tableParam TableParam
-	if err := ctx.BindUri(&tableParam); err != nil {
+	if err := ctx.ShouldBindUri(&tableParam); err != nil {
+		response.AbortBinding(ctx, err)
+
 		return
 	}
 
 	var betParam IDParam
-	if err := ctx.BindUri(&betParam); err != nil {
+	if err := ctx.ShouldBindUri(&betParam); err != nil {
+		response.AbortBinding(ctx, err)
+
+		return
+	}
+
+	var playerQuery PlayerQuery
+	if err := ctx.ShouldBindQuery(&playerQuery); err != nil {
+		response.AbortBinding(ctx, err)
+
 		return
 	}
 
-	deletedID, err := h.Controller.Delete(ctx, tableParam.Table, betParam.Bet)
+	deletedID, err := h.Controller.Delete(ctx, tableParam.Table, betParam.Bet, playerQuery.Player)
 	if err != nil {
-		ctx.AbortWithStatusJSON(http.StatusBadRequest, Error{Error: err.Error()})
+		response.Abort(ctx, err)
 
 		return
 	}
@@ -123,13 +285,15 @@ func (h Handler) Delete(ctx *gin.Context) {
 // Play invokes the Play controller and returns response.
 func (h Handler) Play(ctx *gin.Context) {
 	var params TableParam
-	if err := ctx.BindUri(&params); err != nil {
+	if err := ctx.ShouldBindUri(&params); err != nil {
+		response.AbortBinding(ctx, err)
+
 		return
 	}
 
 	results, err := h.Controller.Play(ctx, params.Table)
 	if err != nil {
-		ctx.AbortWithStatusJSON(http.StatusBadRequest, Error{Error: err.Error()})
+		response.Abort(ctx, err)
 
 		return
 	}
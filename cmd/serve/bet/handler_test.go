@@ -3,12 +3,18 @@ package bet
 import (
 	"bytes"
 	"context"
+	"encoding/json"
 	"errors"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 
+	"github.com/clarke94/roulette-service/cmd/serve/patch"
+	"github.com/clarke94/roulette-service/cmd/serve/response"
+	"github.com/clarke94/roulette-service/internal/pkg/apperror"
 	"github.com/clarke94/roulette-service/internal/pkg/bet"
+	"github.com/clarke94/roulette-service/internal/pkg/page"
 	"github.com/gin-gonic/gin"
 	"github.com/google/go-cmp/cmp"
 	"github.com/google/uuid"
@@ -41,11 +47,12 @@ func TestNewHandler(t *testing.T) {
 
 func TestHandler_Create(t *testing.T) {
 	tests := []struct {
-		name       string
-		controller ControllerProvider
-		tableId    string
-		body       []byte
-		wantCode   int
+		name        string
+		controller  ControllerProvider
+		tableId     string
+		body        []byte
+		wantCode    int
+		wantDetails []response.Detail
 	}{
 		{
 			name: "expect 201 given bet created",
@@ -53,35 +60,77 @@ func TestHandler_Create(t *testing.T) {
 				GivenID: uuid.New().String(),
 			},
 			tableId:  uuid.New().String(),
-			body:     []byte(`{"bet":"foo", "type":"foo", "amount": 10, "currency": "GBP"}`),
+			body:     []byte(`{"playerId":"9b2f3c1e-5a4d-4e7f-8c6b-1d2e3f4a5b6c", "bet":"17", "type":"straight", "amount": 10, "currency": "GBP"}`),
+			wantCode: http.StatusCreated,
+		},
+		{
+			name: "expect 201 given client seed",
+			controller: mockController{
+				GivenID: uuid.New().String(),
+			},
+			tableId:  uuid.New().String(),
+			body:     []byte(`{"playerId":"9b2f3c1e-5a4d-4e7f-8c6b-1d2e3f4a5b6c", "bet":"17", "type":"straight", "amount": 10, "currency": "GBP", "clientSeed": "client"}`),
 			wantCode: http.StatusCreated,
 		},
 		{
-			name: "expect 400 given invalid table request",
+			name:       "expect 422 given client seed too long",
+			controller: mockController{},
+			tableId:    uuid.New().String(),
+			body:       []byte(`{"playerId":"9b2f3c1e-5a4d-4e7f-8c6b-1d2e3f4a5b6c", "bet":"17", "type":"straight", "amount": 10, "currency": "GBP", "clientSeed": "` + strings.Repeat("a", 65) + `"}`),
+			wantCode:   http.StatusUnprocessableEntity,
+		},
+		{
+			name:       "expect 422 given client seed with a comma",
+			controller: mockController{},
+			tableId:    uuid.New().String(),
+			body:       []byte(`{"playerId":"9b2f3c1e-5a4d-4e7f-8c6b-1d2e3f4a5b6c", "bet":"17", "type":"straight", "amount": 10, "currency": "GBP", "clientSeed": "a,b"}`),
+			wantCode:   http.StatusUnprocessableEntity,
+		},
+		{
+			name: "expect 422 given invalid table request",
 			controller: mockController{
 				GivenID: uuid.New().String(),
 			},
 			tableId:  uuid.New().String(),
 			body:     []byte(`{}`),
-			wantCode: http.StatusBadRequest,
+			wantCode: http.StatusUnprocessableEntity,
 		},
 		{
-			name: "expect 400 given invalid table ID",
+			name: "expect 422 given invalid table ID",
 			controller: mockController{
 				GivenID: uuid.New().String(),
 			},
 			tableId:  "foo",
-			body:     []byte(`{"bet":"foo", "type":"foo", "amount": 10, "currency": "GBP"}`),
-			wantCode: http.StatusBadRequest,
+			body:     []byte(`{"playerId":"9b2f3c1e-5a4d-4e7f-8c6b-1d2e3f4a5b6c", "bet":"17", "type":"straight", "amount": 10, "currency": "GBP"}`),
+			wantCode: http.StatusUnprocessableEntity,
 		},
 		{
-			name: "expect 400 given Controller error",
+			name: "expect 500 given Controller error",
 			controller: mockController{
 				GivenError: errors.New("foo"),
 			},
 			tableId:  uuid.New().String(),
-			body:     []byte(`{"bet":"foo", "type":"foo", "amount": 10, "currency": "GBP"}`),
-			wantCode: http.StatusBadRequest,
+			body:     []byte(`{"playerId":"9b2f3c1e-5a4d-4e7f-8c6b-1d2e3f4a5b6c", "bet":"17", "type":"straight", "amount": 10, "currency": "GBP"}`),
+			wantCode: http.StatusInternalServerError,
+		},
+		{
+			name: "expect 409 given round closed",
+			controller: mockController{
+				GivenError: bet.ErrClosed,
+			},
+			tableId:  uuid.New().String(),
+			body:     []byte(`{"playerId":"9b2f3c1e-5a4d-4e7f-8c6b-1d2e3f4a5b6c", "bet":"17", "type":"straight", "amount": 10, "currency": "GBP"}`),
+			wantCode: http.StatusConflict,
+		},
+		{
+			name: "expect 422 with details given validation error",
+			controller: mockController{
+				GivenError: apperror.WithFields(bet.ErrBet, apperror.Field{Field: "bet", Message: "foo"}),
+			},
+			tableId:     uuid.New().String(),
+			body:        []byte(`{"playerId":"9b2f3c1e-5a4d-4e7f-8c6b-1d2e3f4a5b6c", "bet":"37", "type":"straight", "amount": 10, "currency": "GBP"}`),
+			wantCode:    http.StatusUnprocessableEntity,
+			wantDetails: []response.Detail{{Field: "bet", Message: "foo"}},
 		},
 	}
 	for _, tt := range tests {
@@ -99,6 +148,17 @@ func TestHandler_Create(t *testing.T) {
 			if !cmp.Equal(w.Code, tt.wantCode) {
 				t.Error(w.Code, tt.wantCode)
 			}
+
+			if tt.wantDetails == nil {
+				return
+			}
+
+			var got response.Error
+			_ = json.Unmarshal(w.Body.Bytes(), &got)
+
+			if !cmp.Equal(got.Details, tt.wantDetails) {
+				t.Error(cmp.Diff(got.Details, tt.wantDetails))
+			}
 		})
 	}
 }
@@ -108,7 +168,9 @@ func TestHandler_List(t *testing.T) {
 		name       string
 		controller ControllerProvider
 		tableId    string
+		query      string
 		wantCode   int
+		wantNext   string
 	}{
 		{
 			name: "expect 200 given bet created",
@@ -122,32 +184,65 @@ func TestHandler_List(t *testing.T) {
 						Currency: "GBP",
 					},
 				},
+				GivenNext: "foo",
 			},
 			tableId:  uuid.New().String(),
+			query:    "?limit=10&type=red/black&currency=GBP&minAmount=10&maxAmount=100&order=desc",
 			wantCode: http.StatusOK,
+			wantNext: "foo",
+		},
+		{
+			name:       "expect 422 given invalid bet type",
+			controller: mockController{GivenError: bet.ErrType},
+			tableId:    uuid.New().String(),
+			query:      "?type=foo",
+			wantCode:   http.StatusUnprocessableEntity,
+		},
+		{
+			name:       "expect 422 given amount range reversed",
+			controller: mockController{},
+			tableId:    uuid.New().String(),
+			query:      "?minAmount=100&maxAmount=10",
+			wantCode:   http.StatusUnprocessableEntity,
+		},
+		{
+			name:       "expect 422 given invalid player ID",
+			controller: mockController{},
+			tableId:    uuid.New().String(),
+			query:      "?playerId=foo",
+			wantCode:   http.StatusUnprocessableEntity,
+		},
+		{
+			name: "expect 422 given invalid cursor",
+			controller: mockController{
+				GivenError: page.ErrCursor,
+			},
+			tableId:  uuid.New().String(),
+			query:    "?cursor=foo",
+			wantCode: http.StatusUnprocessableEntity,
 		},
 		{
-			name: "expect 400 given invalid table ID",
+			name: "expect 422 given invalid table ID",
 			controller: mockController{
 				GivenError: errors.New("foo"),
 			},
 			tableId:  "foo",
-			wantCode: http.StatusBadRequest,
+			wantCode: http.StatusUnprocessableEntity,
 		},
 		{
-			name: "expect 400 given Controller error",
+			name: "expect 500 given Controller error",
 			controller: mockController{
 				GivenError: errors.New("foo"),
 			},
 			tableId:  uuid.New().String(),
-			wantCode: http.StatusBadRequest,
+			wantCode: http.StatusInternalServerError,
 		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			h := NewHandler(tt.controller)
 
-			r := httptest.NewRequest(http.MethodGet, "/"+tt.tableId, nil)
+			r := httptest.NewRequest(http.MethodGet, "/"+tt.tableId+tt.query, nil)
 			w := httptest.NewRecorder()
 			ctx, router := gin.CreateTestContext(w)
 			ctx.Request = r
@@ -158,17 +253,214 @@ func TestHandler_List(t *testing.T) {
 			if !cmp.Equal(w.Code, tt.wantCode) {
 				t.Error(w.Code, tt.wantCode)
 			}
+
+			if !cmp.Equal(w.Header().Get(response.HeaderNextCursor), tt.wantNext) {
+				t.Error(cmp.Diff(w.Header().Get(response.HeaderNextCursor), tt.wantNext))
+			}
 		})
 	}
 }
 
-func TestHandler_Update(t *testing.T) {
+func TestHandler_Get(t *testing.T) {
+	found := bet.Bet{
+		ID:       uuid.New().String(),
+		TableID:  uuid.New().String(),
+		RoundID:  uuid.New().String(),
+		PlayerID: uuid.New().String(),
+		Bet:      "17",
+		Type:     bet.TypeStraight,
+		Amount:   100,
+		Currency: "GBP",
+		Version:  3,
+	}
+
+	tag := response.ETag(found.Version)
+
+	tests := []struct {
+		name        string
+		controller  ControllerProvider
+		tableId     string
+		id          string
+		ifNoneMatch string
+		wantCode    int
+		wantETag    string
+	}{
+		{
+			name:       "expect 200 given bet found",
+			controller: mockController{GivenBet: found},
+			tableId:    found.TableID,
+			id:         found.ID,
+			wantCode:   http.StatusOK,
+			wantETag:   tag,
+		},
+		{
+			name:        "expect 304 given bet not modified",
+			controller:  mockController{GivenBet: found},
+			tableId:     found.TableID,
+			id:          found.ID,
+			ifNoneMatch: tag,
+			wantCode:    http.StatusNotModified,
+			wantETag:    tag,
+		},
+		{
+			name:       "expect 422 given invalid table ID",
+			controller: mockController{},
+			tableId:    "foo",
+			id:         found.ID,
+			wantCode:   http.StatusUnprocessableEntity,
+		},
+		{
+			name:       "expect 422 given invalid ID",
+			controller: mockController{},
+			tableId:    found.TableID,
+			id:         "foo",
+			wantCode:   http.StatusUnprocessableEntity,
+		},
+		{
+			name: "expect 404 given bet not found",
+			controller: mockController{
+				GivenError: bet.ErrNotFound,
+			},
+			tableId:  found.TableID,
+			id:       found.ID,
+			wantCode: http.StatusNotFound,
+		},
+		{
+			name: "expect 500 given Controller error",
+			controller: mockController{
+				GivenError: errors.New("foo"),
+			},
+			tableId:  found.TableID,
+			id:       found.ID,
+			wantCode: http.StatusInternalServerError,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := NewHandler(tt.controller)
+
+			r := httptest.NewRequest(http.MethodGet, "/"+tt.tableId+"/"+tt.id, nil)
+			if tt.ifNoneMatch != "" {
+				r.Header.Set(response.HeaderIfNoneMatch, tt.ifNoneMatch)
+			}
+
+			w := httptest.NewRecorder()
+			ctx, router := gin.CreateTestContext(w)
+			ctx.Request = r
+
+			router.Handle(http.MethodGet, "/:table/:bet", h.Get)
+			router.ServeHTTP(w, r)
+
+			if !cmp.Equal(w.Code, tt.wantCode) {
+				t.Error(w.Code, tt.wantCode)
+			}
+
+			if !cmp.Equal(w.Header().Get(response.HeaderETag), tt.wantETag) {
+				t.Error(cmp.Diff(w.Header().Get(response.HeaderETag), tt.wantETag))
+			}
+		})
+	}
+}
+
+func TestHandler_ListPlayer(t *testing.T) {
 	tests := []struct {
 		name       string
 		controller ControllerProvider
-		tableId    string
-		body       []byte
+		playerID   string
+		query      string
 		wantCode   int
+		wantNext   string
+	}{
+		{
+			name: "expect 200 given bets found",
+			controller: mockController{
+				GivenList: []bet.Bet{
+					{
+						ID:       uuid.New().String(),
+						TableID:  uuid.New().String(),
+						PlayerID: uuid.New().String(),
+						Bet:      "17",
+						Type:     bet.TypeStraight,
+						Amount:   10,
+						Currency: "GBP",
+					},
+				},
+				GivenNext: "foo",
+			},
+			playerID: uuid.New().String(),
+			query:    "?limit=10&type=straight&order=desc",
+			wantCode: http.StatusOK,
+			wantNext: "foo",
+		},
+		{
+			name:       "expect 422 given invalid limit",
+			controller: mockController{},
+			playerID:   uuid.New().String(),
+			query:      "?limit=101",
+			wantCode:   http.StatusUnprocessableEntity,
+		},
+		{
+			name:       "expect 422 given invalid bet type",
+			controller: mockController{GivenError: bet.ErrType},
+			playerID:   uuid.New().String(),
+			query:      "?type=foo",
+			wantCode:   http.StatusUnprocessableEntity,
+		},
+		{
+			name:       "expect 422 given invalid cursor",
+			controller: mockController{GivenError: page.ErrCursor},
+			playerID:   uuid.New().String(),
+			query:      "?cursor=foo",
+			wantCode:   http.StatusUnprocessableEntity,
+		},
+		{
+			name:       "expect 422 given invalid player ID",
+			controller: mockController{},
+			playerID:   "foo",
+			wantCode:   http.StatusUnprocessableEntity,
+		},
+		{
+			name: "expect 500 given Controller error",
+			controller: mockController{
+				GivenError: errors.New("foo"),
+			},
+			playerID: uuid.New().String(),
+			wantCode: http.StatusInternalServerError,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := NewHandler(tt.controller)
+
+			r := httptest.NewRequest(http.MethodGet, "/"+tt.playerID+"/bets"+tt.query, nil)
+			w := httptest.NewRecorder()
+			ctx, router := gin.CreateTestContext(w)
+			ctx.Request = r
+
+			router.Handle(http.MethodGet, "/:player/bets", h.ListPlayer)
+			router.ServeHTTP(w, r)
+
+			if !cmp.Equal(w.Code, tt.wantCode) {
+				t.Error(w.Code, tt.wantCode)
+			}
+
+			if !cmp.Equal(w.Header().Get(response.HeaderNextCursor), tt.wantNext) {
+				t.Error(cmp.Diff(w.Header().Get(response.HeaderNextCursor), tt.wantNext))
+			}
+		})
+	}
+}
+
+func TestHandler_Update(t *testing.T) {
+	tests := []struct {
+		name           string
+		controller     mockController
+		tableId        string
+		body           []byte
+		ifMatch        string
+		wantCode       int
+		wantETag       string
+		wantClientSeed string
 	}{
 		{
 			name: "expect 200 given bet updated",
@@ -176,42 +468,94 @@ func TestHandler_Update(t *testing.T) {
 				GivenID: uuid.New().String(),
 			},
 			tableId:  uuid.New().String(),
-			body:     []byte(`{"id":"42bb1490-d28e-11eb-b8bc-0242ac130003", "bet":"foo", "type":"foo", "amount": 10, "currency": "GBP"}`),
+			body:     []byte(`{"id":"42bb1490-d28e-11eb-b8bc-0242ac130003", "playerId":"9b2f3c1e-5a4d-4e7f-8c6b-1d2e3f4a5b6c", "bet":"17", "type":"straight", "amount": 10, "currency": "GBP"}`),
+			ifMatch:  `"1"`,
 			wantCode: http.StatusOK,
+			wantETag: `"2"`,
 		},
 		{
-			name: "expect 400 given invalid table ID",
+			name: "expect client seed kept given bet updated with client seed",
+			controller: mockController{
+				GivenID: uuid.New().String(),
+			},
+			tableId:        uuid.New().String(),
+			body:           []byte(`{"id":"42bb1490-d28e-11eb-b8bc-0242ac130003", "playerId":"9b2f3c1e-5a4d-4e7f-8c6b-1d2e3f4a5b6c", "bet":"17", "type":"straight", "amount": 10, "currency": "GBP", "clientSeed": "alice"}`),
+			ifMatch:        `"1"`,
+			wantCode:       http.StatusOK,
+			wantETag:       `"2"`,
+			wantClientSeed: "alice",
+		},
+		{
+			name: "expect 422 given invalid table ID",
 			controller: mockController{
 				GivenID: uuid.New().String(),
 			},
 			tableId:  "foo",
-			body:     []byte(`{"id":"42bb1490-d28e-11eb-b8bc-0242ac130003", "bet":"foo", "type":"foo", "amount": 10, "currency": "GBP"}`),
-			wantCode: http.StatusBadRequest,
+			body:     []byte(`{"id":"42bb1490-d28e-11eb-b8bc-0242ac130003", "playerId":"9b2f3c1e-5a4d-4e7f-8c6b-1d2e3f4a5b6c", "bet":"17", "type":"straight", "amount": 10, "currency": "GBP"}`),
+			ifMatch:  `"1"`,
+			wantCode: http.StatusUnprocessableEntity,
 		},
 		{
-			name: "expect 400 given invalid request",
+			name: "expect 422 given invalid request",
 			controller: mockController{
 				GivenID: uuid.New().String(),
 			},
 			tableId:  uuid.New().String(),
 			body:     []byte(`{}`),
-			wantCode: http.StatusBadRequest,
+			ifMatch:  `"1"`,
+			wantCode: http.StatusUnprocessableEntity,
+		},
+		{
+			name: "expect 428 given no If-Match",
+			controller: mockController{
+				GivenID: uuid.New().String(),
+			},
+			tableId:  uuid.New().String(),
+			body:     []byte(`{"id":"42bb1490-d28e-11eb-b8bc-0242ac130003", "playerId":"9b2f3c1e-5a4d-4e7f-8c6b-1d2e3f4a5b6c", "bet":"17", "type":"straight", "amount": 10, "currency": "GBP"}`),
+			wantCode: http.StatusPreconditionRequired,
+		},
+		{
+			name: "expect 412 given bet has changed",
+			controller: mockController{
+				GivenError: bet.ErrVersion,
+			},
+			tableId:  uuid.New().String(),
+			body:     []byte(`{"id":"42bb1490-d28e-11eb-b8bc-0242ac130003", "playerId":"9b2f3c1e-5a4d-4e7f-8c6b-1d2e3f4a5b6c", "bet":"17", "type":"straight", "amount": 10, "currency": "GBP"}`),
+			ifMatch:  `"1"`,
+			wantCode: http.StatusPreconditionFailed,
 		},
 		{
-			name: "expect 400 given Controller error",
+			name: "expect 403 given bet owned by another player",
+			controller: mockController{
+				GivenError: bet.ErrOwner,
+			},
+			tableId:  uuid.New().String(),
+			body:     []byte(`{"id":"42bb1490-d28e-11eb-b8bc-0242ac130003", "playerId":"9b2f3c1e-5a4d-4e7f-8c6b-1d2e3f4a5b6c", "bet":"17", "type":"straight", "amount": 10, "currency": "GBP"}`),
+			ifMatch:  `"1"`,
+			wantCode: http.StatusForbidden,
+		},
+		{
+			name: "expect 500 given Controller error",
 			controller: mockController{
 				GivenError: errors.New("foo"),
 			},
 			tableId:  uuid.New().String(),
-			body:     []byte((`{"id":"42bb1490-d28e-11eb-b8bc-0242ac130003", "bet":"foo", "type":"foo", "amount": 10, "currency": "GBP"}`)),
-			wantCode: http.StatusBadRequest,
+			body:     []byte(`{"id":"42bb1490-d28e-11eb-b8bc-0242ac130003", "playerId":"9b2f3c1e-5a4d-4e7f-8c6b-1d2e3f4a5b6c", "bet":"17", "type":"straight", "amount": 10, "currency": "GBP"}`),
+			ifMatch:  `"1"`,
+			wantCode: http.StatusInternalServerError,
 		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
+			var updated bet.Bet
+			tt.controller.Updated = &updated
 			h := NewHandler(tt.controller)
 
 			r := httptest.NewRequest(http.MethodPut, "/"+tt.tableId, bytes.NewReader(tt.body))
+			if tt.ifMatch != "" {
+				r.Header.Set(response.HeaderIfMatch, tt.ifMatch)
+			}
+
 			w := httptest.NewRecorder()
 			ctx, router := gin.CreateTestContext(w)
 			ctx.Request = r
@@ -222,6 +566,201 @@ func TestHandler_Update(t *testing.T) {
 			if !cmp.Equal(w.Code, tt.wantCode) {
 				t.Error(w.Code, tt.wantCode)
 			}
+
+			if !cmp.Equal(w.Header().Get(response.HeaderETag), tt.wantETag) {
+				t.Error(cmp.Diff(w.Header().Get(response.HeaderETag), tt.wantETag))
+			}
+
+			if !cmp.Equal(updated.ClientSeed, tt.wantClientSeed) {
+				t.Error(cmp.Diff(updated.ClientSeed, tt.wantClientSeed))
+			}
+		})
+	}
+}
+
+func TestHandler_Patch(t *testing.T) {
+	current := bet.Bet{
+		ID:       "42bb1490-d28e-11eb-b8bc-0242ac130003",
+		TableID:  "84b10ade-d28a-11eb-b8bc-0242ac130003",
+		RoundID:  uuid.New().String(),
+		PlayerID: "9b2f3c1e-5a4d-4e7f-8c6b-1d2e3f4a5b6c",
+		Bet:      "17",
+		Type:     bet.TypeStraight,
+		Amount:   100,
+		Currency: "GBP",
+		Version:  1,
+	}
+
+	tests := []struct {
+		name        string
+		controller  mockController
+		tableId     string
+		id          string
+		player      string
+		body        string
+		ifMatch     string
+		wantCode    int
+		wantETag    string
+		wantUpdated bet.Bet
+	}{
+		{
+			name:       "expect 200 given patched bet",
+			controller: mockController{GivenID: current.ID, GivenBet: current},
+			tableId:    current.TableID,
+			id:         current.ID,
+			player:     current.PlayerID,
+			body:       `{"bet":"0","amount":250}`,
+			ifMatch:    `"1"`,
+			wantCode:   http.StatusOK,
+			wantETag:   `"2"`,
+			wantUpdated: bet.Bet{
+				ID:       current.ID,
+				TableID:  current.TableID,
+				PlayerID: current.PlayerID,
+				Bet:      "0",
+				Type:     bet.TypeStraight,
+				Amount:   250,
+				Currency: "GBP",
+				Version:  1,
+			},
+		},
+		{
+			name:       "expect 422 given invalid bet ID",
+			controller: mockController{},
+			tableId:    current.TableID,
+			id:         "foo",
+			player:     current.PlayerID,
+			body:       `{"amount":250}`,
+			ifMatch:    `"1"`,
+			wantCode:   http.StatusUnprocessableEntity,
+		},
+		{
+			name:       "expect 422 given required field removed",
+			controller: mockController{GivenBet: current},
+			tableId:    current.TableID,
+			id:         current.ID,
+			player:     current.PlayerID,
+			body:       `{"amount":null}`,
+			ifMatch:    `"1"`,
+			wantCode:   http.StatusUnprocessableEntity,
+		},
+		{
+			name:       "expect 422 given patched bet not valid",
+			controller: mockController{GivenBet: current},
+			tableId:    current.TableID,
+			id:         current.ID,
+			player:     current.PlayerID,
+			body:       `{"amount":0}`,
+			ifMatch:    `"1"`,
+			wantCode:   http.StatusUnprocessableEntity,
+		},
+		{
+			name:       "expect 403 given bet placed by another player",
+			controller: mockController{GivenBet: current},
+			tableId:    current.TableID,
+			id:         current.ID,
+			player:     uuid.New().String(),
+			body:       `{"amount":250}`,
+			ifMatch:    `"1"`,
+			wantCode:   http.StatusForbidden,
+		},
+		{
+			name:       "expect owner kept given patch that hands the bet to another player",
+			controller: mockController{GivenID: current.ID, GivenBet: current},
+			tableId:    current.TableID,
+			id:         current.ID,
+			player:     current.PlayerID,
+			body:       `{"playerId":"` + uuid.New().String() + `"}`,
+			ifMatch:    `"1"`,
+			wantCode:   http.StatusOK,
+			wantETag:   `"2"`,
+			wantUpdated: bet.Bet{
+				ID:       current.ID,
+				TableID:  current.TableID,
+				PlayerID: current.PlayerID,
+				Bet:      "17",
+				Type:     bet.TypeStraight,
+				Amount:   100,
+				Currency: "GBP",
+				Version:  1,
+			},
+		},
+		{
+			name:       "expect 422 given no player",
+			controller: mockController{GivenBet: current},
+			tableId:    current.TableID,
+			id:         current.ID,
+			body:       `{"amount":250}`,
+			ifMatch:    `"1"`,
+			wantCode:   http.StatusUnprocessableEntity,
+		},
+		{
+			name:       "expect 428 given no If-Match",
+			controller: mockController{GivenBet: current},
+			tableId:    current.TableID,
+			id:         current.ID,
+			player:     current.PlayerID,
+			body:       `{"amount":250}`,
+			wantCode:   http.StatusPreconditionRequired,
+		},
+		{
+			name: "expect 404 given bet not found",
+			controller: mockController{
+				GivenError: bet.ErrNotFound,
+			},
+			tableId:  current.TableID,
+			id:       current.ID,
+			player:   current.PlayerID,
+			body:     `{"amount":250}`,
+			ifMatch:  `"1"`,
+			wantCode: http.StatusNotFound,
+		},
+		{
+			name: "expect 500 given Controller error",
+			controller: mockController{
+				GivenError: errors.New("foo"),
+			},
+			tableId:  current.TableID,
+			id:       current.ID,
+			player:   current.PlayerID,
+			body:     `{"amount":250}`,
+			ifMatch:  `"1"`,
+			wantCode: http.StatusInternalServerError,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var updated bet.Bet
+
+			tt.controller.Updated = &updated
+			h := NewHandler(tt.controller)
+
+			target := "/" + tt.tableId + "/bet/" + tt.id + "?player=" + tt.player
+			r := httptest.NewRequest(http.MethodPatch, target, strings.NewReader(tt.body))
+			r.Header.Set("Content-Type", patch.ContentType)
+
+			if tt.ifMatch != "" {
+				r.Header.Set(response.HeaderIfMatch, tt.ifMatch)
+			}
+
+			w := httptest.NewRecorder()
+			ctx, router := gin.CreateTestContext(w)
+			ctx.Request = r
+
+			router.Handle(http.MethodPatch, "/:table/bet/:bet", h.Patch)
+			router.ServeHTTP(w, r)
+
+			if !cmp.Equal(w.Code, tt.wantCode) {
+				t.Error(w.Code, tt.wantCode)
+			}
+
+			if !cmp.Equal(w.Header().Get(response.HeaderETag), tt.wantETag) {
+				t.Error(cmp.Diff(w.Header().Get(response.HeaderETag), tt.wantETag))
+			}
+
+			if !cmp.Equal(updated, tt.wantUpdated) {
+				t.Error(cmp.Diff(updated, tt.wantUpdated))
+			}
 		})
 	}
 }
@@ -232,6 +771,7 @@ func TestHandler_Delete(t *testing.T) {
 		controller ControllerProvider
 		id         string
 		tableId    string
+		playerID   string
 		wantCode   int
 	}{
 		{
@@ -241,37 +781,59 @@ func TestHandler_Delete(t *testing.T) {
 			},
 			id:       uuid.New().String(),
 			tableId:  uuid.New().String(),
+			playerID: uuid.New().String(),
 			wantCode: http.StatusOK,
 		},
 		{
-			name:       "expect 400 given invalid ID",
+			name:       "expect 422 given invalid ID",
 			controller: mockController{},
 			id:         "foo",
 			tableId:    uuid.New().String(),
-			wantCode:   http.StatusBadRequest,
+			playerID:   uuid.New().String(),
+			wantCode:   http.StatusUnprocessableEntity,
 		},
 		{
-			name:       "expect 400 given invalid table ID",
+			name:       "expect 422 given invalid table ID",
 			controller: mockController{},
 			tableId:    "foo",
+			playerID:   uuid.New().String(),
 			id:         uuid.New().String(),
-			wantCode:   http.StatusBadRequest,
+			wantCode:   http.StatusUnprocessableEntity,
 		},
 		{
-			name: "expect 400 given Controller error",
+			name:       "expect 422 given invalid player ID",
+			controller: mockController{},
+			id:         uuid.New().String(),
+			tableId:    uuid.New().String(),
+			playerID:   "foo",
+			wantCode:   http.StatusUnprocessableEntity,
+		},
+		{
+			name: "expect 403 given bet owned by another player",
+			controller: mockController{
+				GivenError: bet.ErrOwner,
+			},
+			id:       uuid.New().String(),
+			tableId:  uuid.New().String(),
+			playerID: uuid.New().String(),
+			wantCode: http.StatusForbidden,
+		},
+		{
+			name: "expect 500 given Controller error",
 			controller: mockController{
 				GivenError: errors.New("foo"),
 			},
 			id:       uuid.New().String(),
 			tableId:  uuid.New().String(),
-			wantCode: http.StatusBadRequest,
+			playerID: uuid.New().String(),
+			wantCode: http.StatusInternalServerError,
 		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			h := NewHandler(tt.controller)
 
-			r := httptest.NewRequest(http.MethodDelete, "/"+tt.tableId+"/"+tt.id, nil)
+			r := httptest.NewRequest(http.MethodDelete, "/"+tt.tableId+"/"+tt.id+"?player="+tt.playerID, nil)
 			w := httptest.NewRecorder()
 			ctx, router := gin.CreateTestContext(w)
 			ctx.Request = r
@@ -301,9 +863,12 @@ func TestHandler_Play(t *testing.T) {
 					Color:  "red",
 					Winners: []bet.Winner{
 						{
-							BetID:    uuid.New().String(),
-							Amount:   10,
-							Currency: "GBP",
+							BetID:      uuid.New().String(),
+							Stake:      10,
+							Multiplier: 35,
+							Winnings:   350,
+							Return:     360,
+							Currency:   "GBP",
 						},
 					},
 				},
@@ -312,18 +877,18 @@ func TestHandler_Play(t *testing.T) {
 			wantCode: http.StatusOK,
 		},
 		{
-			name:       "expect 400 given invalid table ID",
+			name:       "expect 422 given invalid table ID",
 			controller: mockController{},
 			tableId:    "foo",
-			wantCode:   http.StatusBadRequest,
+			wantCode:   http.StatusUnprocessableEntity,
 		},
 		{
-			name: "expect 400 given Controller error",
+			name: "expect 500 given Controller error",
 			controller: mockController{
 				GivenError: errors.New("foo"),
 			},
 			tableId:  uuid.New().String(),
-			wantCode: http.StatusBadRequest,
+			wantCode: http.StatusInternalServerError,
 		},
 	}
 	for _, tt := range tests {
@@ -348,24 +913,39 @@ func TestHandler_Play(t *testing.T) {
 type mockController struct {
 	GivenResult bet.Result
 	GivenList   []bet.Bet
+	GivenNext   string
+	GivenBet    bet.Bet
 	GivenID     string
 	GivenError  error
+	Updated     *bet.Bet
 }
 
 func (m mockController) Play(_ context.Context, _ string) (bet.Result, error) {
 	return m.GivenResult, m.GivenError
 }
 
-func (m mockController) Delete(_ context.Context, _, _ string) (string, error) {
+func (m mockController) Delete(_ context.Context, _, _, _ string) (string, error) {
 	return m.GivenID, m.GivenError
 }
 
-func (m mockController) Update(_ context.Context, _ bet.Bet) (string, error) {
+func (m mockController) Update(_ context.Context, model bet.Bet) (string, error) {
+	if m.Updated != nil {
+		*m.Updated = model
+	}
+
 	return m.GivenID, m.GivenError
 }
 
-func (m mockController) List(_ context.Context, _ string) ([]bet.Bet, error) {
-	return m.GivenList, m.GivenError
+func (m mockController) List(_ context.Context, _ string, _ bet.Filter) ([]bet.Bet, string, error) {
+	return m.GivenList, m.GivenNext, m.GivenError
+}
+
+func (m mockController) Get(_ context.Context, _, _ string) (bet.Bet, error) {
+	return m.GivenBet, m.GivenError
+}
+
+func (m mockController) ListPlayer(_ context.Context, _ string, _ bet.Filter) ([]bet.Bet, string, error) {
+	return m.GivenList, m.GivenNext, m.GivenError
 }
 
 func (m mockController) Create(_ context.Context, _ bet.Bet) (string, error) {
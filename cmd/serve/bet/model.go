@@ -1,7 +1,10 @@
 package bet
 
 import (
+	"time"
+
 	"github.com/clarke94/roulette-service/internal/pkg/bet"
+	"github.com/clarke94/roulette-service/internal/pkg/page"
 )
 
 // TableParam is the URL parameter binding for the table ID associated with a Bet.
@@ -14,13 +17,41 @@ type IDParam struct {
 	Bet string `uri:"bet" binding:"required,uuid"`
 }
 
+// PlayerParam is the URL parameter binding for the player that placed a Bet.
+type PlayerParam struct {
+	Player string `uri:"player" binding:"required,uuid"`
+}
+
+// PlayerQuery is the query binding for the player that owns a Bet.
+type PlayerQuery struct {
+	Player string `form:"player" binding:"required,uuid"`
+}
+
+// Query is the query string binding for filtering, sorting and paginating bets.
+type Query struct {
+	Limit     int       `form:"limit" binding:"omitempty,gte=1,lte=100"`
+	Cursor    string    `form:"cursor"`
+	PlayerID  string    `form:"playerId" binding:"omitempty,uuid"`
+	Type      string    `form:"type"`
+	Currency  string    `form:"currency" binding:"omitempty,oneof=GBP EUR USD"`
+	MinAmount int64     `form:"minAmount" binding:"gte=0"`
+	MaxAmount int64     `form:"maxAmount" binding:"omitempty,gtefield=MinAmount"`
+	From      time.Time `form:"from" time_format:"2006-01-02T15:04:05Z07:00"`
+	To        time.Time `form:"to" time_format:"2006-01-02T15:04:05Z07:00"`
+	Order     string    `form:"order" binding:"omitempty,oneof=asc desc"`
+}
+
 // Bet is a presentation API model.
 type Bet struct {
-	ID       string `json:"id,omitempty"`
-	Bet      string `json:"bet" binding:"required"`
-	Type     string `json:"type" binding:"required"`
-	Amount   int64  `json:"amount" binding:"required,gte=10"`
-	Currency string `json:"currency" binding:"required,oneof=GBP EUR USD"`
+	ID         string `json:"id,omitempty"`
+	RoundID    string `json:"roundId,omitempty"`
+	PlayerID   string `json:"playerId" binding:"required,uuid"`
+	Bet        string `json:"bet" binding:"required"`
+	Type       string `json:"type" binding:"required"`
+	Amount     int64  `json:"amount" binding:"required,gte=10"`
+	Currency   string `json:"currency" binding:"required,oneof=GBP EUR USD"`
+	Imprisoned bool   `json:"imprisoned,omitempty"`
+	ClientSeed string `json:"clientSeed,omitempty" binding:"omitempty,max=64,excludesall=0x2C"`
 }
 
 // Update is a Bet with the ID binding required.
@@ -34,23 +65,31 @@ type Upsert struct {
 	ID string `json:"id"`
 }
 
-// Error is a presentation API model for the Error response.
-type Error struct {
-	Error string `json:"error"`
-}
-
 // Result is the round result from a game.
+// The seeds are only present when the round was spun provably fair.
 type Result struct {
-	Number  int      `json:"number"`
-	Color   string   `json:"color"`
-	Winners []Winner `json:"winners"`
+	RoundID        string   `json:"roundId"`
+	Number         int      `json:"number"`
+	Pocket         string   `json:"pocket"`
+	Color          string   `json:"color"`
+	Winners        []Winner `json:"winners"`
+	Imprisoned     []string `json:"imprisoned"`
+	ProvablyFair   bool     `json:"provablyFair"`
+	ServerSeed     string   `json:"serverSeed,omitempty"`
+	ServerSeedHash string   `json:"serverSeedHash,omitempty"`
+	ClientSeed     string   `json:"clientSeed,omitempty"`
+	Nonce          *int64   `json:"nonce,omitempty"`
 }
 
 // Winner is a winning bet from a round.
 type Winner struct {
-	BetID    string `json:"betId"`
-	Amount   int64  `json:"amount"`
-	Currency string `json:"currency"`
+	BetID      string `json:"betId"`
+	Stake      int64  `json:"stake"`
+	Multiplier int64  `json:"multiplier"`
+	Winnings   int64  `json:"winnings"`
+	Return     int64  `json:"return"`
+	Currency   string `json:"currency"`
+	Outcome    string `json:"outcome"`
 }
 
 func domainResultToDomain(t bet.Result) Result {
@@ -59,31 +98,68 @@ func domainResultToDomain(t bet.Result) Result {
 		winners[i] = Winner(t.Winners[i])
 	}
 
-	return Result{
-		Number:  t.Number,
-		Color:   t.Color,
-		Winners: winners,
+	r := Result{
+		RoundID:      t.RoundID,
+		Number:       t.Number,
+		Pocket:       t.Pocket,
+		Color:        t.Color,
+		Winners:      winners,
+		Imprisoned:   t.Imprisoned,
+		ProvablyFair: t.ProvablyFair,
+	}
+
+	if t.ProvablyFair {
+		nonce := t.Nonce
+		r.ServerSeed = t.ServerSeed
+		r.ServerSeedHash = t.ServerSeedHash
+		r.ClientSeed = t.ClientSeed
+		r.Nonce = &nonce
+	}
+
+	return r
+}
+
+func queryToDomain(t Query) bet.Filter {
+	return bet.Filter{
+		Page: page.Page{
+			Limit:  t.Limit,
+			Cursor: t.Cursor,
+		},
+		PlayerID:  t.PlayerID,
+		Type:      t.Type,
+		Currency:  t.Currency,
+		MinAmount: t.MinAmount,
+		MaxAmount: t.MaxAmount,
+		From:      t.From,
+		To:        t.To,
+		Order:     t.Order,
 	}
 }
 
 func presentationToDomain(t Bet, tableID string) bet.Bet {
 	return bet.Bet{
-		ID:       t.ID,
-		TableID:  tableID,
-		Bet:      t.Bet,
-		Type:     t.Type,
-		Amount:   t.Amount,
-		Currency: t.Currency,
+		ID:         t.ID,
+		TableID:    tableID,
+		PlayerID:   t.PlayerID,
+		Bet:        t.Bet,
+		Type:       t.Type,
+		Amount:     t.Amount,
+		Currency:   t.Currency,
+		ClientSeed: t.ClientSeed,
 	}
 }
 
 func domainToPresentation(t *bet.Bet) Bet {
 	return Bet{
-		ID:       t.ID,
-		Bet:      t.Bet,
-		Type:     t.Type,
-		Amount:   t.Amount,
-		Currency: t.Currency,
+		ID:         t.ID,
+		RoundID:    t.RoundID,
+		PlayerID:   t.PlayerID,
+		Bet:        t.Bet,
+		Type:       t.Type,
+		Amount:     t.Amount,
+		Currency:   t.Currency,
+		Imprisoned: t.Imprisoned,
+		ClientSeed: t.ClientSeed,
 	}
 }
 
@@ -2,16 +2,31 @@ package bet
 
 import (
 	domain "github.com/clarke94/roulette-service/internal/pkg/bet"
+	"github.com/clarke94/roulette-service/internal/pkg/event"
+	"github.com/clarke94/roulette-service/internal/pkg/ledger"
+	"github.com/clarke94/roulette-service/internal/pkg/round"
+	"github.com/clarke94/roulette-service/internal/pkg/table"
+	"github.com/clarke94/roulette-service/internal/pkg/wallet"
 	storage "github.com/clarke94/roulette-service/storage/bet"
+	ledgerStorage "github.com/clarke94/roulette-service/storage/ledger"
+	roundStorage "github.com/clarke94/roulette-service/storage/round"
+	tableStorage "github.com/clarke94/roulette-service/storage/table"
+	"github.com/clarke94/roulette-service/storage/transaction"
+	walletStorage "github.com/clarke94/roulette-service/storage/wallet"
 	"github.com/gin-gonic/gin"
 	"github.com/sirupsen/logrus"
 	"gorm.io/gorm"
 )
 
-// Module initializes all bet dependencies.
-func Module(router *gin.Engine, logger *logrus.Logger, db *gorm.DB) {
+// Module initializes all bet dependencies, spinning the wheel with the given source of random numbers and publishing
+// the events of each table to the given hub.
+func Module(router *gin.Engine, logger *logrus.Logger, db *gorm.DB, random domain.RandomProvider, events *event.Hub) {
 	store := storage.New(db)
-	controller := domain.New(logger, store)
+	tables := table.New(logger, tableStorage.New(db), store, transaction.New(db))
+	rounds := round.New(logger, roundStorage.New(db), tables)
+	entries := ledger.New(logger, ledgerStorage.New(db))
+	wallets := wallet.New(logger, walletStorage.New(db), entries, transaction.New(db))
+	controller := domain.New(logger, store, tables, rounds, wallets, entries, transaction.New(db), random, events)
 	handler := NewHandler(controller)
 	NewRouter(router, handler)
 }
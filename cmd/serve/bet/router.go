@@ -13,6 +13,9 @@ func NewRouter(router *gin.Engine, handler Handler) {
 	v1.Handle(http.MethodPost, "/table/:table/play", handler.Play)
 	v1.Handle(http.MethodPost, "/table/:table/bet", handler.Create)
 	v1.Handle(http.MethodGet, "/table/:table/bet", handler.List)
+	v1.Handle(http.MethodGet, "/table/:table/bet/:bet", handler.Get)
 	v1.Handle(http.MethodPut, "/table/:table/bet", handler.Update)
+	v1.Handle(http.MethodPatch, "/table/:table/bet/:bet", handler.Patch)
 	v1.Handle(http.MethodDelete, "/table/:table/bet/:bet", handler.Delete)
+	v1.Handle(http.MethodGet, "/players/:player/bets", handler.ListPlayer)
 }
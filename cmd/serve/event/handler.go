@@ -0,0 +1,150 @@
+package event
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"time"
+
+	"github.com/clarke94/roulette-service/cmd/serve/response"
+	domain "github.com/clarke94/roulette-service/internal/pkg/event"
+	"github.com/clarke94/roulette-service/internal/pkg/table"
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	// DefaultHeartbeat is how often a comment is sent down a quiet stream, so a client that went away is noticed.
+	DefaultHeartbeat = 15 * time.Second
+	// DefaultWriteTimeout is how long each write to a stream may take, in place of the write timeout of the server.
+	DefaultWriteTimeout = 10 * time.Second
+	// DefaultRetry is how long the client waits before reconnecting once a stream ends.
+	DefaultRetry = time.Second
+)
+
+// connKey is the context key of the connection a request was read from.
+type connKey struct{}
+
+// TableProvider provides an interface to the table domain.
+type TableProvider interface {
+	Get(ctx context.Context, id string) (table.Table, error)
+}
+
+// SubscriberProvider provides an interface to subscribe to the events of a table.
+type SubscriberProvider interface {
+	Subscribe(tableID string, after int64) (<-chan domain.Event, func())
+}
+
+// Handler provides a presentation handler.
+type Handler struct {
+	Tables       TableProvider
+	Events       SubscriberProvider
+	Heartbeat    time.Duration
+	WriteTimeout time.Duration
+	Retry        time.Duration
+}
+
+// NewHandler initializes a new Handler.
+func NewHandler(tables TableProvider, events SubscriberProvider) Handler {
+	return Handler{
+		Tables:       tables,
+		Events:       events,
+		Heartbeat:    DefaultHeartbeat,
+		WriteTimeout: DefaultWriteTimeout,
+		Retry:        DefaultRetry,
+	}
+}
+
+// ConnContext carries the connection of each request in its context, set as the ConnContext of the server so a
+// stream can lift the write timeout of the server that would otherwise cut it off.
+func ConnContext(ctx context.Context, c net.Conn) context.Context {
+	return context.WithValue(ctx, connKey{}, c)
+}
+
+// Stream writes the events of the table as server-sent events until the subscription ends or the client goes away.
+func (h Handler) Stream(ctx *gin.Context) {
+	var params TableParam
+	if err := ctx.ShouldBindUri(&params); err != nil {
+		response.AbortBinding(ctx, err)
+
+		return
+	}
+
+	var header Header
+	if err := ctx.ShouldBindHeader(&header); err != nil {
+		response.AbortBinding(ctx, err)
+
+		return
+	}
+
+	if _, err := h.Tables.Get(ctx, params.Table); err != nil {
+		response.Abort(ctx, err)
+
+		return
+	}
+
+	events, unsubscribe := h.Events.Subscribe(params.Table, header.LastEventID)
+	defer unsubscribe()
+
+	ctx.Header("Content-Type", "text/event-stream")
+	ctx.Header("Cache-Control", "no-cache")
+	ctx.Header("Connection", "keep-alive")
+	ctx.Header("X-Accel-Buffering", "no")
+	ctx.Status(http.StatusOK)
+
+	h.extend(ctx.Request)
+	fmt.Fprintf(ctx.Writer, "retry: %d\n\n", h.Retry.Milliseconds())
+	ctx.Writer.Flush()
+
+	heartbeat := time.NewTicker(h.Heartbeat)
+	defer heartbeat.Stop()
+
+	for {
+		select {
+		case <-ctx.Request.Context().Done():
+			return
+		case <-heartbeat.C:
+			h.extend(ctx.Request)
+
+			if _, err := io.WriteString(ctx.Writer, ":\n\n"); err != nil {
+				return
+			}
+
+			ctx.Writer.Flush()
+		case e, ok := <-events:
+			if !ok {
+				return
+			}
+
+			h.extend(ctx.Request)
+
+			if err := write(ctx.Writer, e); err != nil {
+				return
+			}
+
+			ctx.Writer.Flush()
+		}
+	}
+}
+
+// extend moves the write deadline of the connection of the request to the write timeout from now, so a stream stays
+// open for as long as the client keeps reading it.
+func (h Handler) extend(r *http.Request) {
+	if c, ok := r.Context().Value(connKey{}).(net.Conn); ok {
+		_ = c.SetWriteDeadline(time.Now().Add(h.WriteTimeout))
+	}
+}
+
+// write writes the event in the server-sent events format.
+func write(w io.Writer, e domain.Event) error {
+	data, err := json.Marshal(dataToPresentation(e))
+	if err != nil {
+		return err
+	}
+
+	_, err = fmt.Fprintf(w, "id: %d\nevent: %s\ndata: %s\n\n", e.ID, e.Type, data)
+
+	return err
+}
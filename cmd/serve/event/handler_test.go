@@ -0,0 +1,263 @@
+package event
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/clarke94/roulette-service/internal/pkg/bet"
+	domain "github.com/clarke94/roulette-service/internal/pkg/event"
+	"github.com/clarke94/roulette-service/internal/pkg/round"
+	"github.com/clarke94/roulette-service/internal/pkg/table"
+	"github.com/gin-gonic/gin"
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/uuid"
+)
+
+var published = []domain.Event{
+	{
+		ID:   1,
+		Type: domain.TypeBetPlaced,
+		Data: bet.Bet{
+			ID:       "b",
+			RoundID:  "r",
+			PlayerID: "p",
+			Bet:      "17",
+			Type:     bet.TypeStraight,
+			Amount:   10,
+			Currency: "GBP",
+		},
+	},
+	{
+		ID:   2,
+		Type: domain.TypeRoundSpun,
+		Data: round.Round{
+			ID:             "r",
+			State:          round.StateSpun,
+			Number:         17,
+			Color:          "black",
+			ServerSeedHash: "h",
+			SpunAt:         time.Date(2021, 6, 21, 21, 0, 0, 0, time.UTC),
+		},
+	},
+	{
+		ID:   3,
+		Type: domain.TypeRoundSettled,
+		Data: bet.Result{
+			RoundID:    "r",
+			Number:     17,
+			Pocket:     "17",
+			Color:      "black",
+			Winners:    []bet.Winner{{BetID: "b", Stake: 10, Multiplier: 35, Winnings: 350, Return: 360, Currency: "GBP"}},
+			Imprisoned: []string{},
+		},
+	},
+}
+
+func TestNewHandler(t *testing.T) {
+	tests := []struct {
+		name   string
+		tables TableProvider
+		events SubscriberProvider
+		want   Handler
+	}{
+		{
+			name:   "expect Handler to init",
+			tables: mockTables{},
+			events: mockEvents{},
+			want: Handler{
+				Tables:       mockTables{},
+				Events:       mockEvents{},
+				Heartbeat:    DefaultHeartbeat,
+				WriteTimeout: DefaultWriteTimeout,
+				Retry:        DefaultRetry,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := NewHandler(tt.tables, tt.events)
+
+			if !cmp.Equal(got, tt.want) {
+				t.Error(cmp.Diff(got, tt.want))
+			}
+		})
+	}
+}
+
+func TestHandler_Stream(t *testing.T) {
+	tests := []struct {
+		name        string
+		tables      mockTables
+		events      mockEvents
+		tableID     string
+		lastEventID string
+		heartbeat   time.Duration
+		wantCode    int
+		wantBody    string
+	}{
+		{
+			name:     "expect events streamed given table found",
+			events:   mockEvents{GivenEvents: published},
+			tableID:  uuid.New().String(),
+			wantCode: http.StatusOK,
+			wantBody: "retry: 1000\n\n" +
+				"id: 1\nevent: bet.placed\ndata: " +
+				`{"id":"b","roundId":"r","playerId":"p","bet":"17","type":"straight","amount":10,"currency":"GBP"}` + "\n\n" +
+				"id: 2\nevent: round.spun\ndata: " +
+				`{"id":"r","state":"spun","number":17,"pocket":"17","color":"black","provablyFair":false,` +
+				`"serverSeedHash":"h","spunAt":"2021-06-21T21:00:00Z"}` + "\n\n" +
+				"id: 3\nevent: round.settled\ndata: " +
+				`{"roundId":"r","number":17,"pocket":"17","color":"black","winners":[{"betId":"b","stake":10,` +
+				`"multiplier":35,"winnings":350,"return":360,"currency":"GBP","outcome":""}],"imprisoned":[],` +
+				`"provablyFair":false}` + "\n\n",
+		},
+		{
+			name:        "expect only later events streamed given last event ID",
+			events:      mockEvents{GivenEvents: published[:2]},
+			tableID:     uuid.New().String(),
+			lastEventID: "1",
+			wantCode:    http.StatusOK,
+			wantBody: "retry: 1000\n\n" +
+				"id: 2\nevent: round.spun\ndata: " +
+				`{"id":"r","state":"spun","number":17,"pocket":"17","color":"black","provablyFair":false,` +
+				`"serverSeedHash":"h","spunAt":"2021-06-21T21:00:00Z"}` + "\n\n",
+		},
+		{
+			name:     "expect stream ended given client gone",
+			events:   mockEvents{Open: true},
+			tableID:  uuid.New().String(),
+			wantCode: http.StatusOK,
+			wantBody: "retry: 1000\n\n",
+		},
+		{
+			name:      "expect heartbeat given quiet stream",
+			events:    mockEvents{Open: true},
+			tableID:   uuid.New().String(),
+			heartbeat: 20 * time.Millisecond,
+			wantCode:  http.StatusOK,
+			wantBody:  "retry: 1000\n\n:\n\n",
+		},
+		{
+			name:     "expect 422 given invalid table ID",
+			tableID:  "foo",
+			wantCode: http.StatusUnprocessableEntity,
+		},
+		{
+			name:        "expect 422 given invalid last event ID",
+			tableID:     uuid.New().String(),
+			lastEventID: "foo",
+			wantCode:    http.StatusUnprocessableEntity,
+		},
+		{
+			name:        "expect 422 given negative last event ID",
+			tableID:     uuid.New().String(),
+			lastEventID: "-1",
+			wantCode:    http.StatusUnprocessableEntity,
+		},
+		{
+			name:     "expect 404 given table not found",
+			tables:   mockTables{GivenError: table.ErrNotFound},
+			tableID:  uuid.New().String(),
+			wantCode: http.StatusNotFound,
+		},
+		{
+			name:     "expect 500 given table error",
+			tables:   mockTables{GivenError: errors.New("foo")},
+			tableID:  uuid.New().String(),
+			wantCode: http.StatusInternalServerError,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := NewHandler(tt.tables, tt.events)
+			if tt.heartbeat > 0 {
+				h.Heartbeat = tt.heartbeat
+			}
+
+			gone, cancel := context.WithTimeout(context.Background(), 30*time.Millisecond)
+			defer cancel()
+
+			r := httptest.NewRequest(http.MethodGet, "/"+tt.tableID+"/events", nil).WithContext(gone)
+			if tt.lastEventID != "" {
+				r.Header.Set("Last-Event-ID", tt.lastEventID)
+			}
+
+			w := httptest.NewRecorder()
+			ctx, router := gin.CreateTestContext(w)
+			ctx.Request = r
+
+			router.Handle(http.MethodGet, "/:table/events", h.Stream)
+			router.ServeHTTP(w, r)
+
+			if !cmp.Equal(w.Code, tt.wantCode) {
+				t.Error(w.Code, tt.wantCode)
+			}
+
+			if tt.wantCode != http.StatusOK {
+				return
+			}
+
+			if !cmp.Equal(w.Header().Get("Content-Type"), "text/event-stream") {
+				t.Error(cmp.Diff(w.Header().Get("Content-Type"), "text/event-stream"))
+			}
+
+			if !cmp.Equal(w.Body.String(), tt.wantBody) {
+				t.Error(cmp.Diff(w.Body.String(), tt.wantBody))
+			}
+		})
+	}
+}
+
+func TestHandler_extend(t *testing.T) {
+	c, other := net.Pipe()
+	defer c.Close()
+	defer other.Close()
+
+	h := NewHandler(mockTables{}, mockEvents{})
+	h.WriteTimeout = 10 * time.Millisecond
+
+	r := httptest.NewRequest(http.MethodGet, "/", nil)
+	h.extend(r.WithContext(ConnContext(r.Context(), c)))
+
+	_, err := c.Write([]byte("foo"))
+
+	var netErr net.Error
+	if !errors.As(err, &netErr) || !netErr.Timeout() {
+		t.Errorf("expect write timed out given write deadline extended, got %v", err)
+	}
+}
+
+type mockTables struct {
+	GivenError error
+}
+
+func (m mockTables) Get(_ context.Context, id string) (table.Table, error) {
+	return table.Table{ID: id}, m.GivenError
+}
+
+// mockEvents replays the given events after the last event ID, then closes the subscription unless it is Open.
+type mockEvents struct {
+	GivenEvents []domain.Event
+	Open        bool
+}
+
+func (m mockEvents) Subscribe(_ string, after int64) (<-chan domain.Event, func()) {
+	events := make(chan domain.Event, len(m.GivenEvents))
+
+	for _, e := range m.GivenEvents {
+		if e.ID > after {
+			events <- e
+		}
+	}
+
+	if !m.Open {
+		close(events)
+	}
+
+	return events, func() {}
+}
@@ -0,0 +1,143 @@
+package event
+
+import (
+	"time"
+
+	"github.com/clarke94/roulette-service/internal/pkg/bet"
+	domain "github.com/clarke94/roulette-service/internal/pkg/event"
+	"github.com/clarke94/roulette-service/internal/pkg/round"
+)
+
+// TableParam is the URL parameter binding for the table ID associated with the events.
+type TableParam struct {
+	Table string `uri:"table" binding:"required,uuid"`
+}
+
+// Header is the header binding of the last event received, sent by an EventSource when it reconnects.
+type Header struct {
+	LastEventID int64 `header:"Last-Event-ID" binding:"gte=0"`
+}
+
+// Bet is the presentation API model of a bet placed, updated or cancelled.
+type Bet struct {
+	ID         string `json:"id"`
+	RoundID    string `json:"roundId"`
+	PlayerID   string `json:"playerId"`
+	Bet        string `json:"bet"`
+	Type       string `json:"type"`
+	Amount     int64  `json:"amount"`
+	Currency   string `json:"currency"`
+	Imprisoned bool   `json:"imprisoned,omitempty"`
+}
+
+// Round is the presentation API model of a round that closed or spun.
+type Round struct {
+	ID             string     `json:"id"`
+	State          string     `json:"state"`
+	Number         *int       `json:"number,omitempty"`
+	Pocket         string     `json:"pocket,omitempty"`
+	Color          string     `json:"color,omitempty"`
+	ProvablyFair   bool       `json:"provablyFair"`
+	ServerSeedHash string     `json:"serverSeedHash"`
+	SpunAt         *time.Time `json:"spunAt,omitempty"`
+}
+
+// Result is the presentation API model of a settled round.
+type Result struct {
+	RoundID        string   `json:"roundId"`
+	Number         int      `json:"number"`
+	Pocket         string   `json:"pocket"`
+	Color          string   `json:"color"`
+	Winners        []Winner `json:"winners"`
+	Imprisoned     []string `json:"imprisoned"`
+	ProvablyFair   bool     `json:"provablyFair"`
+	ServerSeed     string   `json:"serverSeed,omitempty"`
+	ServerSeedHash string   `json:"serverSeedHash,omitempty"`
+	ClientSeed     string   `json:"clientSeed,omitempty"`
+	Nonce          *int64   `json:"nonce,omitempty"`
+}
+
+// Winner is a winning bet from a round.
+type Winner struct {
+	BetID      string `json:"betId"`
+	Stake      int64  `json:"stake"`
+	Multiplier int64  `json:"multiplier"`
+	Winnings   int64  `json:"winnings"`
+	Return     int64  `json:"return"`
+	Currency   string `json:"currency"`
+	Outcome    string `json:"outcome"`
+}
+
+// dataToPresentation returns the presentation of the data published with the event.
+func dataToPresentation(e domain.Event) interface{} {
+	switch t := e.Data.(type) {
+	case bet.Bet:
+		return betToPresentation(&t)
+	case round.Round:
+		return roundToPresentation(&t)
+	case bet.Result:
+		return resultToPresentation(&t)
+	default:
+		return t
+	}
+}
+
+func betToPresentation(t *bet.Bet) Bet {
+	return Bet{
+		ID:         t.ID,
+		RoundID:    t.RoundID,
+		PlayerID:   t.PlayerID,
+		Bet:        t.Bet,
+		Type:       t.Type,
+		Amount:     t.Amount,
+		Currency:   t.Currency,
+		Imprisoned: t.Imprisoned,
+	}
+}
+
+func roundToPresentation(t *round.Round) Round {
+	r := Round{
+		ID:             t.ID,
+		State:          t.State,
+		ProvablyFair:   t.ProvablyFair,
+		ServerSeedHash: t.ServerSeedHash,
+	}
+
+	if !t.SpunAt.IsZero() {
+		number := t.Number
+		spunAt := t.SpunAt
+		r.Number = &number
+		r.Pocket = round.Pocket(number)
+		r.Color = t.Color
+		r.SpunAt = &spunAt
+	}
+
+	return r
+}
+
+func resultToPresentation(t *bet.Result) Result {
+	winners := make([]Winner, len(t.Winners))
+	for i := range t.Winners {
+		winners[i] = Winner(t.Winners[i])
+	}
+
+	r := Result{
+		RoundID:      t.RoundID,
+		Number:       t.Number,
+		Pocket:       t.Pocket,
+		Color:        t.Color,
+		Winners:      winners,
+		Imprisoned:   t.Imprisoned,
+		ProvablyFair: t.ProvablyFair,
+	}
+
+	if t.ProvablyFair {
+		nonce := t.Nonce
+		r.ServerSeed = t.ServerSeed
+		r.ServerSeedHash = t.ServerSeedHash
+		r.ClientSeed = t.ClientSeed
+		r.Nonce = &nonce
+	}
+
+	return r
+}
@@ -0,0 +1,19 @@
+package event
+
+import (
+	domain "github.com/clarke94/roulette-service/internal/pkg/event"
+	"github.com/clarke94/roulette-service/internal/pkg/table"
+	betStorage "github.com/clarke94/roulette-service/storage/bet"
+	storage "github.com/clarke94/roulette-service/storage/table"
+	"github.com/clarke94/roulette-service/storage/transaction"
+	"github.com/gin-gonic/gin"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+// Module initializes all event dependencies, streaming the events published to the given hub.
+func Module(router *gin.Engine, logger *logrus.Logger, db *gorm.DB, events *domain.Hub) {
+	tables := table.New(logger, storage.New(db), betStorage.New(db), transaction.New(db))
+	handler := NewHandler(tables, events)
+	NewRouter(router, handler)
+}
@@ -0,0 +1,14 @@
+package event
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// NewRouter initializes all event routes.
+func NewRouter(router *gin.Engine, handler Handler) {
+	v1 := router.Group("/v1")
+
+	v1.Handle(http.MethodGet, "/table/:table/events", handler.Stream)
+}
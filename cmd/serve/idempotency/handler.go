@@ -0,0 +1,169 @@
+package idempotency
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"io"
+	"net/http"
+
+	"github.com/clarke94/roulette-service/cmd/serve/response"
+	"github.com/clarke94/roulette-service/internal/pkg/idempotency"
+	"github.com/gin-gonic/gin"
+)
+
+// ReplayedHeader is set on a response that is replayed from the first request made with the idempotency key.
+const ReplayedHeader = "Idempotent-Replayed"
+
+// replayed is the set of response headers recorded with the response and replayed along with it.
+var replayed = []string{response.HeaderETag, response.HeaderNextCursor, "Location"}
+
+// mutating is the set of HTTP methods that change state and so honour an idempotency key.
+var mutating = map[string]bool{
+	http.MethodPost:   true,
+	http.MethodPut:    true,
+	http.MethodPatch:  true,
+	http.MethodDelete: true,
+}
+
+// ControllerProvider provides an interface for the domain controller.
+type ControllerProvider interface {
+	Begin(ctx context.Context, id, fingerprint string) (idempotency.Key, error)
+	Finish(ctx context.Context, model idempotency.Key) error
+	Release(ctx context.Context, model idempotency.Key) error
+}
+
+// Handler provides a presentation handler.
+type Handler struct {
+	Controller ControllerProvider
+}
+
+// NewHandler initializes a new Handler.
+func NewHandler(controller ControllerProvider) Handler {
+	return Handler{
+		Controller: controller,
+	}
+}
+
+// Handle is a middleware that replays the response to the first request made with an Idempotency-Key to any retry of
+// it within the TTL, and responds with a conflict when the key is reused for a different request. A request that
+// fails with a server error releases its key so that it can be retried, and a request that never finishes gives up its
+// key once its lease runs out.
+func (h Handler) Handle(ctx *gin.Context) {
+	if !mutating[ctx.Request.Method] {
+		ctx.Next()
+
+		return
+	}
+
+	var header Header
+	if err := ctx.ShouldBindHeader(&header); err != nil {
+		response.AbortBinding(ctx, err)
+
+		return
+	}
+
+	if header.Key == "" {
+		ctx.Next()
+
+		return
+	}
+
+	body, err := io.ReadAll(ctx.Request.Body)
+	if err != nil {
+		response.AbortBinding(ctx, err)
+
+		return
+	}
+
+	ctx.Request.Body = io.NopCloser(bytes.NewReader(body))
+
+	key, err := h.Controller.Begin(ctx, header.Key, fingerprint(ctx.Request, body))
+	if err != nil {
+		response.Abort(ctx, err)
+
+		return
+	}
+
+	if key.Done() {
+		for name, value := range key.Headers {
+			ctx.Header(name, value)
+		}
+
+		ctx.Header(ReplayedHeader, "true")
+		ctx.Data(key.Status, key.ContentType, key.Body)
+		ctx.Abort()
+
+		return
+	}
+
+	w := &recorder{ResponseWriter: ctx.Writer, body: &bytes.Buffer{}}
+	ctx.Writer = w
+
+	finished := false
+
+	defer func() {
+		if !finished {
+			_ = h.Controller.Release(ctx, key)
+		}
+	}()
+
+	ctx.Next()
+
+	if w.Status() >= http.StatusInternalServerError {
+		return
+	}
+
+	key.Status = w.Status()
+	key.ContentType = w.Header().Get("Content-Type")
+	key.Headers = headers(w.Header())
+	key.Body = w.body.Bytes()
+
+	// A request that lost its lease leaves the key to the retry that took it over.
+	err = h.Controller.Finish(ctx, key)
+	finished = err == nil || errors.Is(err, idempotency.ErrLeaseLost)
+}
+
+// headers returns the response headers that are replayed along with the response.
+func headers(header http.Header) map[string]string {
+	recorded := make(map[string]string)
+
+	for _, name := range replayed {
+		if value := header.Get(name); value != "" {
+			recorded[name] = value
+		}
+	}
+
+	return recorded
+}
+
+// fingerprint identifies a request by its method, URL and body, so a key reused for a different request is detected.
+func fingerprint(r *http.Request, body []byte) string {
+	hash := sha256.New()
+	hash.Write([]byte(r.Method + " " + r.URL.RequestURI() + "\n"))
+	hash.Write(body)
+
+	return hex.EncodeToString(hash.Sum(nil))
+}
+
+// recorder copies the response body as it is written so it can be replayed.
+type recorder struct {
+	gin.ResponseWriter
+	body *bytes.Buffer
+}
+
+// Write writes the data to the response and the copy of the body.
+func (r *recorder) Write(data []byte) (int, error) {
+	r.body.Write(data)
+
+	return r.ResponseWriter.Write(data)
+}
+
+// WriteString writes the string to the response and the copy of the body.
+func (r *recorder) WriteString(s string) (int, error) {
+	r.body.WriteString(s)
+
+	return r.ResponseWriter.WriteString(s)
+}
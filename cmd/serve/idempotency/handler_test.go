@@ -0,0 +1,294 @@
+package idempotency
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/clarke94/roulette-service/internal/pkg/idempotency"
+	"github.com/gin-gonic/gin"
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestNewHandler(t *testing.T) {
+	tests := []struct {
+		name       string
+		controller ControllerProvider
+		want       Handler
+	}{
+		{
+			name:       "expect Handler to init",
+			controller: &mockController{},
+			want: Handler{
+				Controller: &mockController{},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := NewHandler(tt.controller)
+
+			if !cmp.Equal(got, tt.want, cmp.AllowUnexported(mockController{})) {
+				t.Error(cmp.Diff(got, tt.want, cmp.AllowUnexported(mockController{})))
+			}
+		})
+	}
+}
+
+func TestHandler_Handle(t *testing.T) {
+	tests := []struct {
+		name         string
+		controller   *mockController
+		method       string
+		key          string
+		status       int
+		wantCode     int
+		wantBody     string
+		wantReplayed bool
+		wantETag     string
+		wantCalls    int
+		wantFinished *idempotency.Key
+		wantReleased bool
+	}{
+		{
+			name:       "expect request passed through given no key",
+			controller: &mockController{},
+			method:     http.MethodPost,
+			status:     http.StatusCreated,
+			wantCode:   http.StatusCreated,
+			wantBody:   `{"id":"foo"}`,
+			wantCalls:  1,
+		},
+		{
+			name:       "expect request passed through given method is not mutating",
+			controller: &mockController{},
+			method:     http.MethodGet,
+			key:        "foo",
+			status:     http.StatusOK,
+			wantCode:   http.StatusOK,
+			wantBody:   `{"id":"foo"}`,
+			wantCalls:  1,
+		},
+		{
+			name: "expect response recorded given new key",
+			controller: &mockController{
+				GivenKey: idempotency.Key{ID: "foo", Fingerprint: "bar"},
+			},
+			method:    http.MethodPost,
+			key:       "foo",
+			status:    http.StatusCreated,
+			wantCode:  http.StatusCreated,
+			wantBody:  `{"id":"foo"}`,
+			wantCalls: 1,
+			wantFinished: &idempotency.Key{
+				ID:          "foo",
+				Fingerprint: "bar",
+				Status:      http.StatusCreated,
+				ContentType: "application/json; charset=utf-8",
+				Headers:     map[string]string{"ETag": `"1"`},
+				Body:        []byte(`{"id":"foo"}`),
+			},
+		},
+		{
+			name: "expect response replayed given key already used",
+			controller: &mockController{
+				GivenKey: idempotency.Key{
+					ID:          "foo",
+					Fingerprint: "bar",
+					Status:      http.StatusCreated,
+					ContentType: "application/json; charset=utf-8",
+					Headers:     map[string]string{"ETag": `"2"`},
+					Body:        []byte(`{"id":"bar"}`),
+				},
+			},
+			method:       http.MethodPost,
+			key:          "foo",
+			wantCode:     http.StatusCreated,
+			wantBody:     `{"id":"bar"}`,
+			wantReplayed: true,
+			wantETag:     `"2"`,
+			wantCalls:    0,
+		},
+		{
+			name:         "expect key released given server error",
+			controller:   &mockController{GivenKey: idempotency.Key{ID: "foo"}},
+			method:       http.MethodPost,
+			key:          "foo",
+			status:       http.StatusInternalServerError,
+			wantCode:     http.StatusInternalServerError,
+			wantBody:     `{"id":"foo"}`,
+			wantCalls:    1,
+			wantReleased: true,
+		},
+		{
+			name: "expect key released given response not recorded",
+			controller: &mockController{
+				GivenKey:         idempotency.Key{ID: "foo"},
+				GivenFinishError: errors.New("foo"),
+			},
+			method:    http.MethodPost,
+			key:       "foo",
+			status:    http.StatusCreated,
+			wantCode:  http.StatusCreated,
+			wantBody:  `{"id":"foo"}`,
+			wantCalls: 1,
+			wantFinished: &idempotency.Key{
+				ID:          "foo",
+				Status:      http.StatusCreated,
+				ContentType: "application/json; charset=utf-8",
+				Headers:     map[string]string{"ETag": `"1"`},
+				Body:        []byte(`{"id":"foo"}`),
+			},
+			wantReleased: true,
+		},
+		{
+			name: "expect key left to the retry that took it over given lease lost",
+			controller: &mockController{
+				GivenKey:         idempotency.Key{ID: "foo"},
+				GivenFinishError: idempotency.ErrLeaseLost,
+			},
+			method:    http.MethodPost,
+			key:       "foo",
+			status:    http.StatusCreated,
+			wantCode:  http.StatusCreated,
+			wantBody:  `{"id":"foo"}`,
+			wantCalls: 1,
+			wantFinished: &idempotency.Key{
+				ID:          "foo",
+				Status:      http.StatusCreated,
+				ContentType: "application/json; charset=utf-8",
+				Headers:     map[string]string{"ETag": `"1"`},
+				Body:        []byte(`{"id":"foo"}`),
+			},
+			wantReleased: false,
+		},
+		{
+			name:       "expect 409 given key used for a different request",
+			controller: &mockController{GivenError: idempotency.ErrMismatch},
+			method:     http.MethodPut,
+			key:        "foo",
+			wantCode:   http.StatusConflict,
+			wantBody:   `{"error":"idempotency key was used with a different request","code":"conflict"}`,
+			wantCalls:  0,
+		},
+		{
+			name:       "expect 422 given key too long",
+			controller: &mockController{},
+			method:     http.MethodDelete,
+			key:        strings.Repeat("a", 256),
+			wantCode:   http.StatusUnprocessableEntity,
+			wantCalls:  0,
+		},
+		{
+			name:       "expect 500 given Controller error",
+			controller: &mockController{GivenError: idempotency.ErrBegin},
+			method:     http.MethodPost,
+			key:        "foo",
+			wantCode:   http.StatusInternalServerError,
+			wantBody:   `{"error":"unable to check idempotency key","code":"internal"}`,
+			wantCalls:  0,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := NewHandler(tt.controller)
+
+			calls := 0
+
+			r := httptest.NewRequest(tt.method, "/table", bytes.NewReader([]byte(`{"name":"foo"}`)))
+			if tt.key != "" {
+				r.Header.Set("Idempotency-Key", tt.key)
+			}
+
+			w := httptest.NewRecorder()
+			_, router := gin.CreateTestContext(w)
+
+			router.Use(h.Handle)
+			router.Handle(tt.method, "/table", func(ctx *gin.Context) {
+				calls++
+
+				var body map[string]string
+				if err := ctx.ShouldBindJSON(&body); err != nil || body["name"] != "foo" {
+					t.Error("expect request body to be readable by the handler")
+				}
+
+				ctx.Header("ETag", `"1"`)
+				ctx.JSON(tt.status, map[string]string{"id": "foo"})
+			})
+			router.ServeHTTP(w, r)
+
+			if !cmp.Equal(w.Code, tt.wantCode) {
+				t.Error(w.Code, tt.wantCode)
+			}
+
+			if tt.wantBody != "" && !cmp.Equal(w.Body.String(), tt.wantBody) {
+				t.Error(cmp.Diff(w.Body.String(), tt.wantBody))
+			}
+
+			if !cmp.Equal(w.Header().Get(ReplayedHeader) == "true", tt.wantReplayed) {
+				t.Error(cmp.Diff(w.Header().Get(ReplayedHeader) == "true", tt.wantReplayed))
+			}
+
+			if tt.wantETag != "" && !cmp.Equal(w.Header().Get("ETag"), tt.wantETag) {
+				t.Error(cmp.Diff(w.Header().Get("ETag"), tt.wantETag))
+			}
+
+			if !cmp.Equal(calls, tt.wantCalls) {
+				t.Error(cmp.Diff(calls, tt.wantCalls))
+			}
+
+			if !cmp.Equal(tt.controller.finished, tt.wantFinished) {
+				t.Error(cmp.Diff(tt.controller.finished, tt.wantFinished))
+			}
+
+			if !cmp.Equal(tt.controller.released, tt.wantReleased) {
+				t.Error(cmp.Diff(tt.controller.released, tt.wantReleased))
+			}
+		})
+	}
+}
+
+func TestHandler_fingerprint(t *testing.T) {
+	post := httptest.NewRequest(http.MethodPost, "/table", nil)
+	put := httptest.NewRequest(http.MethodPut, "/table", nil)
+
+	if !cmp.Equal(fingerprint(post, []byte("foo")), fingerprint(post, []byte("foo"))) {
+		t.Error("expect same fingerprint given same request")
+	}
+
+	if cmp.Equal(fingerprint(post, []byte("foo")), fingerprint(post, []byte("bar"))) {
+		t.Error("expect different fingerprint given different body")
+	}
+
+	if cmp.Equal(fingerprint(post, []byte("foo")), fingerprint(put, []byte("foo"))) {
+		t.Error("expect different fingerprint given different method")
+	}
+}
+
+type mockController struct {
+	GivenKey         idempotency.Key
+	GivenError       error
+	GivenFinishError error
+	finished         *idempotency.Key
+	released         bool
+}
+
+func (m *mockController) Begin(_ context.Context, _, _ string) (idempotency.Key, error) {
+	return m.GivenKey, m.GivenError
+}
+
+func (m *mockController) Finish(_ context.Context, model idempotency.Key) error {
+	m.finished = &model
+
+	return m.GivenFinishError
+}
+
+func (m *mockController) Release(_ context.Context, _ idempotency.Key) error {
+	m.released = true
+
+	return nil
+}
@@ -0,0 +1,6 @@
+package idempotency
+
+// Header is the header binding of the idempotency key a client sends to retry a request safely.
+type Header struct {
+	Key string `header:"Idempotency-Key" binding:"omitempty,max=255"`
+}
@@ -0,0 +1,21 @@
+package idempotency
+
+import (
+	"time"
+
+	domain "github.com/clarke94/roulette-service/internal/pkg/idempotency"
+	storage "github.com/clarke94/roulette-service/storage/idempotency"
+	"github.com/gin-gonic/gin"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+// Module initializes all idempotency dependencies, replaying responses for the given TTL and holding the key of a
+// request in progress for the given lease.
+// It must be initialized before the modules of the routes it covers.
+func Module(router *gin.Engine, logger *logrus.Logger, db *gorm.DB, ttl, lease time.Duration) {
+	store := storage.New(db)
+	controller := domain.New(logger, store, ttl, lease)
+	handler := NewHandler(controller)
+	NewRouter(router, handler)
+}
@@ -0,0 +1,35 @@
+package idempotency
+
+import (
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+func TestModule(t *testing.T) {
+	tests := []struct {
+		name   string
+		router *gin.Engine
+		logger *logrus.Logger
+		db     *gorm.DB
+		ttl    time.Duration
+		lease  time.Duration
+	}{
+		{
+			name:   "expect Module to init",
+			router: gin.New(),
+			logger: logrus.New(),
+			db:     &gorm.DB{},
+			ttl:    time.Hour,
+			lease:  time.Minute,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			Module(tt.router, tt.logger, tt.db, tt.ttl, tt.lease)
+		})
+	}
+}
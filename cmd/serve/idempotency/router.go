@@ -0,0 +1,10 @@
+package idempotency
+
+import (
+	"github.com/gin-gonic/gin"
+)
+
+// NewRouter registers the idempotency middleware, which only covers the routes initialized after it.
+func NewRouter(router *gin.Engine, handler Handler) {
+	router.Use(handler.Handle)
+}
@@ -0,0 +1,48 @@
+package ledger
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/clarke94/roulette-service/cmd/serve/response"
+	"github.com/clarke94/roulette-service/internal/pkg/ledger"
+	"github.com/gin-gonic/gin"
+)
+
+// ControllerProvider provides an interface for the domain controller.
+type ControllerProvider interface {
+	List(ctx context.Context, filter ledger.Filter) ([]ledger.Entry, string, error)
+}
+
+// Handler provides a presentation handler.
+type Handler struct {
+	Controller ControllerProvider
+}
+
+// NewHandler initializes a new Handler.
+func NewHandler(controller ControllerProvider) Handler {
+	return Handler{
+		Controller: controller,
+	}
+}
+
+// List invokes the List controller and returns response.
+func (h Handler) List(ctx *gin.Context) {
+	var query Query
+	if err := ctx.ShouldBindQuery(&query); err != nil {
+		response.AbortBinding(ctx, err)
+
+		return
+	}
+
+	entries, next, err := h.Controller.List(ctx, queryToDomain(query))
+	if err != nil {
+		response.Abort(ctx, err)
+
+		return
+	}
+
+	response.NextCursor(ctx, next)
+
+	ctx.JSON(http.StatusOK, domainListToPresentation(entries))
+}
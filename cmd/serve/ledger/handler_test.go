@@ -0,0 +1,145 @@
+package ledger
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/clarke94/roulette-service/cmd/serve/response"
+	"github.com/clarke94/roulette-service/internal/pkg/ledger"
+	"github.com/clarke94/roulette-service/internal/pkg/page"
+	"github.com/gin-gonic/gin"
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/uuid"
+)
+
+func TestNewHandler(t *testing.T) {
+	tests := []struct {
+		name       string
+		controller ControllerProvider
+		want       Handler
+	}{
+		{
+			name:       "expect Handler to init",
+			controller: mockController{},
+			want: Handler{
+				Controller: mockController{},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := NewHandler(tt.controller)
+
+			if !cmp.Equal(got, tt.want) {
+				t.Error(cmp.Diff(got, tt.want))
+			}
+		})
+	}
+}
+
+func TestHandler_List(t *testing.T) {
+	tests := []struct {
+		name       string
+		controller ControllerProvider
+		query      string
+		wantCode   int
+		wantNext   string
+	}{
+		{
+			name: "expect 200 given entries found",
+			controller: mockController{
+				GivenList: []ledger.Entry{
+					{
+						ID:            uuid.New().String(),
+						Type:          ledger.TypeStakeReserved,
+						BetID:         uuid.New().String(),
+						RoundID:       uuid.New().String(),
+						Currency:      "GBP",
+						DebitAccount:  ledger.AccountReserved,
+						CreditAccount: ledger.AccountAvailable,
+						Amount:        100,
+						CreatedAt:     time.Now(),
+					},
+				},
+				GivenNext: "foo",
+			},
+			query:    "?limit=10&playerId=" + uuid.New().String() + "&type=winnings-paid&currency=GBP&from=2021-06-21T21:00:00Z",
+			wantCode: http.StatusOK,
+			wantNext: "foo",
+		},
+		{
+			name:       "expect 422 given invalid limit",
+			controller: mockController{},
+			query:      "?limit=1000",
+			wantCode:   http.StatusUnprocessableEntity,
+		},
+		{
+			name:       "expect 422 given invalid bet ID",
+			controller: mockController{},
+			query:      "?betId=foo",
+			wantCode:   http.StatusUnprocessableEntity,
+		},
+		{
+			name:       "expect 422 given invalid type",
+			controller: mockController{},
+			query:      "?type=foo",
+			wantCode:   http.StatusUnprocessableEntity,
+		},
+		{
+			name:       "expect 422 given invalid date",
+			controller: mockController{},
+			query:      "?to=tomorrow",
+			wantCode:   http.StatusUnprocessableEntity,
+		},
+		{
+			name: "expect 422 given invalid cursor",
+			controller: mockController{
+				GivenError: page.ErrCursor,
+			},
+			query:    "?cursor=foo",
+			wantCode: http.StatusUnprocessableEntity,
+		},
+		{
+			name: "expect 500 given Controller error",
+			controller: mockController{
+				GivenError: errors.New("foo"),
+			},
+			wantCode: http.StatusInternalServerError,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := NewHandler(tt.controller)
+
+			r := httptest.NewRequest(http.MethodGet, "/ledger"+tt.query, nil)
+			w := httptest.NewRecorder()
+			ctx, router := gin.CreateTestContext(w)
+			ctx.Request = r
+
+			router.Handle(http.MethodGet, "/ledger", h.List)
+			router.ServeHTTP(w, r)
+
+			if !cmp.Equal(w.Code, tt.wantCode) {
+				t.Error(w.Code, tt.wantCode)
+			}
+
+			if !cmp.Equal(w.Header().Get(response.HeaderNextCursor), tt.wantNext) {
+				t.Error(cmp.Diff(w.Header().Get(response.HeaderNextCursor), tt.wantNext))
+			}
+		})
+	}
+}
+
+type mockController struct {
+	GivenList  []ledger.Entry
+	GivenNext  string
+	GivenError error
+}
+
+func (m mockController) List(_ context.Context, _ ledger.Filter) ([]ledger.Entry, string, error) {
+	return m.GivenList, m.GivenNext, m.GivenError
+}
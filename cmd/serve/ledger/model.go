@@ -0,0 +1,64 @@
+package ledger
+
+import (
+	"time"
+
+	"github.com/clarke94/roulette-service/internal/pkg/ledger"
+	"github.com/clarke94/roulette-service/internal/pkg/page"
+)
+
+// Query is the query string binding for filtering and paginating ledger entries.
+type Query struct {
+	Limit    int       `form:"limit" binding:"omitempty,gte=1,lte=100"`
+	Cursor   string    `form:"cursor"`
+	PlayerID string    `form:"playerId" binding:"omitempty,uuid"`
+	TableID  string    `form:"tableId" binding:"omitempty,uuid"`
+	RoundID  string    `form:"roundId" binding:"omitempty,uuid"`
+	BetID    string    `form:"betId" binding:"omitempty,uuid"`
+	Type     string    `form:"type" binding:"omitempty,oneof=stake-reserved stake-refunded stake-lost stake-returned winnings-paid opening-balance deposit"`
+	Currency string    `form:"currency" binding:"omitempty,oneof=GBP EUR USD"`
+	From     time.Time `form:"from" time_format:"2006-01-02T15:04:05Z07:00"`
+	To       time.Time `form:"to" time_format:"2006-01-02T15:04:05Z07:00"`
+}
+
+// Entry is a presentation API model.
+type Entry struct {
+	ID            string    `json:"id"`
+	Type          string    `json:"type"`
+	BetID         string    `json:"betId"`
+	RoundID       string    `json:"roundId"`
+	TableID       string    `json:"tableId"`
+	PlayerID      string    `json:"playerId"`
+	Currency      string    `json:"currency"`
+	DebitAccount  string    `json:"debitAccount"`
+	CreditAccount string    `json:"creditAccount"`
+	Amount        int64     `json:"amount"`
+	CreatedAt     time.Time `json:"createdAt"`
+}
+
+func queryToDomain(t Query) ledger.Filter {
+	return ledger.Filter{
+		Page: page.Page{
+			Limit:  t.Limit,
+			Cursor: t.Cursor,
+		},
+		PlayerID: t.PlayerID,
+		TableID:  t.TableID,
+		RoundID:  t.RoundID,
+		BetID:    t.BetID,
+		Type:     t.Type,
+		Currency: t.Currency,
+		From:     t.From,
+		To:       t.To,
+	}
+}
+
+func domainListToPresentation(t []ledger.Entry) []Entry {
+	entries := make([]Entry, len(t))
+
+	for i := range t {
+		entries[i] = Entry(t[i])
+	}
+
+	return entries
+}
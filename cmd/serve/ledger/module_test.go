@@ -0,0 +1,30 @@
+package ledger
+
+import (
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+func TestModule(t *testing.T) {
+	tests := []struct {
+		name   string
+		router *gin.Engine
+		logger *logrus.Logger
+		db     *gorm.DB
+	}{
+		{
+			name:   "expect Module to init",
+			router: gin.New(),
+			logger: logrus.New(),
+			db:     &gorm.DB{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			Module(tt.router, tt.logger, tt.db)
+		})
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"net/http"
 
+	"github.com/clarke94/roulette-service/cmd/serve/response"
 	"github.com/gin-gonic/gin"
 )
 
@@ -29,7 +30,7 @@ func NewHandler(controller ControllerProvider) Handler {
 func (h Handler) Docs(ctx *gin.Context) {
 	html, err := h.Controller.Docs()
 	if err != nil {
-		ctx.AbortWithStatusJSON(http.StatusBadRequest, err)
+		response.Abort(ctx, err)
 
 		return
 	}
@@ -41,7 +42,7 @@ func (h Handler) Docs(ctx *gin.Context) {
 func (h Handler) Specification(ctx *gin.Context) {
 	data, err := h.Controller.Specification()
 	if err != nil {
-		ctx.AbortWithStatusJSON(http.StatusBadRequest, err)
+		response.Abort(ctx, err)
 
 		return
 	}
@@ -50,7 +51,7 @@ func (h Handler) Specification(ctx *gin.Context) {
 
 	err = json.Unmarshal(data, &spec)
 	if err != nil {
-		ctx.AbortWithStatusJSON(http.StatusBadRequest, err)
+		response.Abort(ctx, err)
 
 		return
 	}
@@ -49,12 +49,12 @@ func TestHandler_Docs(t *testing.T) {
 			wantCode: http.StatusOK,
 		},
 		{
-			name: "expect 400 given Controller error",
+			name: "expect 500 given Controller error",
 			controller: mockController{
 				GivenBytes: []byte(`<html></html>`),
 				GivenError: errors.New("foo"),
 			},
-			wantCode: http.StatusBadRequest,
+			wantCode: http.StatusInternalServerError,
 		},
 	}
 	for _, tt := range tests {
@@ -87,19 +87,19 @@ func TestHandler_Specification(t *testing.T) {
 			wantCode: http.StatusOK,
 		},
 		{
-			name: "expect 400 given Controller error",
+			name: "expect 500 given Controller error",
 			controller: mockController{
 				GivenBytes: []byte(`{"foo": "bar"}`),
 				GivenError: errors.New("foo"),
 			},
-			wantCode: http.StatusBadRequest,
+			wantCode: http.StatusInternalServerError,
 		},
 		{
-			name: "expect 400 given no data found",
+			name: "expect 500 given no data found",
 			controller: mockController{
 				GivenBytes: nil,
 			},
-			wantCode: http.StatusBadRequest,
+			wantCode: http.StatusInternalServerError,
 		},
 	}
 	for _, tt := range tests {
@@ -0,0 +1,94 @@
+// Package patch applies JSON Merge Patch (RFC 7396) request bodies to the presentation models of a resource.
+package patch
+
+import (
+	"bytes"
+	"encoding/json"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+)
+
+// ContentType is the media type of a JSON Merge Patch request body.
+const ContentType = "application/merge-patch+json"
+
+// Bind applies the JSON Merge Patch in the request body to the current presentation model of a resource and binds
+// the result to obj, validating it with the same binding rules as the request body of a create.
+// A member of the patch that is null removes it, leaving the zero value of the field in obj.
+func Bind(ctx *gin.Context, current, obj interface{}) error {
+	body, err := ctx.GetRawData()
+	if err != nil {
+		return err
+	}
+
+	target, err := json.Marshal(current)
+	if err != nil {
+		return err
+	}
+
+	merged, err := Merge(target, body)
+	if err != nil {
+		return err
+	}
+
+	if err := json.Unmarshal(merged, obj); err != nil {
+		return err
+	}
+
+	return binding.Validator.ValidateStruct(obj)
+}
+
+// Merge returns the target JSON document with the JSON Merge Patch applied to it.
+func Merge(target, patch []byte) ([]byte, error) {
+	p, err := decode(patch)
+	if err != nil {
+		return nil, err
+	}
+
+	t, err := decode(target)
+	if err != nil {
+		return nil, err
+	}
+
+	return json.Marshal(merge(t, p))
+}
+
+// decode returns the JSON document keeping every number as it was written, so an amount in the smallest currency
+// unit does not lose precision as a float.
+func decode(doc []byte) (interface{}, error) {
+	d := json.NewDecoder(bytes.NewReader(doc))
+	d.UseNumber()
+
+	var v interface{}
+	if err := d.Decode(&v); err != nil {
+		return nil, err
+	}
+
+	return v, nil
+}
+
+// merge applies the patch to the target as described by RFC 7396, any patch that is not an object replacing the
+// target as a whole.
+func merge(target, patch interface{}) interface{} {
+	p, ok := patch.(map[string]interface{})
+	if !ok {
+		return patch
+	}
+
+	t, ok := target.(map[string]interface{})
+	if !ok {
+		t = map[string]interface{}{}
+	}
+
+	for k, v := range p {
+		if v == nil {
+			delete(t, k)
+
+			continue
+		}
+
+		t[k] = merge(t[k], v)
+	}
+
+	return t
+}
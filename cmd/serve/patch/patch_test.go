@@ -0,0 +1,166 @@
+package patch
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestMerge(t *testing.T) {
+	tests := []struct {
+		name    string
+		target  string
+		patch   string
+		want    string
+		wantErr bool
+	}{
+		{
+			name:   "expect member replaced given new value",
+			target: `{"a":"b"}`,
+			patch:  `{"a":"c"}`,
+			want:   `{"a":"c"}`,
+		},
+		{
+			name:   "expect member added given missing member",
+			target: `{"a":"b"}`,
+			patch:  `{"b":"c"}`,
+			want:   `{"a":"b","b":"c"}`,
+		},
+		{
+			name:   "expect member removed given null",
+			target: `{"a":"b","b":"c"}`,
+			patch:  `{"a":null}`,
+			want:   `{"b":"c"}`,
+		},
+		{
+			name:   "expect member set given zero value",
+			target: `{"a":100,"b":true}`,
+			patch:  `{"a":0,"b":false}`,
+			want:   `{"a":0,"b":false}`,
+		},
+		{
+			name:   "expect nested object merged",
+			target: `{"a":{"b":"c","d":"e"}}`,
+			patch:  `{"a":{"d":null,"f":"g"}}`,
+			want:   `{"a":{"b":"c","f":"g"}}`,
+		},
+		{
+			name:   "expect array replaced as a whole",
+			target: `{"a":["b","c"]}`,
+			patch:  `{"a":["d"]}`,
+			want:   `{"a":["d"]}`,
+		},
+		{
+			name:   "expect large number kept exactly",
+			target: `{"a":1}`,
+			patch:  `{"a":9007199254740993}`,
+			want:   `{"a":9007199254740993}`,
+		},
+		{
+			name:   "expect target replaced given patch that is not an object",
+			target: `{"a":"b"}`,
+			patch:  `"c"`,
+			want:   `"c"`,
+		},
+		{
+			name:    "expect fail given invalid patch",
+			target:  `{"a":"b"}`,
+			patch:   `{`,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Merge([]byte(tt.target), []byte(tt.patch))
+
+			if !cmp.Equal(err != nil, tt.wantErr) {
+				t.Fatal(cmp.Diff(err != nil, tt.wantErr))
+			}
+
+			if !cmp.Equal(string(got), tt.want) {
+				t.Error(cmp.Diff(string(got), tt.want))
+			}
+		})
+	}
+}
+
+func TestBind(t *testing.T) {
+	type model struct {
+		Name   string `json:"name" binding:"required"`
+		Amount int64  `json:"amount" binding:"gte=0"`
+		Limit  int64  `json:"limit,omitempty"`
+	}
+
+	current := model{Name: "foo", Amount: 100, Limit: 1000}
+
+	tests := []struct {
+		name    string
+		body    string
+		want    model
+		wantErr bool
+	}{
+		{
+			name: "expect patched model given valid patch",
+			body: `{"amount":50}`,
+			want: model{Name: "foo", Amount: 50, Limit: 1000},
+		},
+		{
+			name: "expect zero value given explicit zero",
+			body: `{"amount":0}`,
+			want: model{Name: "foo", Amount: 0, Limit: 1000},
+		},
+		{
+			name: "expect zero value given null",
+			body: `{"limit":null}`,
+			want: model{Name: "foo", Amount: 100, Limit: 0},
+		},
+		{
+			name:    "expect fail given required member removed",
+			body:    `{"name":null}`,
+			want:    model{Amount: 100, Limit: 1000},
+			wantErr: true,
+		},
+		{
+			name:    "expect fail given merged model is not valid",
+			body:    `{"amount":-1}`,
+			want:    model{Name: "foo", Amount: -1, Limit: 1000},
+			wantErr: true,
+		},
+		{
+			name:    "expect fail given patch of the wrong type",
+			body:    `{"amount":"foo"}`,
+			want:    model{Name: "foo", Limit: 1000},
+			wantErr: true,
+		},
+		{
+			name:    "expect fail given no body",
+			body:    "",
+			want:    model{},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := httptest.NewRequest(http.MethodPatch, "/", bytes.NewReader([]byte(tt.body)))
+			r.Header.Set("Content-Type", ContentType)
+
+			ctx, _ := gin.CreateTestContext(httptest.NewRecorder())
+			ctx.Request = r
+
+			var got model
+
+			err := Bind(ctx, current, &got)
+			if !cmp.Equal(err != nil, tt.wantErr) {
+				t.Fatal(cmp.Diff(err != nil, tt.wantErr))
+			}
+
+			if !cmp.Equal(got, tt.want) {
+				t.Error(cmp.Diff(got, tt.want))
+			}
+		})
+	}
+}
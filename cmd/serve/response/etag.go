@@ -0,0 +1,73 @@
+package response
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/clarke94/roulette-service/internal/pkg/apperror"
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	// HeaderETag is the response header holding the entity tag of the version of the resource.
+	HeaderETag = "ETag"
+	// HeaderIfNoneMatch is the request header holding the entity tags a client already has.
+	HeaderIfNoneMatch = "If-None-Match"
+	// HeaderIfMatch is the request header holding the entity tag of the version a change is based on.
+	HeaderIfMatch = "If-Match"
+)
+
+// ErrIfMatch is returned when a change does not hold the entity tag of the version it is based on in If-Match.
+var ErrIfMatch = apperror.New(apperror.PreconditionRequired, "If-Match header with the ETag of the resource is required")
+
+// JSONWithETag responds with the body and the entity tag of its version, or with 304 Not Modified and no body when
+// the tag is listed in the If-None-Match header, so a client polling a resource only downloads it again once it
+// changes.
+func JSONWithETag(ctx *gin.Context, code int, version int64, body interface{}) {
+	tag := ETag(version)
+	ctx.Header(HeaderETag, tag)
+
+	if matches(ctx.GetHeader(HeaderIfNoneMatch), tag) {
+		ctx.AbortWithStatus(http.StatusNotModified)
+
+		return
+	}
+
+	ctx.JSON(code, body)
+}
+
+// ETag returns the strong entity tag of the version of a resource.
+func ETag(version int64) string {
+	return `"` + strconv.FormatInt(version, 10) + `"`
+}
+
+// IfMatch returns the version of the resource held in the If-Match header of the request.
+// If-Match only matches a strong entity tag, so a weak tag or any other value is treated as missing.
+func IfMatch(ctx *gin.Context) (int64, error) {
+	tag := strings.TrimSpace(ctx.GetHeader(HeaderIfMatch))
+	if len(tag) < 3 || !strings.HasPrefix(tag, `"`) || !strings.HasSuffix(tag, `"`) {
+		return 0, ErrIfMatch
+	}
+
+	version, err := strconv.ParseInt(tag[1:len(tag)-1], 10, 64)
+	if err != nil || version <= 0 {
+		return 0, ErrIfMatch
+	}
+
+	return version, nil
+}
+
+// matches reports whether any entity tag in the If-None-Match header is the given tag, using the weak comparison
+// If-None-Match calls for so a tag marked weak by a proxy still matches.
+func matches(header, tag string) bool {
+	for _, candidate := range strings.Split(header, ",") {
+		candidate = strings.TrimSpace(candidate)
+
+		if candidate == "*" || strings.TrimPrefix(candidate, "W/") == tag {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,139 @@
+package response
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+)
+
+func TestJSONWithETag(t *testing.T) {
+	tests := []struct {
+		name        string
+		ifNoneMatch string
+		wantCode    int
+		wantBody    string
+	}{
+		{
+			name:     "expect 200 with body given no If-None-Match",
+			wantCode: http.StatusOK,
+			wantBody: `{"foo":"bar"}`,
+		},
+		{
+			name:        "expect 200 with body given another version",
+			ifNoneMatch: `"2"`,
+			wantCode:    http.StatusOK,
+			wantBody:    `{"foo":"bar"}`,
+		},
+		{
+			name:        "expect 304 given matching tag",
+			ifNoneMatch: `"3"`,
+			wantCode:    http.StatusNotModified,
+		},
+		{
+			name:        "expect 304 given matching weak tag in a list",
+			ifNoneMatch: `"2", W/"3"`,
+			wantCode:    http.StatusNotModified,
+		},
+		{
+			name:        "expect 304 given any tag",
+			ifNoneMatch: "*",
+			wantCode:    http.StatusNotModified,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := httptest.NewRequest(http.MethodGet, "/", nil)
+			if tt.ifNoneMatch != "" {
+				r.Header.Set(HeaderIfNoneMatch, tt.ifNoneMatch)
+			}
+
+			w := httptest.NewRecorder()
+			ctx, _ := gin.CreateTestContext(w)
+			ctx.Request = r
+
+			JSONWithETag(ctx, http.StatusOK, 3, map[string]string{"foo": "bar"})
+
+			if !cmp.Equal(w.Code, tt.wantCode) {
+				t.Error(w.Code, tt.wantCode)
+			}
+
+			if !cmp.Equal(w.Header().Get(HeaderETag), `"3"`) {
+				t.Error(cmp.Diff(w.Header().Get(HeaderETag), `"3"`))
+			}
+
+			if !cmp.Equal(w.Body.String(), tt.wantBody) {
+				t.Error(cmp.Diff(w.Body.String(), tt.wantBody))
+			}
+		})
+	}
+}
+
+func TestIfMatch(t *testing.T) {
+	tests := []struct {
+		name    string
+		ifMatch string
+		want    int64
+		wantErr error
+	}{
+		{
+			name:    "expect version given strong tag",
+			ifMatch: `"3"`,
+			want:    3,
+			wantErr: nil,
+		},
+		{
+			name:    "expect fail given no tag",
+			ifMatch: "",
+			want:    0,
+			wantErr: ErrIfMatch,
+		},
+		{
+			name:    "expect fail given weak tag",
+			ifMatch: `W/"3"`,
+			want:    0,
+			wantErr: ErrIfMatch,
+		},
+		{
+			name:    "expect fail given unquoted tag",
+			ifMatch: "3",
+			want:    0,
+			wantErr: ErrIfMatch,
+		},
+		{
+			name:    "expect fail given any tag",
+			ifMatch: "*",
+			want:    0,
+			wantErr: ErrIfMatch,
+		},
+		{
+			name:    "expect fail given tag that is not a version",
+			ifMatch: `"foo"`,
+			want:    0,
+			wantErr: ErrIfMatch,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := httptest.NewRequest(http.MethodPut, "/", nil)
+			if tt.ifMatch != "" {
+				r.Header.Set(HeaderIfMatch, tt.ifMatch)
+			}
+
+			ctx, _ := gin.CreateTestContext(httptest.NewRecorder())
+			ctx.Request = r
+
+			got, err := IfMatch(ctx)
+			if !cmp.Equal(err, tt.wantErr, cmpopts.EquateErrors()) {
+				t.Error(cmp.Diff(err, tt.wantErr, cmpopts.EquateErrors()))
+			}
+
+			if !cmp.Equal(got, tt.want) {
+				t.Error(cmp.Diff(got, tt.want))
+			}
+		})
+	}
+}
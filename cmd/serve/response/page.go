@@ -0,0 +1,13 @@
+package response
+
+import "github.com/gin-gonic/gin"
+
+// HeaderNextCursor is the response header holding the cursor of the next page.
+const HeaderNextCursor = "X-Next-Cursor"
+
+// NextCursor sets the cursor of the next page of a list, leaving it out on the last page.
+func NextCursor(ctx *gin.Context, next string) {
+	if next != "" {
+		ctx.Header(HeaderNextCursor, next)
+	}
+}
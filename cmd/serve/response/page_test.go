@@ -0,0 +1,40 @@
+package response
+
+import (
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestNextCursor(t *testing.T) {
+	tests := []struct {
+		name       string
+		next       string
+		wantHeader []string
+	}{
+		{
+			name:       "expect cursor given next page",
+			next:       "foo",
+			wantHeader: []string{"foo"},
+		},
+		{
+			name:       "expect no cursor given last page",
+			next:       "",
+			wantHeader: nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			ctx, _ := gin.CreateTestContext(w)
+
+			NextCursor(ctx, tt.next)
+
+			if !cmp.Equal(w.Header().Values(HeaderNextCursor), tt.wantHeader) {
+				t.Error(cmp.Diff(w.Header().Values(HeaderNextCursor), tt.wantHeader))
+			}
+		})
+	}
+}
@@ -0,0 +1,105 @@
+// Package response writes the JSON responses shared by every presentation handler, such as the error envelope.
+package response
+
+import (
+	"errors"
+	"net/http"
+	"reflect"
+	"strings"
+
+	"github.com/clarke94/roulette-service/internal/pkg/apperror"
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+	"github.com/go-playground/validator/v10"
+)
+
+// Error is a presentation API model for the Error response.
+type Error struct {
+	Error   string   `json:"error"`
+	Code    string   `json:"code"`
+	Details []Detail `json:"details,omitempty"`
+}
+
+// Detail is a presentation API model for a single field that is not valid.
+type Detail struct {
+	Field   string `json:"field"`
+	Message string `json:"message"`
+}
+
+// statusMap is the HTTP status code for each Kind of error.
+var statusMap = map[apperror.Kind]int{
+	apperror.NotFound:             http.StatusNotFound,
+	apperror.Validation:           http.StatusUnprocessableEntity,
+	apperror.Conflict:             http.StatusConflict,
+	apperror.LimitExceeded:        http.StatusUnprocessableEntity,
+	apperror.Forbidden:            http.StatusForbidden,
+	apperror.PreconditionFailed:   http.StatusPreconditionFailed,
+	apperror.PreconditionRequired: http.StatusPreconditionRequired,
+	apperror.Internal:             http.StatusInternalServerError,
+}
+
+// validationMessage is the error message for a request that fails binding.
+const validationMessage = "request is not valid"
+
+// RegisterFieldNames reports the fields that fail binding by the name used in the request rather than the Go field
+// name. It must be called before any request is bound.
+func RegisterFieldNames() {
+	if v, ok := binding.Validator.Engine().(*validator.Validate); ok {
+		v.RegisterTagNameFunc(fieldName)
+	}
+}
+
+// Abort stops the request and responds with the error using the status code of its Kind.
+func Abort(ctx *gin.Context, err error) {
+	kind := apperror.KindOf(err)
+
+	fields := apperror.FieldsOf(err)
+	details := make([]Detail, len(fields))
+
+	for i, f := range fields {
+		details[i] = Detail(f)
+	}
+
+	ctx.AbortWithStatusJSON(statusMap[kind], Error{
+		Error:   err.Error(),
+		Code:    string(kind),
+		Details: details,
+	})
+}
+
+// AbortBinding stops a request that failed binding and responds with a validation error.
+func AbortBinding(ctx *gin.Context, err error) {
+	var details []Detail
+
+	var vErrs validator.ValidationErrors
+	if errors.As(err, &vErrs) {
+		for _, e := range vErrs {
+			details = append(details, Detail{
+				Field:   e.Field(),
+				Message: "failed on the " + e.Tag() + " rule",
+			})
+		}
+	}
+
+	ctx.AbortWithStatusJSON(http.StatusUnprocessableEntity, Error{
+		Error:   validationMessage,
+		Code:    string(apperror.Validation),
+		Details: details,
+	})
+}
+
+// fieldName returns the name of the field as it appears in the JSON body, URI, query or headers of the request.
+func fieldName(f reflect.StructField) string {
+	for _, tag := range []string{"json", "uri", "form", "header"} {
+		name := strings.Split(f.Tag.Get(tag), ",")[0]
+		if name == "-" {
+			return ""
+		}
+
+		if name != "" {
+			return name
+		}
+	}
+
+	return f.Name
+}
@@ -0,0 +1,159 @@
+package response
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/clarke94/roulette-service/internal/pkg/apperror"
+	"github.com/gin-gonic/gin"
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestAbort(t *testing.T) {
+	tests := []struct {
+		name     string
+		err      error
+		wantCode int
+		wantBody Error
+	}{
+		{
+			name:     "expect 404 given not found error",
+			err:      apperror.New(apperror.NotFound, "foo not found"),
+			wantCode: http.StatusNotFound,
+			wantBody: Error{Error: "foo not found", Code: "not_found"},
+		},
+		{
+			name: "expect 422 with details given validation error with fields",
+			err: apperror.WithFields(
+				apperror.New(apperror.Validation, "foo is not valid"),
+				apperror.Field{Field: "bar", Message: "baz"},
+			),
+			wantCode: http.StatusUnprocessableEntity,
+			wantBody: Error{
+				Error:   "foo is not valid",
+				Code:    "validation",
+				Details: []Detail{{Field: "bar", Message: "baz"}},
+			},
+		},
+		{
+			name:     "expect 409 given conflict error",
+			err:      apperror.New(apperror.Conflict, "foo"),
+			wantCode: http.StatusConflict,
+			wantBody: Error{Error: "foo", Code: "conflict"},
+		},
+		{
+			name:     "expect 422 given limit exceeded error",
+			err:      apperror.New(apperror.LimitExceeded, "foo"),
+			wantCode: http.StatusUnprocessableEntity,
+			wantBody: Error{Error: "foo", Code: "limit_exceeded"},
+		},
+		{
+			name:     "expect 403 given forbidden error",
+			err:      apperror.New(apperror.Forbidden, "foo"),
+			wantCode: http.StatusForbidden,
+			wantBody: Error{Error: "foo", Code: "forbidden"},
+		},
+		{
+			name:     "expect 412 given precondition failed error",
+			err:      apperror.New(apperror.PreconditionFailed, "foo"),
+			wantCode: http.StatusPreconditionFailed,
+			wantBody: Error{Error: "foo", Code: "precondition_failed"},
+		},
+		{
+			name:     "expect 428 given precondition required error",
+			err:      apperror.New(apperror.PreconditionRequired, "foo"),
+			wantCode: http.StatusPreconditionRequired,
+			wantBody: Error{Error: "foo", Code: "precondition_required"},
+		},
+		{
+			name:     "expect 500 given any other error",
+			err:      errors.New("foo"),
+			wantCode: http.StatusInternalServerError,
+			wantBody: Error{Error: "foo", Code: "internal"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			ctx, _ := gin.CreateTestContext(w)
+
+			Abort(ctx, tt.err)
+
+			if !cmp.Equal(w.Code, tt.wantCode) {
+				t.Error(w.Code, tt.wantCode)
+			}
+
+			var got Error
+			if err := json.Unmarshal(w.Body.Bytes(), &got); err != nil {
+				t.Fatal(err)
+			}
+
+			if !cmp.Equal(got, tt.wantBody) {
+				t.Error(cmp.Diff(got, tt.wantBody))
+			}
+		})
+	}
+}
+
+func TestAbortBinding(t *testing.T) {
+	RegisterFieldNames()
+
+	type request struct {
+		PlayerID string `json:"playerId" binding:"required,uuid"`
+	}
+
+	tests := []struct {
+		name     string
+		body     string
+		wantBody Error
+	}{
+		{
+			name: "expect details given field that fails validation",
+			body: `{"playerId": "foo"}`,
+			wantBody: Error{
+				Error:   validationMessage,
+				Code:    "validation",
+				Details: []Detail{{Field: "playerId", Message: "failed on the uuid rule"}},
+			},
+		},
+		{
+			name: "expect no details given malformed body",
+			body: `{`,
+			wantBody: Error{
+				Error: validationMessage,
+				Code:  "validation",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			_, router := gin.CreateTestContext(w)
+
+			router.Handle(http.MethodPost, "/", func(ctx *gin.Context) {
+				var r request
+				if err := ctx.ShouldBindJSON(&r); err != nil {
+					AbortBinding(ctx, err)
+				}
+			})
+			router.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/", strings.NewReader(tt.body)))
+
+			if !cmp.Equal(w.Code, http.StatusUnprocessableEntity) {
+				t.Error(w.Code, http.StatusUnprocessableEntity)
+			}
+
+			var got Error
+			if err := json.Unmarshal(w.Body.Bytes(), &got); err != nil {
+				t.Fatal(err)
+			}
+
+			if !cmp.Equal(got, tt.wantBody) {
+				t.Error(cmp.Diff(got, tt.wantBody))
+			}
+		})
+	}
+}
@@ -0,0 +1,109 @@
+package round
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/clarke94/roulette-service/cmd/serve/response"
+	"github.com/clarke94/roulette-service/internal/pkg/round"
+	"github.com/gin-gonic/gin"
+)
+
+// ControllerProvider provides an interface for the domain controller.
+type ControllerProvider interface {
+	List(ctx context.Context, tableID string, filter round.Filter) ([]round.Round, string, error)
+	Get(ctx context.Context, tableID, id string) (round.Round, error)
+	Verify(ctx context.Context, tableID, id string) (round.Verification, error)
+}
+
+// Handler provides a presentation handler.
+type Handler struct {
+	Controller ControllerProvider
+}
+
+// NewHandler initializes a new Handler.
+func NewHandler(controller ControllerProvider) Handler {
+	return Handler{
+		Controller: controller,
+	}
+}
+
+// List invokes the List controller and returns response.
+func (h Handler) List(ctx *gin.Context) {
+	var params TableParam
+	if err := ctx.ShouldBindUri(&params); err != nil {
+		response.AbortBinding(ctx, err)
+
+		return
+	}
+
+	var query Query
+	if err := ctx.ShouldBindQuery(&query); err != nil {
+		response.AbortBinding(ctx, err)
+
+		return
+	}
+
+	rounds, next, err := h.Controller.List(ctx, params.Table, queryToDomain(query))
+	if err != nil {
+		response.Abort(ctx, err)
+
+		return
+	}
+
+	response.NextCursor(ctx, next)
+
+	ctx.JSON(http.StatusOK, domainListToPresentation(rounds))
+}
+
+// Get invokes the Get controller and returns response.
+func (h Handler) Get(ctx *gin.Context) {
+	var tableParam TableParam
+	if err := ctx.ShouldBindUri(&tableParam); err != nil {
+		response.AbortBinding(ctx, err)
+
+		return
+	}
+
+	var roundParam IDParam
+	if err := ctx.ShouldBindUri(&roundParam); err != nil {
+		response.AbortBinding(ctx, err)
+
+		return
+	}
+
+	model, err := h.Controller.Get(ctx, tableParam.Table, roundParam.Round)
+	if err != nil {
+		response.Abort(ctx, err)
+
+		return
+	}
+
+	ctx.JSON(http.StatusOK, domainToPresentation(&model))
+}
+
+// Verify invokes the Verify controller and returns response.
+func (h Handler) Verify(ctx *gin.Context) {
+	var tableParam TableParam
+	if err := ctx.ShouldBindUri(&tableParam); err != nil {
+		response.AbortBinding(ctx, err)
+
+		return
+	}
+
+	var roundParam IDParam
+	if err := ctx.ShouldBindUri(&roundParam); err != nil {
+		response.AbortBinding(ctx, err)
+
+		return
+	}
+
+	model, err := h.Controller.Verify(ctx, tableParam.Table, roundParam.Round)
+	if err != nil {
+		response.Abort(ctx, err)
+
+		return
+	}
+
+	ctx.JSON(http.StatusOK, verificationToPresentation(model))
+}
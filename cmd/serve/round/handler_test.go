@@ -0,0 +1,301 @@
+package round
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/clarke94/roulette-service/cmd/serve/response"
+	"github.com/clarke94/roulette-service/internal/pkg/round"
+	"github.com/gin-gonic/gin"
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/uuid"
+)
+
+func TestNewHandler(t *testing.T) {
+	tests := []struct {
+		name       string
+		controller ControllerProvider
+		want       Handler
+	}{
+		{
+			name:       "expect Handler to init",
+			controller: mockController{},
+			want: Handler{
+				Controller: mockController{},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := NewHandler(tt.controller)
+
+			if !cmp.Equal(got, tt.want) {
+				t.Error(cmp.Diff(got, tt.want))
+			}
+		})
+	}
+}
+
+func TestHandler_List(t *testing.T) {
+	tests := []struct {
+		name       string
+		controller ControllerProvider
+		tableID    string
+		query      string
+		wantCode   int
+		wantNext   string
+	}{
+		{
+			name: "expect 200 given rounds found",
+			controller: mockController{
+				GivenList: []round.Round{
+					{
+						ID:     uuid.New().String(),
+						State:  round.StateSettled,
+						Number: 17,
+						Color:  "black",
+						SpunAt: time.Now(),
+					},
+				},
+				GivenNext: "foo",
+			},
+			tableID:  uuid.New().String(),
+			query:    "?limit=10&from=2021-06-21T21:00:00Z&to=2021-06-21T22:00:00Z",
+			wantCode: http.StatusOK,
+			wantNext: "foo",
+		},
+		{
+			name:       "expect 422 given invalid table ID",
+			controller: mockController{},
+			tableID:    "foo",
+			wantCode:   http.StatusUnprocessableEntity,
+		},
+		{
+			name:       "expect 422 given invalid limit",
+			controller: mockController{},
+			tableID:    uuid.New().String(),
+			query:      "?limit=1000",
+			wantCode:   http.StatusUnprocessableEntity,
+		},
+		{
+			name:       "expect 422 given invalid date",
+			controller: mockController{},
+			tableID:    uuid.New().String(),
+			query:      "?from=yesterday",
+			wantCode:   http.StatusUnprocessableEntity,
+		},
+		{
+			name: "expect 500 given Controller error",
+			controller: mockController{
+				GivenError: errors.New("foo"),
+			},
+			tableID:  uuid.New().String(),
+			wantCode: http.StatusInternalServerError,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := NewHandler(tt.controller)
+
+			r := httptest.NewRequest(http.MethodGet, "/"+tt.tableID+"/rounds"+tt.query, nil)
+			w := httptest.NewRecorder()
+			ctx, router := gin.CreateTestContext(w)
+			ctx.Request = r
+
+			router.Handle(http.MethodGet, "/:table/rounds", h.List)
+			router.ServeHTTP(w, r)
+
+			if !cmp.Equal(w.Code, tt.wantCode) {
+				t.Error(w.Code, tt.wantCode)
+			}
+
+			if !cmp.Equal(w.Header().Get(response.HeaderNextCursor), tt.wantNext) {
+				t.Error(cmp.Diff(w.Header().Get(response.HeaderNextCursor), tt.wantNext))
+			}
+		})
+	}
+}
+
+func TestHandler_Get(t *testing.T) {
+	tests := []struct {
+		name       string
+		controller ControllerProvider
+		tableID    string
+		id         string
+		wantCode   int
+	}{
+		{
+			name: "expect 200 given round found",
+			controller: mockController{
+				GivenRound: round.Round{
+					ID:    uuid.New().String(),
+					State: round.StateOpen,
+				},
+			},
+			tableID:  uuid.New().String(),
+			id:       uuid.New().String(),
+			wantCode: http.StatusOK,
+		},
+		{
+			name:       "expect 422 given invalid round ID",
+			controller: mockController{},
+			tableID:    uuid.New().String(),
+			id:         "foo",
+			wantCode:   http.StatusUnprocessableEntity,
+		},
+		{
+			name: "expect 404 given round not found",
+			controller: mockController{
+				GivenError: round.ErrNotFound,
+			},
+			tableID:  uuid.New().String(),
+			id:       uuid.New().String(),
+			wantCode: http.StatusNotFound,
+		},
+		{
+			name: "expect 500 given Controller error",
+			controller: mockController{
+				GivenError: errors.New("foo"),
+			},
+			tableID:  uuid.New().String(),
+			id:       uuid.New().String(),
+			wantCode: http.StatusInternalServerError,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := NewHandler(tt.controller)
+
+			r := httptest.NewRequest(http.MethodGet, "/"+tt.tableID+"/rounds/"+tt.id, nil)
+			w := httptest.NewRecorder()
+			ctx, router := gin.CreateTestContext(w)
+			ctx.Request = r
+
+			router.Handle(http.MethodGet, "/:table/rounds/:round", h.Get)
+			router.ServeHTTP(w, r)
+
+			if !cmp.Equal(w.Code, tt.wantCode) {
+				t.Error(w.Code, tt.wantCode)
+			}
+		})
+	}
+}
+
+func TestHandler_Verify(t *testing.T) {
+	tests := []struct {
+		name       string
+		controller ControllerProvider
+		tableID    string
+		id         string
+		wantCode   int
+		wantBody   Verification
+	}{
+		{
+			name: "expect 200 given settled provably fair round",
+			controller: mockController{
+				GivenVerification: round.Verification{
+					RoundID:     "8117bb87-148c-4fb1-8971-a2d4373b3f19",
+					ServerSeed:  "server",
+					ClientSeed:  "client",
+					Wheel:       "american",
+					Number:      round.DoubleZero,
+					HashMatches: true,
+					Matches:     true,
+				},
+			},
+			tableID:  uuid.New().String(),
+			id:       uuid.New().String(),
+			wantCode: http.StatusOK,
+			wantBody: Verification{
+				RoundID:     "8117bb87-148c-4fb1-8971-a2d4373b3f19",
+				ServerSeed:  "server",
+				ClientSeed:  "client",
+				Wheel:       "american",
+				Number:      round.DoubleZero,
+				Pocket:      "00",
+				HashMatches: true,
+				Matches:     true,
+			},
+		},
+		{
+			name:       "expect 422 given invalid round ID",
+			controller: mockController{},
+			tableID:    uuid.New().String(),
+			id:         "foo",
+			wantCode:   http.StatusUnprocessableEntity,
+		},
+		{
+			name: "expect 409 given round not spun provably fair",
+			controller: mockController{
+				GivenError: round.ErrNotFair,
+			},
+			tableID:  uuid.New().String(),
+			id:       uuid.New().String(),
+			wantCode: http.StatusConflict,
+		},
+		{
+			name: "expect 404 given round not found",
+			controller: mockController{
+				GivenError: round.ErrNotFound,
+			},
+			tableID:  uuid.New().String(),
+			id:       uuid.New().String(),
+			wantCode: http.StatusNotFound,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := NewHandler(tt.controller)
+
+			r := httptest.NewRequest(http.MethodGet, "/"+tt.tableID+"/rounds/"+tt.id+"/verify", nil)
+			w := httptest.NewRecorder()
+			ctx, router := gin.CreateTestContext(w)
+			ctx.Request = r
+
+			router.Handle(http.MethodGet, "/:table/rounds/:round/verify", h.Verify)
+			router.ServeHTTP(w, r)
+
+			if !cmp.Equal(w.Code, tt.wantCode) {
+				t.Fatal(w.Code, tt.wantCode)
+			}
+
+			if w.Code != http.StatusOK {
+				return
+			}
+
+			var got Verification
+			if err := json.Unmarshal(w.Body.Bytes(), &got); err != nil {
+				t.Fatal(err)
+			}
+
+			if !cmp.Equal(got, tt.wantBody) {
+				t.Error(cmp.Diff(got, tt.wantBody))
+			}
+		})
+	}
+}
+
+type mockController struct {
+	GivenRound        round.Round
+	GivenList         []round.Round
+	GivenNext         string
+	GivenVerification round.Verification
+	GivenError        error
+}
+
+func (m mockController) List(_ context.Context, _ string, _ round.Filter) ([]round.Round, string, error) {
+	return m.GivenList, m.GivenNext, m.GivenError
+}
+
+func (m mockController) Get(_ context.Context, _, _ string) (round.Round, error) {
+	return m.GivenRound, m.GivenError
+}
+
+func (m mockController) Verify(_ context.Context, _, _ string) (round.Verification, error) {
+	return m.GivenVerification, m.GivenError
+}
@@ -0,0 +1,145 @@
+package round
+
+import (
+	"time"
+
+	"github.com/clarke94/roulette-service/internal/pkg/page"
+	"github.com/clarke94/roulette-service/internal/pkg/round"
+)
+
+// TableParam is the URL parameter binding for the table ID associated with a Round.
+type TableParam struct {
+	Table string `uri:"table" binding:"required,uuid"`
+}
+
+// IDParam is the URL parameter binding the round ID.
+type IDParam struct {
+	Round string `uri:"round" binding:"required,uuid"`
+}
+
+// Query is the query string binding for filtering and paginating rounds.
+type Query struct {
+	Limit  int       `form:"limit" binding:"omitempty,gte=1,lte=100"`
+	Cursor string    `form:"cursor"`
+	From   time.Time `form:"from" time_format:"2006-01-02T15:04:05Z07:00"`
+	To     time.Time `form:"to" time_format:"2006-01-02T15:04:05Z07:00"`
+}
+
+// Round is a presentation API model.
+// ServerSeed is only present once the round is settled.
+type Round struct {
+	ID             string     `json:"id"`
+	State          string     `json:"state"`
+	Number         *int       `json:"number,omitempty"`
+	Pocket         string     `json:"pocket,omitempty"`
+	Color          string     `json:"color,omitempty"`
+	TotalStaked    int64      `json:"totalStaked"`
+	TotalPaid      int64      `json:"totalPaid"`
+	Winners        []Winner   `json:"winners"`
+	ProvablyFair   bool       `json:"provablyFair"`
+	ServerSeedHash string     `json:"serverSeedHash"`
+	ServerSeed     string     `json:"serverSeed,omitempty"`
+	ClientSeed     string     `json:"clientSeed,omitempty"`
+	Nonce          *int64     `json:"nonce,omitempty"`
+	SpunAt         *time.Time `json:"spunAt,omitempty"`
+	CreatedAt      time.Time  `json:"createdAt"`
+}
+
+// Verification is the outcome of a provably fair round recomputed from its seeds.
+type Verification struct {
+	RoundID        string `json:"roundId"`
+	ServerSeed     string `json:"serverSeed"`
+	ServerSeedHash string `json:"serverSeedHash"`
+	ClientSeed     string `json:"clientSeed"`
+	Nonce          int64  `json:"nonce"`
+	Wheel          string `json:"wheel"`
+	Number         int    `json:"number"`
+	Pocket         string `json:"pocket"`
+	HashMatches    bool   `json:"hashMatches"`
+	Matches        bool   `json:"matches"`
+}
+
+// Winner is a winning bet from a round.
+type Winner struct {
+	BetID      string `json:"betId"`
+	Stake      int64  `json:"stake"`
+	Multiplier int64  `json:"multiplier"`
+	Winnings   int64  `json:"winnings"`
+	Return     int64  `json:"return"`
+	Currency   string `json:"currency"`
+	Outcome    string `json:"outcome"`
+}
+
+func queryToDomain(t Query) round.Filter {
+	return round.Filter{
+		Page: page.Page{
+			Limit:  t.Limit,
+			Cursor: t.Cursor,
+		},
+		From: t.From,
+		To:   t.To,
+	}
+}
+
+func domainToPresentation(t *round.Round) Round {
+	r := Round{
+		ID:             t.ID,
+		State:          t.State,
+		Color:          t.Color,
+		TotalStaked:    t.TotalStaked,
+		TotalPaid:      t.TotalPaid,
+		Winners:        make([]Winner, len(t.Winners)),
+		CreatedAt:      t.CreatedAt,
+		ProvablyFair:   t.ProvablyFair,
+		ServerSeedHash: t.ServerSeedHash,
+		ClientSeed:     t.ClientSeed,
+	}
+
+	for i := range t.Winners {
+		r.Winners[i] = Winner(t.Winners[i])
+	}
+
+	if !t.SpunAt.IsZero() {
+		number := t.Number
+		spunAt := t.SpunAt
+		r.Number = &number
+		r.Pocket = round.Pocket(number)
+		r.SpunAt = &spunAt
+	}
+
+	if t.ProvablyFair && !t.SpunAt.IsZero() {
+		nonce := t.Nonce
+		r.Nonce = &nonce
+	}
+
+	if t.State == round.StateSettled {
+		r.ServerSeed = t.ServerSeed
+	}
+
+	return r
+}
+
+func verificationToPresentation(t round.Verification) Verification {
+	return Verification{
+		RoundID:        t.RoundID,
+		ServerSeed:     t.ServerSeed,
+		ServerSeedHash: t.ServerSeedHash,
+		ClientSeed:     t.ClientSeed,
+		Nonce:          t.Nonce,
+		Wheel:          t.Wheel,
+		Number:         t.Number,
+		Pocket:         round.Pocket(t.Number),
+		HashMatches:    t.HashMatches,
+		Matches:        t.Matches,
+	}
+}
+
+func domainListToPresentation(t []round.Round) []Round {
+	rounds := make([]Round, len(t))
+
+	for i := range t {
+		rounds[i] = domainToPresentation(&t[i])
+	}
+
+	return rounds
+}
@@ -0,0 +1,18 @@
+package round
+
+import (
+	domain "github.com/clarke94/roulette-service/internal/pkg/round"
+	storage "github.com/clarke94/roulette-service/storage/round"
+	tableStorage "github.com/clarke94/roulette-service/storage/table"
+	"github.com/gin-gonic/gin"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+// Module initializes all round dependencies.
+func Module(router *gin.Engine, logger *logrus.Logger, db *gorm.DB) {
+	store := storage.New(db)
+	controller := domain.New(logger, store, tableStorage.New(db))
+	handler := NewHandler(controller)
+	NewRouter(router, handler)
+}
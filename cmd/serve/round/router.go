@@ -0,0 +1,16 @@
+package round
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// NewRouter initializes all round routes.
+func NewRouter(router *gin.Engine, handler Handler) {
+	v1 := router.Group("/v1")
+
+	v1.Handle(http.MethodGet, "/table/:table/rounds", handler.List)
+	v1.Handle(http.MethodGet, "/table/:table/rounds/:round", handler.Get)
+	v1.Handle(http.MethodGet, "/table/:table/rounds/:round/verify", handler.Verify)
+}
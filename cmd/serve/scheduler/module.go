@@ -0,0 +1,49 @@
+package scheduler
+
+import (
+	"context"
+
+	"github.com/clarke94/roulette-service/internal/pkg/bet"
+	"github.com/clarke94/roulette-service/internal/pkg/event"
+	"github.com/clarke94/roulette-service/internal/pkg/ledger"
+	"github.com/clarke94/roulette-service/internal/pkg/round"
+	domain "github.com/clarke94/roulette-service/internal/pkg/scheduler"
+	"github.com/clarke94/roulette-service/internal/pkg/table"
+	"github.com/clarke94/roulette-service/internal/pkg/wallet"
+	betStorage "github.com/clarke94/roulette-service/storage/bet"
+	ledgerStorage "github.com/clarke94/roulette-service/storage/ledger"
+	roundStorage "github.com/clarke94/roulette-service/storage/round"
+	tableStorage "github.com/clarke94/roulette-service/storage/table"
+	"github.com/clarke94/roulette-service/storage/transaction"
+	walletStorage "github.com/clarke94/roulette-service/storage/wallet"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+// Module initializes all scheduler dependencies and runs the scheduler until the context is done, spinning the wheel
+// with the given source of random numbers and publishing the events of each table to the given hub.
+// The returned channel is closed once the scheduler has stopped.
+func Module(
+	ctx context.Context,
+	logger *logrus.Logger,
+	db *gorm.DB,
+	random bet.RandomProvider,
+	events *event.Hub,
+) <-chan struct{} {
+	tables := table.New(logger, tableStorage.New(db), betStorage.New(db), transaction.New(db))
+	rounds := round.New(logger, roundStorage.New(db), tables)
+	entries := ledger.New(logger, ledgerStorage.New(db))
+	wallets := wallet.New(logger, walletStorage.New(db), entries, transaction.New(db))
+	games := bet.New(logger, betStorage.New(db), tables, rounds, wallets, entries, transaction.New(db), random, events)
+	scheduler := domain.New(logger, tables, rounds, games)
+
+	done := make(chan struct{})
+
+	go func() {
+		defer close(done)
+
+		scheduler.Run(ctx)
+	}()
+
+	return done
+}
@@ -0,0 +1,38 @@
+package scheduler
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/clarke94/roulette-service/internal/pkg/event"
+	"github.com/clarke94/roulette-service/internal/pkg/rng"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+func TestModule(t *testing.T) {
+	tests := []struct {
+		name   string
+		logger *logrus.Logger
+		db     *gorm.DB
+	}{
+		{
+			name:   "expect Module to stop once the context is done",
+			logger: logrus.New(),
+			db:     &gorm.DB{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx, cancel := context.WithCancel(context.Background())
+			cancel()
+
+			select {
+			case <-Module(ctx, tt.logger, tt.db, rng.NewCrypto(), event.NewHub()):
+			case <-time.After(time.Second):
+				t.Fatal("expect scheduler to stop once the context is done")
+			}
+		})
+	}
+}
@@ -11,10 +11,24 @@ import (
 	"time"
 
 	"github.com/clarke94/roulette-service/cmd/serve/bet"
+	"github.com/clarke94/roulette-service/cmd/serve/event"
+	"github.com/clarke94/roulette-service/cmd/serve/idempotency"
+	"github.com/clarke94/roulette-service/cmd/serve/ledger"
 	"github.com/clarke94/roulette-service/cmd/serve/openapi"
+	"github.com/clarke94/roulette-service/cmd/serve/response"
+	"github.com/clarke94/roulette-service/cmd/serve/round"
+	"github.com/clarke94/roulette-service/cmd/serve/scheduler"
 	"github.com/clarke94/roulette-service/cmd/serve/table"
+	"github.com/clarke94/roulette-service/cmd/serve/wallet"
+	domain "github.com/clarke94/roulette-service/internal/pkg/bet"
+	hub "github.com/clarke94/roulette-service/internal/pkg/event"
+	"github.com/clarke94/roulette-service/internal/pkg/rng"
 	betStorage "github.com/clarke94/roulette-service/storage/bet"
+	idempotencyStorage "github.com/clarke94/roulette-service/storage/idempotency"
+	ledgerStorage "github.com/clarke94/roulette-service/storage/ledger"
+	roundStorage "github.com/clarke94/roulette-service/storage/round"
 	storage "github.com/clarke94/roulette-service/storage/table"
+	walletStorage "github.com/clarke94/roulette-service/storage/wallet"
 	"github.com/gin-gonic/gin"
 	"github.com/sirupsen/logrus"
 	"github.com/spf13/cobra"
@@ -45,17 +59,35 @@ func New() *cobra.Command {
 	}
 }
 
-// Run will run a HTTP server and gracefully shutdown on fatal error.
+// Run will run a HTTP server and the scheduler of automatic spins, gracefully shutting both down on fatal error.
 func (h *Handler) Run(_ *cobra.Command, _ []string) {
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	response.RegisterFieldNames()
+
 	router := gin.Default()
 	logger := logrus.New()
 	db := h.newDatabase(logger)
+	random := h.newRandom(logger)
+	events := hub.NewHub()
 
+	idempotency.Module(router, logger, db, viper.GetDuration("IDEMPOTENCY_TTL"), viper.GetDuration("IDEMPOTENCY_LEASE"))
 	openapi.Module(router, logger)
-	table.Module(router, logger, db)
-	bet.Module(router, logger, db)
+	table.Module(router, logger, db, random, events)
+	bet.Module(router, logger, db, random, events)
+	round.Module(router, logger, db)
+	wallet.Module(router, logger, db)
+	ledger.Module(router, logger, db)
+	event.Module(router, logger, db, events)
 
-	h.newServer(router, logger)
+	scheduled := scheduler.Module(ctx, logger, db, random, events)
+
+	h.newServer(ctx, stop, router, logger, events)
+
+	<-scheduled
+
+	logger.Warn("server exiting")
 }
 
 func (h *Handler) newDatabase(logger *logrus.Logger) *gorm.DB {
@@ -79,7 +111,15 @@ func (h *Handler) newDatabase(logger *logrus.Logger) *gorm.DB {
 		return nil
 	}
 
-	err = db.AutoMigrate(storage.Table{}, betStorage.Bet{})
+	err = db.AutoMigrate(
+		storage.Table{},
+		betStorage.Bet{},
+		roundStorage.Round{},
+		roundStorage.Winner{},
+		walletStorage.Wallet{},
+		idempotencyStorage.Key{},
+		ledgerStorage.Entry{},
+	)
 	if err != nil {
 		logger.WithFields(logrus.Fields{
 			"error": err.Error(),
@@ -91,18 +131,65 @@ func (h *Handler) newDatabase(logger *logrus.Logger) *gorm.DB {
 	return db
 }
 
-func (h *Handler) newServer(router *gin.Engine, logger *logrus.Logger) {
-	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
-	defer stop()
+// newRandom returns the source of random numbers selected by RNG_SOURCE, defaulting to crypto.
+// The seeded and scripted sources are repeatable and so are refused when APP_ENV is production.
+func (h *Handler) newRandom(logger *logrus.Logger) domain.RandomProvider {
+	source := viper.GetString("RNG_SOURCE")
+	if source == "" || source == rng.SourceCrypto {
+		return rng.NewCrypto()
+	}
+
+	if viper.GetString("APP_ENV") == "production" {
+		logger.WithFields(logrus.Fields{
+			"source": source,
+		}).Fatalln("only the crypto random number source can be used in production")
+
+		return nil
+	}
+
+	switch source {
+	case rng.SourceSeeded:
+		return rng.NewSeeded(viper.GetInt64("RNG_SEED"))
+	case rng.SourceScripted:
+		outcomes, err := rng.ParseOutcomes(viper.GetString("RNG_OUTCOMES"))
+		if err != nil {
+			logger.WithFields(logrus.Fields{
+				"error": err.Error(),
+			}).Fatalln("unable to initialize random number source")
+
+			return nil
+		}
+
+		return rng.NewScripted(outcomes...)
+	}
 
+	logger.WithFields(logrus.Fields{
+		"error": rng.ErrSource.Error(),
+	}).Fatalln("unable to initialize random number source")
+
+	return nil
+}
+
+// newServer serves HTTP until the context is done, calling stop so a second signal terminates immediately.
+// Event streams outlive the write timeout of the server, so they are ended by closing the hub on shutdown.
+func (h *Handler) newServer(
+	ctx context.Context,
+	stop context.CancelFunc,
+	router *gin.Engine,
+	logger *logrus.Logger,
+	events *hub.Hub,
+) {
 	srv := &http.Server{
 		Addr:           fmt.Sprintf(":%s", viper.GetString("PORT")),
 		ReadTimeout:    serverTimeoutSeconds * time.Second,
 		WriteTimeout:   serverTimeoutSeconds * time.Second,
 		MaxHeaderBytes: serverMaxHeaderBytes,
 		Handler:        router,
+		ConnContext:    event.ConnContext,
 	}
 
+	srv.RegisterOnShutdown(events.Close)
+
 	go func() {
 		if err := srv.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
 			logger.WithFields(logrus.Fields{
@@ -117,16 +204,12 @@ func (h *Handler) newServer(router *gin.Engine, logger *logrus.Logger) {
 
 	stop()
 
-	ctx, cancel := context.WithTimeout(context.Background(), serverTimeoutSeconds*time.Second)
+	shutdown, cancel := context.WithTimeout(context.Background(), serverTimeoutSeconds*time.Second)
 	defer cancel()
 
-	if err := srv.Shutdown(ctx); err != nil {
+	if err := srv.Shutdown(shutdown); err != nil {
 		logger.WithFields(logrus.Fields{
 			"error": err.Error(),
 		}).Error("server forced to shutdown")
-
-		return
 	}
-
-	logger.Warn("server exiting")
 }
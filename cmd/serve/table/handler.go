@@ -4,6 +4,8 @@ import (
 	"context"
 	"net/http"
 
+	"github.com/clarke94/roulette-service/cmd/serve/patch"
+	"github.com/clarke94/roulette-service/cmd/serve/response"
 	"github.com/clarke94/roulette-service/internal/pkg/table"
 	"github.com/gin-gonic/gin"
 )
@@ -11,33 +13,42 @@ import (
 // ControllerProvider provides an interface for the domain controller.
 type ControllerProvider interface {
 	Create(ctx context.Context, model table.Table) (string, error)
-	List(ctx context.Context) ([]table.Table, error)
+	List(ctx context.Context, filter table.Filter) ([]table.Table, string, error)
+	Get(ctx context.Context, id string) (table.Table, error)
 	Update(ctx context.Context, model table.Table) (string, error)
-	Delete(ctx context.Context, id string) (string, error)
+}
+
+// BetProvider provides an interface to the bet domain, which deletes a table along with the bets in play on it.
+type BetProvider interface {
+	DeleteTable(ctx context.Context, tableID string, force bool) (string, error)
 }
 
 // Handler provides a presentation handler.
 type Handler struct {
 	Controller ControllerProvider
+	Bets       BetProvider
 }
 
 // NewHandler initializes a new Handler.
-func NewHandler(controller ControllerProvider) Handler {
+func NewHandler(controller ControllerProvider, bets BetProvider) Handler {
 	return Handler{
 		Controller: controller,
+		Bets:       bets,
 	}
 }
 
 // Create invokes the Create controller and returns response.
 func (h Handler) Create(ctx *gin.Context) {
 	var model Table
-	if err := ctx.BindJSON(&model); err != nil {
+	if err := ctx.ShouldBindJSON(&model); err != nil {
+		response.AbortBinding(ctx, err)
+
 		return
 	}
 
 	id, err := h.Controller.Create(ctx, presentationToDomain(model))
 	if err != nil {
-		ctx.AbortWithStatusJSON(http.StatusBadRequest, Error{Error: err.Error()})
+		response.Abort(ctx, err)
 
 		return
 	}
@@ -47,51 +58,142 @@ func (h Handler) Create(ctx *gin.Context) {
 
 // List invokes the List controller and returns response.
 func (h Handler) List(ctx *gin.Context) {
-	tables, err := h.Controller.List(ctx)
+	var query Query
+	if err := ctx.ShouldBindQuery(&query); err != nil {
+		response.AbortBinding(ctx, err)
+
+		return
+	}
+
+	tables, next, err := h.Controller.List(ctx, queryToDomain(query))
 	if err != nil {
-		ctx.AbortWithStatusJSON(http.StatusBadRequest, Error{Error: err.Error()})
+		response.Abort(ctx, err)
 
 		return
 	}
 
+	response.NextCursor(ctx, next)
+
 	ctx.JSON(http.StatusOK, domainListToPresentation(tables))
 }
 
-// Update invokes the Update controller and returns response.
+// Get invokes the Get controller and returns response, or 304 Not Modified when the client already has the table.
+func (h Handler) Get(ctx *gin.Context) {
+	var params IDParam
+	if err := ctx.ShouldBindUri(&params); err != nil {
+		response.AbortBinding(ctx, err)
+
+		return
+	}
+
+	model, err := h.Controller.Get(ctx, params.Table)
+	if err != nil {
+		response.Abort(ctx, err)
+
+		return
+	}
+
+	response.JSONWithETag(ctx, http.StatusOK, model.Version, domainToPresentation(model))
+}
+
+// Update invokes the Update controller with the version of the table held in If-Match and returns response along
+// with the ETag of the new version.
 func (h Handler) Update(ctx *gin.Context) {
 	var model Update
-	if err := ctx.BindJSON(&model); err != nil {
+	if err := ctx.ShouldBindJSON(&model); err != nil {
+		response.AbortBinding(ctx, err)
+
 		return
 	}
 
-	domainModel := presentationToDomain(Table{
-		ID:         model.ID,
-		Name:       model.Table.Name,
-		MaximumBet: model.Table.MaximumBet,
-		MinimumBet: model.Table.MinimumBet,
-		Currency:   model.Table.Currency,
-	})
+	version, err := response.IfMatch(ctx)
+	if err != nil {
+		response.Abort(ctx, err)
+
+		return
+	}
+
+	domainModel := presentationToDomain(model.Table)
+	domainModel.ID = model.ID
+	domainModel.Version = version
 
 	id, err := h.Controller.Update(ctx, domainModel)
 	if err != nil {
-		ctx.AbortWithStatusJSON(http.StatusBadRequest, Error{Error: err.Error()})
+		response.Abort(ctx, err)
 
 		return
 	}
 
+	ctx.Header(response.HeaderETag, response.ETag(version+1))
 	ctx.JSON(http.StatusOK, Upsert{ID: id})
 }
 
-// Delete invokes the Delete controller and returns an id.
+// Patch applies the JSON Merge Patch in the request body to the table, validates the result like a new table and
+// invokes the Update controller with the version of the table held in If-Match.
+func (h Handler) Patch(ctx *gin.Context) {
+	var params IDParam
+	if err := ctx.ShouldBindUri(&params); err != nil {
+		response.AbortBinding(ctx, err)
+
+		return
+	}
+
+	version, err := response.IfMatch(ctx)
+	if err != nil {
+		response.Abort(ctx, err)
+
+		return
+	}
+
+	current, err := h.Controller.Get(ctx, params.Table)
+	if err != nil {
+		response.Abort(ctx, err)
+
+		return
+	}
+
+	var model Table
+	if err := patch.Bind(ctx, domainToPresentation(current), &model); err != nil {
+		response.AbortBinding(ctx, err)
+
+		return
+	}
+
+	domainModel := presentationToDomain(model)
+	domainModel.ID = params.Table
+	domainModel.Version = version
+
+	id, err := h.Controller.Update(ctx, domainModel)
+	if err != nil {
+		response.Abort(ctx, err)
+
+		return
+	}
+
+	ctx.Header(response.HeaderETag, response.ETag(version+1))
+	ctx.JSON(http.StatusOK, Upsert{ID: id})
+}
+
+// Delete invokes the DeleteTable controller of the bet domain, refunding any bets in play when forced, and returns
+// an id.
 func (h Handler) Delete(ctx *gin.Context) {
 	var param IDParam
-	if err := ctx.BindUri(&param); err != nil {
+	if err := ctx.ShouldBindUri(&param); err != nil {
+		response.AbortBinding(ctx, err)
+
+		return
+	}
+
+	var query ForceQuery
+	if err := ctx.ShouldBindQuery(&query); err != nil {
+		response.AbortBinding(ctx, err)
+
 		return
 	}
 
-	deletedID, err := h.Controller.Delete(ctx, param.Table)
+	deletedID, err := h.Bets.DeleteTable(ctx, param.Table, query.Force)
 	if err != nil {
-		ctx.AbortWithStatusJSON(http.StatusBadRequest, Error{Error: err.Error()})
+		response.Abort(ctx, err)
 
 		return
 	}
@@ -4,6 +4,10 @@ import (
 	"bytes"
 	"context"
 	"errors"
+	"github.com/clarke94/roulette-service/cmd/serve/patch"
+	"github.com/clarke94/roulette-service/cmd/serve/response"
+	"github.com/clarke94/roulette-service/internal/pkg/bet"
+	"github.com/clarke94/roulette-service/internal/pkg/page"
 	"github.com/clarke94/roulette-service/internal/pkg/table"
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
@@ -18,19 +22,22 @@ func TestNewHandler(t *testing.T) {
 	tests := []struct {
 		name       string
 		controller ControllerProvider
+		bets       BetProvider
 		want       Handler
 	}{
 		{
 			name:       "expect Handler to init",
 			controller: mockController{},
+			bets:       mockBets{},
 			want: Handler{
 				Controller: mockController{},
+				Bets:       mockBets{},
 			},
 		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			got := NewHandler(tt.controller)
+			got := NewHandler(tt.controller, tt.bets)
 
 			if !cmp.Equal(got, tt.want) {
 				t.Error(cmp.Diff(got, tt.want))
@@ -55,20 +62,54 @@ func TestHandler_Create(t *testing.T) {
 			wantCode: http.StatusCreated,
 		},
 		{
-			name: "expect 400 given no body",
+			name: "expect 201 given table with American wheel created",
+			controller: mockController{
+				GivenID: uuid.New().String(),
+			},
+			body:     []byte(`{"name":"foo", "maximumBet": 1000, "minimumBet": 100, "currency":"GBP", "wheel":"american"}`),
+			wantCode: http.StatusCreated,
+		},
+		{
+			name:       "expect 422 given unknown wheel",
+			controller: mockController{},
+			body:       []byte(`{"name":"foo", "maximumBet": 1000, "minimumBet": 100, "currency":"GBP", "wheel":"foo"}`),
+			wantCode:   http.StatusUnprocessableEntity,
+		},
+		{
+			name:       "expect 422 given unknown rules",
+			controller: mockController{},
+			body:       []byte(`{"name":"foo", "maximumBet": 1000, "minimumBet": 100, "currency":"GBP", "rules":"foo"}`),
+			wantCode:   http.StatusUnprocessableEntity,
+		},
+		{
+			name: "expect 201 given automatic spins",
+			controller: mockController{
+				GivenID: uuid.New().String(),
+			},
+			body:     []byte(`{"name":"foo", "maximumBet": 1000, "minimumBet": 100, "currency":"GBP", "bettingWindow": 30, "noMoreBets": 5, "spinInterval": 60}`),
+			wantCode: http.StatusCreated,
+		},
+		{
+			name:       "expect 422 given negative betting window",
+			controller: mockController{},
+			body:       []byte(`{"name":"foo", "maximumBet": 1000, "minimumBet": 100, "currency":"GBP", "bettingWindow": -1}`),
+			wantCode:   http.StatusUnprocessableEntity,
+		},
+		{
+			name: "expect 422 given no body",
 			controller: mockController{
 				GivenID: uuid.New().String(),
 			},
 			body:     nil,
-			wantCode: http.StatusBadRequest,
+			wantCode: http.StatusUnprocessableEntity,
 		},
 		{
-			name: "expect 400 given Controller error",
+			name: "expect 500 given Controller error",
 			controller: mockController{
 				GivenError: errors.New("foo"),
 			},
 			body:     []byte(`{"name":"foo", "maximumBet": 1000, "minimumBet": 100, "currency":"GBP"}`),
-			wantCode: http.StatusBadRequest,
+			wantCode: http.StatusInternalServerError,
 		},
 	}
 	for _, tt := range tests {
@@ -79,7 +120,7 @@ func TestHandler_Create(t *testing.T) {
 
 			ctx.Request = r
 
-			h := NewHandler(tt.controller)
+			h := NewHandler(tt.controller, mockBets{})
 			h.Create(ctx)
 
 			if !cmp.Equal(w.Code, tt.wantCode) {
@@ -93,7 +134,9 @@ func TestHandler_List(t *testing.T) {
 	tests := []struct {
 		name       string
 		controller ControllerProvider
+		query      string
 		wantCode   int
+		wantNext   string
 	}{
 		{
 			name: "expect 200 given table created",
@@ -107,31 +150,149 @@ func TestHandler_List(t *testing.T) {
 						Currency:   "GBP",
 					},
 				},
+				GivenNext: "foo",
 			},
+			query:    "?limit=10&currency=GBP&wheel=american&from=2021-06-21T21:00:00Z&order=desc",
 			wantCode: http.StatusOK,
+			wantNext: "foo",
+		},
+		{
+			name:       "expect 422 given invalid limit",
+			controller: mockController{},
+			query:      "?limit=1000",
+			wantCode:   http.StatusUnprocessableEntity,
 		},
 		{
-			name: "expect 400 given Controller error",
+			name:       "expect 422 given invalid order",
+			controller: mockController{},
+			query:      "?order=foo",
+			wantCode:   http.StatusUnprocessableEntity,
+		},
+		{
+			name:       "expect 422 given invalid currency",
+			controller: mockController{},
+			query:      "?currency=foo",
+			wantCode:   http.StatusUnprocessableEntity,
+		},
+		{
+			name: "expect 422 given invalid cursor",
+			controller: mockController{
+				GivenError: page.ErrCursor,
+			},
+			query:    "?cursor=foo",
+			wantCode: http.StatusUnprocessableEntity,
+		},
+		{
+			name: "expect 500 given Controller error",
 			controller: mockController{
 				GivenError: errors.New("foo"),
 			},
-			wantCode: http.StatusBadRequest,
+			wantCode: http.StatusInternalServerError,
 		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			r := httptest.NewRequest(http.MethodGet, "/", nil)
+			r := httptest.NewRequest(http.MethodGet, "/"+tt.query, nil)
 			w := httptest.NewRecorder()
 			ctx, _ := gin.CreateTestContext(w)
 
 			ctx.Request = r
 
-			h := NewHandler(tt.controller)
+			h := NewHandler(tt.controller, mockBets{})
 			h.List(ctx)
 
 			if !cmp.Equal(w.Code, tt.wantCode) {
 				t.Error(w.Code, tt.wantCode)
 			}
+
+			if !cmp.Equal(w.Header().Get(response.HeaderNextCursor), tt.wantNext) {
+				t.Error(cmp.Diff(w.Header().Get(response.HeaderNextCursor), tt.wantNext))
+			}
+		})
+	}
+}
+
+func TestHandler_Get(t *testing.T) {
+	found := table.Table{
+		ID:         "84b10ade-d28a-11eb-b8bc-0242ac130003",
+		Name:       "Table 1",
+		MaximumBet: 10000,
+		MinimumBet: 1000,
+		Currency:   "GBP",
+		Version:    3,
+	}
+
+	tag := response.ETag(found.Version)
+
+	tests := []struct {
+		name        string
+		controller  ControllerProvider
+		id          string
+		ifNoneMatch string
+		wantCode    int
+		wantETag    string
+	}{
+		{
+			name:       "expect 200 given table found",
+			controller: mockController{GivenTable: found},
+			id:         found.ID,
+			wantCode:   http.StatusOK,
+			wantETag:   tag,
+		},
+		{
+			name:        "expect 304 given table not modified",
+			controller:  mockController{GivenTable: found},
+			id:          found.ID,
+			ifNoneMatch: tag,
+			wantCode:    http.StatusNotModified,
+			wantETag:    tag,
+		},
+		{
+			name:       "expect 422 given invalid ID",
+			controller: mockController{},
+			id:         "foo",
+			wantCode:   http.StatusUnprocessableEntity,
+		},
+		{
+			name: "expect 404 given table not found",
+			controller: mockController{
+				GivenError: table.ErrNotFound,
+			},
+			id:       found.ID,
+			wantCode: http.StatusNotFound,
+		},
+		{
+			name: "expect 500 given Controller error",
+			controller: mockController{
+				GivenError: errors.New("foo"),
+			},
+			id:       found.ID,
+			wantCode: http.StatusInternalServerError,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := NewHandler(tt.controller, mockBets{})
+
+			r := httptest.NewRequest(http.MethodGet, "/"+tt.id, nil)
+			if tt.ifNoneMatch != "" {
+				r.Header.Set(response.HeaderIfNoneMatch, tt.ifNoneMatch)
+			}
+
+			w := httptest.NewRecorder()
+			ctx, router := gin.CreateTestContext(w)
+			ctx.Request = r
+
+			router.Handle(http.MethodGet, "/:table", h.Get)
+			router.ServeHTTP(w, r)
+
+			if !cmp.Equal(w.Code, tt.wantCode) {
+				t.Error(w.Code, tt.wantCode)
+			}
+
+			if !cmp.Equal(w.Header().Get(response.HeaderETag), tt.wantETag) {
+				t.Error(cmp.Diff(w.Header().Get(response.HeaderETag), tt.wantETag))
+			}
 		})
 	}
 }
@@ -141,7 +302,9 @@ func TestHandler_Update(t *testing.T) {
 		name       string
 		controller ControllerProvider
 		body       []byte
+		ifMatch    string
 		wantCode   int
+		wantETag   string
 	}{
 		{
 			name: "expect 200 given table updated",
@@ -149,78 +312,311 @@ func TestHandler_Update(t *testing.T) {
 				GivenID: uuid.New().String(),
 			},
 			body:     []byte(`{"id":"42bb1490-d28e-11eb-b8bc-0242ac130003", "name":"foo", "maximumBet": 1000, "minimumBet": 100, "currency":"GBP"}`),
+			ifMatch:  `"1"`,
 			wantCode: http.StatusOK,
+			wantETag: `"2"`,
 		},
 		{
-			name: "expect 400 given no body",
+			name: "expect 422 given no body",
 			controller: mockController{
 				GivenID: uuid.New().String(),
 			},
 			body:     nil,
-			wantCode: http.StatusBadRequest,
+			ifMatch:  `"1"`,
+			wantCode: http.StatusUnprocessableEntity,
+		},
+		{
+			name: "expect 428 given no If-Match",
+			controller: mockController{
+				GivenID: uuid.New().String(),
+			},
+			body:     []byte(`{"id":"42bb1490-d28e-11eb-b8bc-0242ac130003", "name":"foo", "maximumBet": 1000, "minimumBet": 100, "currency":"GBP"}`),
+			wantCode: http.StatusPreconditionRequired,
+		},
+		{
+			name: "expect 412 given table has changed",
+			controller: mockController{
+				GivenError: table.ErrVersion,
+			},
+			body:     []byte(`{"id":"42bb1490-d28e-11eb-b8bc-0242ac130003", "name":"foo", "maximumBet": 1000, "minimumBet": 100, "currency":"GBP"}`),
+			ifMatch:  `"1"`,
+			wantCode: http.StatusPreconditionFailed,
+		},
+		{
+			name: "expect 409 given wheel changed while bets in play",
+			controller: mockController{
+				GivenError: table.ErrInPlay,
+			},
+			body:     []byte(`{"id":"42bb1490-d28e-11eb-b8bc-0242ac130003", "name":"foo", "maximumBet": 1000, "minimumBet": 100, "currency":"GBP", "wheel":"american"}`),
+			ifMatch:  `"1"`,
+			wantCode: http.StatusConflict,
 		},
 		{
-			name: "expect 400 given Controller error",
+			name: "expect 500 given Controller error",
 			controller: mockController{
 				GivenError: errors.New("foo"),
 			},
 			body:     []byte(`{"id":"42bb1490-d28e-11eb-b8bc-0242ac130003", "name":"foo", "maximumBet": 1000, "minimumBet": 100, "currency":"GBP"}`),
-			wantCode: http.StatusBadRequest,
+			ifMatch:  `"1"`,
+			wantCode: http.StatusInternalServerError,
 		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			r := httptest.NewRequest(http.MethodPost, "/", bytes.NewReader(tt.body))
+			if tt.ifMatch != "" {
+				r.Header.Set(response.HeaderIfMatch, tt.ifMatch)
+			}
+
 			w := httptest.NewRecorder()
 			ctx, _ := gin.CreateTestContext(w)
 
 			ctx.Request = r
 
-			h := NewHandler(tt.controller)
+			h := NewHandler(tt.controller, mockBets{})
 			h.Update(ctx)
 
 			if !cmp.Equal(w.Code, tt.wantCode) {
 				t.Error(w.Code, tt.wantCode)
 			}
+
+			if !cmp.Equal(w.Header().Get(response.HeaderETag), tt.wantETag) {
+				t.Error(cmp.Diff(w.Header().Get(response.HeaderETag), tt.wantETag))
+			}
+		})
+	}
+}
+
+func TestHandler_Patch(t *testing.T) {
+	current := table.Table{
+		ID:              "84b10ade-d28a-11eb-b8bc-0242ac130003",
+		Name:            "Table 1",
+		MaximumBet:      10000,
+		MinimumBet:      1000,
+		Currency:        "GBP",
+		MaximumExposure: 50000,
+		Wheel:           table.WheelEuropean,
+		Rules:           table.RulesStandard,
+		ProvablyFair:    true,
+		BettingWindow:   30,
+		Version:         1,
+	}
+
+	tests := []struct {
+		name        string
+		controller  mockController
+		id          string
+		body        string
+		ifMatch     string
+		wantCode    int
+		wantETag    string
+		wantUpdated table.Table
+	}{
+		{
+			name:       "expect 200 given patched table",
+			controller: mockController{GivenID: current.ID, GivenTable: current},
+			id:         current.ID,
+			body:       `{"name":"Table 2","minimumBet":500}`,
+			ifMatch:    `"1"`,
+			wantCode:   http.StatusOK,
+			wantETag:   `"2"`,
+			wantUpdated: table.Table{
+				ID:              current.ID,
+				Name:            "Table 2",
+				MaximumBet:      10000,
+				MinimumBet:      500,
+				Currency:        "GBP",
+				MaximumExposure: 50000,
+				Wheel:           table.WheelEuropean,
+				Rules:           table.RulesStandard,
+				ProvablyFair:    true,
+				BettingWindow:   30,
+				Version:         1,
+			},
+		},
+		{
+			name:       "expect 200 given zero values and nulls cleared",
+			controller: mockController{GivenID: current.ID, GivenTable: current},
+			id:         current.ID,
+			body:       `{"maximumExposure":0,"provablyFair":false,"bettingWindow":null}`,
+			ifMatch:    `"1"`,
+			wantCode:   http.StatusOK,
+			wantETag:   `"2"`,
+			wantUpdated: table.Table{
+				ID:         current.ID,
+				Name:       "Table 1",
+				MaximumBet: 10000,
+				MinimumBet: 1000,
+				Currency:   "GBP",
+				Wheel:      table.WheelEuropean,
+				Rules:      table.RulesStandard,
+				Version:    1,
+			},
+		},
+		{
+			name:       "expect 422 given invalid ID",
+			controller: mockController{},
+			id:         "foo",
+			body:       `{"name":"Table 2"}`,
+			ifMatch:    `"1"`,
+			wantCode:   http.StatusUnprocessableEntity,
+		},
+		{
+			name:       "expect 422 given required field removed",
+			controller: mockController{GivenTable: current},
+			id:         current.ID,
+			body:       `{"name":null}`,
+			ifMatch:    `"1"`,
+			wantCode:   http.StatusUnprocessableEntity,
+		},
+		{
+			name:       "expect 422 given patched table not valid",
+			controller: mockController{GivenTable: current},
+			id:         current.ID,
+			body:       `{"maximumBet":100}`,
+			ifMatch:    `"1"`,
+			wantCode:   http.StatusUnprocessableEntity,
+		},
+		{
+			name:       "expect 422 given invalid patch",
+			controller: mockController{GivenTable: current},
+			id:         current.ID,
+			body:       `{`,
+			ifMatch:    `"1"`,
+			wantCode:   http.StatusUnprocessableEntity,
+		},
+		{
+			name:       "expect 428 given no If-Match",
+			controller: mockController{GivenTable: current},
+			id:         current.ID,
+			body:       `{"name":"Table 2"}`,
+			wantCode:   http.StatusPreconditionRequired,
+		},
+		{
+			name: "expect 404 given table not found",
+			controller: mockController{
+				GivenError: table.ErrNotFound,
+			},
+			id:       current.ID,
+			body:     `{"name":"Table 2"}`,
+			ifMatch:  `"1"`,
+			wantCode: http.StatusNotFound,
+		},
+		{
+			name: "expect 500 given Controller error",
+			controller: mockController{
+				GivenError: errors.New("foo"),
+			},
+			id:       current.ID,
+			body:     `{"name":"Table 2"}`,
+			ifMatch:  `"1"`,
+			wantCode: http.StatusInternalServerError,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var updated table.Table
+
+			tt.controller.Updated = &updated
+			h := NewHandler(tt.controller, mockBets{})
+
+			r := httptest.NewRequest(http.MethodPatch, "/"+tt.id, bytes.NewReader([]byte(tt.body)))
+			r.Header.Set("Content-Type", patch.ContentType)
+
+			if tt.ifMatch != "" {
+				r.Header.Set(response.HeaderIfMatch, tt.ifMatch)
+			}
+
+			w := httptest.NewRecorder()
+			ctx, router := gin.CreateTestContext(w)
+			ctx.Request = r
+
+			router.Handle(http.MethodPatch, "/:table", h.Patch)
+			router.ServeHTTP(w, r)
+
+			if !cmp.Equal(w.Code, tt.wantCode) {
+				t.Error(w.Code, tt.wantCode)
+			}
+
+			if !cmp.Equal(w.Header().Get(response.HeaderETag), tt.wantETag) {
+				t.Error(cmp.Diff(w.Header().Get(response.HeaderETag), tt.wantETag))
+			}
+
+			if !cmp.Equal(updated, tt.wantUpdated) {
+				t.Error(cmp.Diff(updated, tt.wantUpdated))
+			}
 		})
 	}
 }
 
 func TestHandler_Delete(t *testing.T) {
 	tests := []struct {
-		name       string
-		controller ControllerProvider
-		id         string
-		wantCode   int
+		name     string
+		bets     BetProvider
+		id       string
+		query    string
+		wantCode int
 	}{
 		{
 			name: "expect 200 given table deleted",
-			controller: mockController{
-				GivenID: uuid.New().String(),
+			bets: mockBets{
+				GivenID: "84b10ade-d28a-11eb-b8bc-0242ac130003",
 			},
 			id:       "84b10ade-d28a-11eb-b8bc-0242ac130003",
 			wantCode: http.StatusOK,
 		},
 		{
-			name:       "expect 400 given invalid ID",
-			controller: mockController{},
-			id:         "foo",
-			wantCode:   http.StatusBadRequest,
+			name: "expect 200 given table deleted with force",
+			bets: mockBets{
+				GivenID: "84b10ade-d28a-11eb-b8bc-0242ac130003",
+			},
+			id:       "84b10ade-d28a-11eb-b8bc-0242ac130003",
+			query:    "?force=true",
+			wantCode: http.StatusOK,
 		},
 		{
-			name: "expect 400 given Controller error",
-			controller: mockController{
+			name:     "expect 422 given invalid ID",
+			bets:     mockBets{},
+			id:       "foo",
+			wantCode: http.StatusUnprocessableEntity,
+		},
+		{
+			name:     "expect 422 given invalid force",
+			bets:     mockBets{},
+			id:       "84b10ade-d28a-11eb-b8bc-0242ac130003",
+			query:    "?force=foo",
+			wantCode: http.StatusUnprocessableEntity,
+		},
+		{
+			name: "expect 404 given table not found",
+			bets: mockBets{
+				GivenError: bet.ErrTable,
+			},
+			id:       "84b10ade-d28a-11eb-b8bc-0242ac130003",
+			wantCode: http.StatusNotFound,
+		},
+		{
+			name: "expect 409 given bets in play",
+			bets: mockBets{
+				GivenError: bet.ErrOpenBets,
+			},
+			id:       "84b10ade-d28a-11eb-b8bc-0242ac130003",
+			wantCode: http.StatusConflict,
+		},
+		{
+			name: "expect 500 given Controller error",
+			bets: mockBets{
 				GivenError: errors.New("foo"),
 			},
 			id:       "84b10ade-d28a-11eb-b8bc-0242ac130003",
-			wantCode: http.StatusBadRequest,
+			wantCode: http.StatusInternalServerError,
 		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			h := NewHandler(tt.controller)
+			h := NewHandler(mockController{}, tt.bets)
 
-			r := httptest.NewRequest(http.MethodDelete, "/"+tt.id, nil)
+			r := httptest.NewRequest(http.MethodDelete, "/"+tt.id+tt.query, nil)
 			w := httptest.NewRecorder()
 			ctx, router := gin.CreateTestContext(w)
 			ctx.Request = r
@@ -237,22 +633,38 @@ func TestHandler_Delete(t *testing.T) {
 
 type mockController struct {
 	GivenList  []table.Table
+	GivenNext  string
+	GivenTable table.Table
 	GivenID    string
 	GivenError error
+	Updated    *table.Table
 }
 
-func (m mockController) Delete(_ context.Context, _ string) (string, error) {
+func (m mockController) Update(_ context.Context, model table.Table) (string, error) {
+	if m.Updated != nil {
+		*m.Updated = model
+	}
+
 	return m.GivenID, m.GivenError
 }
 
-func (m mockController) Update(_ context.Context, _ table.Table) (string, error) {
-	return m.GivenID, m.GivenError
+func (m mockController) List(_ context.Context, _ table.Filter) ([]table.Table, string, error) {
+	return m.GivenList, m.GivenNext, m.GivenError
 }
 
-func (m mockController) List(_ context.Context) ([]table.Table, error) {
-	return m.GivenList, m.GivenError
+func (m mockController) Get(_ context.Context, _ string) (table.Table, error) {
+	return m.GivenTable, m.GivenError
 }
 
 func (m mockController) Create(_ context.Context, _ table.Table) (string, error) {
 	return m.GivenID, m.GivenError
 }
+
+type mockBets struct {
+	GivenID    string
+	GivenError error
+}
+
+func (m mockBets) DeleteTable(_ context.Context, _ string, _ bool) (string, error) {
+	return m.GivenID, m.GivenError
+}
@@ -1,6 +1,9 @@
 package table
 
 import (
+	"time"
+
+	"github.com/clarke94/roulette-service/internal/pkg/page"
 	"github.com/clarke94/roulette-service/internal/pkg/table"
 )
 
@@ -9,13 +12,39 @@ type IDParam struct {
 	Table string `uri:"table" binding:"required,uuid"`
 }
 
+// ForceQuery is the query binding for deleting a table along with the bets in play on it.
+type ForceQuery struct {
+	Force bool `form:"force"`
+}
+
+// Query is the query string binding for filtering, sorting and paginating tables.
+type Query struct {
+	Limit    int       `form:"limit" binding:"omitempty,gte=1,lte=100"`
+	Cursor   string    `form:"cursor"`
+	Currency string    `form:"currency" binding:"omitempty,oneof=GBP USD EUR"`
+	Wheel    string    `form:"wheel" binding:"omitempty,oneof=european american"`
+	Rules    string    `form:"rules" binding:"omitempty,oneof=standard la-partage en-prison"`
+	From     time.Time `form:"from" time_format:"2006-01-02T15:04:05Z07:00"`
+	To       time.Time `form:"to" time_format:"2006-01-02T15:04:05Z07:00"`
+	Order    string    `form:"order" binding:"omitempty,oneof=asc desc"`
+}
+
 // Table is a presentation API model.
+// The version of the table is only exposed as its ETag.
 type Table struct {
-	ID         string `json:"id,omitempty"`
-	Name       string `json:"name" binding:"required"`
-	MaximumBet int    `json:"maximumBet" binding:"required,gte=10,gtefield=MinimumBet"`
-	MinimumBet int    `json:"minimumBet" binding:"required,gte=10"`
-	Currency   string `json:"currency" binding:"required,oneof=GBP USD EUR"`
+	ID                 string `json:"id,omitempty"`
+	Name               string `json:"name" binding:"required"`
+	MaximumBet         int    `json:"maximumBet" binding:"required,gte=10,gtefield=MinimumBet"`
+	MinimumBet         int    `json:"minimumBet" binding:"required,gte=10"`
+	Currency           string `json:"currency" binding:"required,oneof=GBP USD EUR"`
+	MaximumPlayerStake int    `json:"maximumPlayerStake,omitempty" binding:"omitempty,gtefield=MaximumBet"`
+	MaximumExposure    int    `json:"maximumExposure,omitempty" binding:"gte=0"`
+	Wheel              string `json:"wheel,omitempty" binding:"omitempty,oneof=european american"`
+	Rules              string `json:"rules,omitempty" binding:"omitempty,oneof=standard la-partage en-prison"`
+	ProvablyFair       bool   `json:"provablyFair"`
+	BettingWindow      int    `json:"bettingWindow,omitempty" binding:"gte=0"`
+	NoMoreBets         int    `json:"noMoreBets,omitempty" binding:"gte=0"`
+	SpinInterval       int    `json:"spinInterval,omitempty" binding:"gte=0"`
 }
 
 // Update is a Table with a required ID binding.
@@ -29,17 +58,55 @@ type Upsert struct {
 	ID string `json:"id"`
 }
 
-// Error is a presentation API model for the Error response.
-type Error struct {
-	Error string `json:"error"`
+func queryToDomain(t Query) table.Filter {
+	return table.Filter{
+		Page: page.Page{
+			Limit:  t.Limit,
+			Cursor: t.Cursor,
+		},
+		Currency: t.Currency,
+		Wheel:    t.Wheel,
+		Rules:    t.Rules,
+		From:     t.From,
+		To:       t.To,
+		Order:    t.Order,
+	}
 }
 
 func presentationToDomain(t Table) table.Table {
-	return table.Table(t)
+	return table.Table{
+		ID:                 t.ID,
+		Name:               t.Name,
+		MaximumBet:         t.MaximumBet,
+		MinimumBet:         t.MinimumBet,
+		Currency:           t.Currency,
+		MaximumPlayerStake: t.MaximumPlayerStake,
+		MaximumExposure:    t.MaximumExposure,
+		Wheel:              t.Wheel,
+		Rules:              t.Rules,
+		ProvablyFair:       t.ProvablyFair,
+		BettingWindow:      t.BettingWindow,
+		NoMoreBets:         t.NoMoreBets,
+		SpinInterval:       t.SpinInterval,
+	}
 }
 
 func domainToPresentation(t table.Table) Table {
-	return Table(t)
+	return Table{
+		ID:                 t.ID,
+		Name:               t.Name,
+		MaximumBet:         t.MaximumBet,
+		MinimumBet:         t.MinimumBet,
+		Currency:           t.Currency,
+		MaximumPlayerStake: t.MaximumPlayerStake,
+		MaximumExposure:    t.MaximumExposure,
+		Wheel:              t.Wheel,
+		Rules:              t.Rules,
+		ProvablyFair:       t.ProvablyFair,
+		BettingWindow:      t.BettingWindow,
+		NoMoreBets:         t.NoMoreBets,
+		SpinInterval:       t.SpinInterval,
+	}
 }
 
 func domainListToPresentation(t []table.Table) []Table {
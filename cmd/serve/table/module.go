@@ -1,17 +1,32 @@
 package table
 
 import (
+	"github.com/clarke94/roulette-service/internal/pkg/bet"
+	"github.com/clarke94/roulette-service/internal/pkg/event"
+	"github.com/clarke94/roulette-service/internal/pkg/ledger"
+	"github.com/clarke94/roulette-service/internal/pkg/round"
 	domain "github.com/clarke94/roulette-service/internal/pkg/table"
+	"github.com/clarke94/roulette-service/internal/pkg/wallet"
+	betStorage "github.com/clarke94/roulette-service/storage/bet"
+	ledgerStorage "github.com/clarke94/roulette-service/storage/ledger"
+	roundStorage "github.com/clarke94/roulette-service/storage/round"
 	storage "github.com/clarke94/roulette-service/storage/table"
+	"github.com/clarke94/roulette-service/storage/transaction"
+	walletStorage "github.com/clarke94/roulette-service/storage/wallet"
 	"github.com/gin-gonic/gin"
 	"github.com/sirupsen/logrus"
 	"gorm.io/gorm"
 )
 
-// Module initializes all table dependencies.
-func Module(router *gin.Engine, logger *logrus.Logger, db *gorm.DB) {
+// Module initializes all table dependencies, deleting a table through the bet domain so the bets in play on it are
+// refunded and the events of the table are published to the given hub.
+func Module(router *gin.Engine, logger *logrus.Logger, db *gorm.DB, random bet.RandomProvider, events *event.Hub) {
 	store := storage.New(db)
-	controller := domain.New(logger, store)
-	handler := NewHandler(controller)
+	controller := domain.New(logger, store, betStorage.New(db), transaction.New(db))
+	rounds := round.New(logger, roundStorage.New(db), controller)
+	entries := ledger.New(logger, ledgerStorage.New(db))
+	wallets := wallet.New(logger, walletStorage.New(db), entries, transaction.New(db))
+	bets := bet.New(logger, betStorage.New(db), controller, rounds, wallets, entries, transaction.New(db), random, events)
+	handler := NewHandler(controller, bets)
 	NewRouter(router, handler)
 }
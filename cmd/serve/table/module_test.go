@@ -1,6 +1,8 @@
 package table
 
 import (
+	"github.com/clarke94/roulette-service/internal/pkg/event"
+	"github.com/clarke94/roulette-service/internal/pkg/rng"
 	"github.com/gin-gonic/gin"
 	"github.com/go-playground/validator/v10"
 	"github.com/sirupsen/logrus"
@@ -26,7 +28,7 @@ func TestModule(t *testing.T) {
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			Module(tt.router, tt.logger, tt.db)
+			Module(tt.router, tt.logger, tt.db, rng.NewCrypto(), event.NewHub())
 		})
 	}
 }
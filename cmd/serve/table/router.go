@@ -12,6 +12,8 @@ func NewRouter(router *gin.Engine, handler Handler) {
 
 	v1.Handle(http.MethodPost, "/table", handler.Create)
 	v1.Handle(http.MethodGet, "/table", handler.List)
+	v1.Handle(http.MethodGet, "/table/:table", handler.Get)
 	v1.Handle(http.MethodPut, "/table", handler.Update)
+	v1.Handle(http.MethodPatch, "/table/:table", handler.Patch)
 	v1.Handle(http.MethodDelete, "/table/:table", handler.Delete)
 }
@@ -0,0 +1,107 @@
+package wallet
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/clarke94/roulette-service/cmd/serve/response"
+	"github.com/clarke94/roulette-service/internal/pkg/wallet"
+	"github.com/gin-gonic/gin"
+)
+
+// ControllerProvider provides an interface for the domain controller.
+type ControllerProvider interface {
+	Create(ctx context.Context, model wallet.Wallet) (string, error)
+	List(ctx context.Context, playerID string) ([]wallet.Wallet, error)
+	Deposit(ctx context.Context, playerID, currency string, amount int64) error
+}
+
+// Handler provides a presentation handler.
+type Handler struct {
+	Controller ControllerProvider
+}
+
+// NewHandler initializes a new Handler.
+func NewHandler(controller ControllerProvider) Handler {
+	return Handler{
+		Controller: controller,
+	}
+}
+
+// Create invokes the Create controller and returns response.
+func (h Handler) Create(ctx *gin.Context) {
+	var params PlayerParam
+	if err := ctx.ShouldBindUri(&params); err != nil {
+		response.AbortBinding(ctx, err)
+
+		return
+	}
+
+	var model Wallet
+	if err := ctx.ShouldBindJSON(&model); err != nil {
+		response.AbortBinding(ctx, err)
+
+		return
+	}
+
+	id, err := h.Controller.Create(ctx, presentationToDomain(model, params.Player))
+	if err != nil {
+		response.Abort(ctx, err)
+
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, Upsert{ID: id})
+}
+
+// List invokes the List controller and returns response.
+func (h Handler) List(ctx *gin.Context) {
+	var params PlayerParam
+	if err := ctx.ShouldBindUri(&params); err != nil {
+		response.AbortBinding(ctx, err)
+
+		return
+	}
+
+	wallets, err := h.Controller.List(ctx, params.Player)
+	if err != nil {
+		response.Abort(ctx, err)
+
+		return
+	}
+
+	ctx.JSON(http.StatusOK, domainListToPresentation(wallets))
+}
+
+// Deposit invokes the Deposit controller and returns response.
+func (h Handler) Deposit(ctx *gin.Context) {
+	var playerParam PlayerParam
+	if err := ctx.ShouldBindUri(&playerParam); err != nil {
+		response.AbortBinding(ctx, err)
+
+		return
+	}
+
+	var currencyParam CurrencyParam
+	if err := ctx.ShouldBindUri(&currencyParam); err != nil {
+		response.AbortBinding(ctx, err)
+
+		return
+	}
+
+	var model Deposit
+	if err := ctx.ShouldBindJSON(&model); err != nil {
+		response.AbortBinding(ctx, err)
+
+		return
+	}
+
+	err := h.Controller.Deposit(ctx, playerParam.Player, currencyParam.Currency, model.Amount)
+	if err != nil {
+		response.Abort(ctx, err)
+
+		return
+	}
+
+	ctx.Status(http.StatusNoContent)
+}
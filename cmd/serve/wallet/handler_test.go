@@ -0,0 +1,256 @@
+package wallet
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/clarke94/roulette-service/internal/pkg/wallet"
+	"github.com/gin-gonic/gin"
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/uuid"
+)
+
+func TestNewHandler(t *testing.T) {
+	tests := []struct {
+		name       string
+		controller ControllerProvider
+		want       Handler
+	}{
+		{
+			name:       "expect Handler to init",
+			controller: mockController{},
+			want: Handler{
+				Controller: mockController{},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := NewHandler(tt.controller)
+
+			if !cmp.Equal(got, tt.want) {
+				t.Error(cmp.Diff(got, tt.want))
+			}
+		})
+	}
+}
+
+func TestHandler_Create(t *testing.T) {
+	tests := []struct {
+		name       string
+		controller ControllerProvider
+		playerID   string
+		body       []byte
+		wantCode   int
+	}{
+		{
+			name: "expect 201 given wallet created",
+			controller: mockController{
+				GivenID: uuid.New().String(),
+			},
+			playerID: uuid.New().String(),
+			body:     []byte(`{"currency": "GBP", "balance": 1000}`),
+			wantCode: http.StatusCreated,
+		},
+		{
+			name:       "expect 422 given invalid wallet request",
+			controller: mockController{},
+			playerID:   uuid.New().String(),
+			body:       []byte(`{"currency": "foo"}`),
+			wantCode:   http.StatusUnprocessableEntity,
+		},
+		{
+			name:       "expect 422 given invalid player ID",
+			controller: mockController{},
+			playerID:   "foo",
+			body:       []byte(`{"currency": "GBP", "balance": 1000}`),
+			wantCode:   http.StatusUnprocessableEntity,
+		},
+		{
+			name: "expect 409 given wallet already exists for currency",
+			controller: mockController{
+				GivenError: wallet.ErrExists,
+			},
+			playerID: uuid.New().String(),
+			body:     []byte(`{"currency": "GBP", "balance": 1000}`),
+			wantCode: http.StatusConflict,
+		},
+		{
+			name: "expect 500 given Controller error",
+			controller: mockController{
+				GivenError: errors.New("foo"),
+			},
+			playerID: uuid.New().String(),
+			body:     []byte(`{"currency": "GBP", "balance": 1000}`),
+			wantCode: http.StatusInternalServerError,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := NewHandler(tt.controller)
+
+			r := httptest.NewRequest(http.MethodPost, "/"+tt.playerID+"/wallets", bytes.NewReader(tt.body))
+			w := httptest.NewRecorder()
+			ctx, router := gin.CreateTestContext(w)
+			ctx.Request = r
+
+			router.Handle(http.MethodPost, "/:player/wallets", h.Create)
+			router.ServeHTTP(w, r)
+
+			if !cmp.Equal(w.Code, tt.wantCode) {
+				t.Error(w.Code, tt.wantCode)
+			}
+		})
+	}
+}
+
+func TestHandler_List(t *testing.T) {
+	tests := []struct {
+		name       string
+		controller ControllerProvider
+		playerID   string
+		wantCode   int
+	}{
+		{
+			name: "expect 200 given wallets found",
+			controller: mockController{
+				GivenList: []wallet.Wallet{
+					{
+						ID:       uuid.New().String(),
+						Currency: "GBP",
+						Balance:  1000,
+						Reserved: 100,
+					},
+				},
+			},
+			playerID: uuid.New().String(),
+			wantCode: http.StatusOK,
+		},
+		{
+			name:       "expect 422 given invalid player ID",
+			controller: mockController{},
+			playerID:   "foo",
+			wantCode:   http.StatusUnprocessableEntity,
+		},
+		{
+			name: "expect 500 given Controller error",
+			controller: mockController{
+				GivenError: errors.New("foo"),
+			},
+			playerID: uuid.New().String(),
+			wantCode: http.StatusInternalServerError,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := NewHandler(tt.controller)
+
+			r := httptest.NewRequest(http.MethodGet, "/"+tt.playerID+"/wallets", nil)
+			w := httptest.NewRecorder()
+			ctx, router := gin.CreateTestContext(w)
+			ctx.Request = r
+
+			router.Handle(http.MethodGet, "/:player/wallets", h.List)
+			router.ServeHTTP(w, r)
+
+			if !cmp.Equal(w.Code, tt.wantCode) {
+				t.Error(w.Code, tt.wantCode)
+			}
+		})
+	}
+}
+
+func TestHandler_Deposit(t *testing.T) {
+	tests := []struct {
+		name       string
+		controller ControllerProvider
+		playerID   string
+		currency   string
+		body       []byte
+		wantCode   int
+	}{
+		{
+			name:       "expect 204 given deposit made",
+			controller: mockController{},
+			playerID:   uuid.New().String(),
+			currency:   "GBP",
+			body:       []byte(`{"amount": 100}`),
+			wantCode:   http.StatusNoContent,
+		},
+		{
+			name:       "expect 422 given invalid amount",
+			controller: mockController{},
+			playerID:   uuid.New().String(),
+			currency:   "GBP",
+			body:       []byte(`{"amount": -100}`),
+			wantCode:   http.StatusUnprocessableEntity,
+		},
+		{
+			name:       "expect 422 given invalid currency",
+			controller: mockController{},
+			playerID:   uuid.New().String(),
+			currency:   "foo",
+			body:       []byte(`{"amount": 100}`),
+			wantCode:   http.StatusUnprocessableEntity,
+		},
+		{
+			name: "expect 404 given wallet not found",
+			controller: mockController{
+				GivenError: wallet.ErrNotFound,
+			},
+			playerID: uuid.New().String(),
+			currency: "GBP",
+			body:     []byte(`{"amount": 100}`),
+			wantCode: http.StatusNotFound,
+		},
+		{
+			name: "expect 500 given Controller error",
+			controller: mockController{
+				GivenError: errors.New("foo"),
+			},
+			playerID: uuid.New().String(),
+			currency: "GBP",
+			body:     []byte(`{"amount": 100}`),
+			wantCode: http.StatusInternalServerError,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := NewHandler(tt.controller)
+
+			r := httptest.NewRequest(http.MethodPost, "/"+tt.playerID+"/wallets/"+tt.currency+"/deposit", bytes.NewReader(tt.body))
+			w := httptest.NewRecorder()
+			ctx, router := gin.CreateTestContext(w)
+			ctx.Request = r
+
+			router.Handle(http.MethodPost, "/:player/wallets/:currency/deposit", h.Deposit)
+			router.ServeHTTP(w, r)
+
+			if !cmp.Equal(w.Code, tt.wantCode) {
+				t.Error(w.Code, tt.wantCode)
+			}
+		})
+	}
+}
+
+type mockController struct {
+	GivenID    string
+	GivenList  []wallet.Wallet
+	GivenError error
+}
+
+func (m mockController) Create(_ context.Context, _ wallet.Wallet) (string, error) {
+	return m.GivenID, m.GivenError
+}
+
+func (m mockController) List(_ context.Context, _ string) ([]wallet.Wallet, error) {
+	return m.GivenList, m.GivenError
+}
+
+func (m mockController) Deposit(_ context.Context, _, _ string, _ int64) error {
+	return m.GivenError
+}
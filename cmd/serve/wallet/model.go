@@ -0,0 +1,63 @@
+package wallet
+
+import (
+	"github.com/clarke94/roulette-service/internal/pkg/wallet"
+)
+
+// PlayerParam is the URL parameter binding for the player that holds a Wallet.
+type PlayerParam struct {
+	Player string `uri:"player" binding:"required,uuid"`
+}
+
+// CurrencyParam is the URL parameter binding for the currency of a Wallet.
+type CurrencyParam struct {
+	Currency string `uri:"currency" binding:"required,oneof=GBP EUR USD"`
+}
+
+// Wallet is a presentation API model.
+type Wallet struct {
+	ID        string `json:"id,omitempty"`
+	Currency  string `json:"currency" binding:"required,oneof=GBP EUR USD"`
+	Balance   int64  `json:"balance" binding:"gte=0"`
+	Reserved  int64  `json:"reserved"`
+	Available int64  `json:"available"`
+}
+
+// Deposit is a presentation API model for adding funds to a Wallet.
+type Deposit struct {
+	Amount int64 `json:"amount" binding:"required,gt=0"`
+}
+
+// Upsert is a presentation API model for the Upsert response.
+type Upsert struct {
+	ID string `json:"id"`
+}
+
+func presentationToDomain(t Wallet, playerID string) wallet.Wallet {
+	return wallet.Wallet{
+		ID:       t.ID,
+		PlayerID: playerID,
+		Currency: t.Currency,
+		Balance:  t.Balance,
+	}
+}
+
+func domainToPresentation(t *wallet.Wallet) Wallet {
+	return Wallet{
+		ID:        t.ID,
+		Currency:  t.Currency,
+		Balance:   t.Balance,
+		Reserved:  t.Reserved,
+		Available: t.Available(),
+	}
+}
+
+func domainListToPresentation(t []wallet.Wallet) []Wallet {
+	wallets := make([]Wallet, len(t))
+
+	for i := range t {
+		wallets[i] = domainToPresentation(&t[i])
+	}
+
+	return wallets
+}
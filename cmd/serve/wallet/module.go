@@ -0,0 +1,21 @@
+package wallet
+
+import (
+	"github.com/clarke94/roulette-service/internal/pkg/ledger"
+	domain "github.com/clarke94/roulette-service/internal/pkg/wallet"
+	ledgerStorage "github.com/clarke94/roulette-service/storage/ledger"
+	"github.com/clarke94/roulette-service/storage/transaction"
+	storage "github.com/clarke94/roulette-service/storage/wallet"
+	"github.com/gin-gonic/gin"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+// Module initializes all wallet dependencies.
+func Module(router *gin.Engine, logger *logrus.Logger, db *gorm.DB) {
+	store := storage.New(db)
+	entries := ledger.New(logger, ledgerStorage.New(db))
+	controller := domain.New(logger, store, entries, transaction.New(db))
+	handler := NewHandler(controller)
+	NewRouter(router, handler)
+}
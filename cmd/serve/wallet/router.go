@@ -0,0 +1,16 @@
+package wallet
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// NewRouter initializes all wallet routes.
+func NewRouter(router *gin.Engine, handler Handler) {
+	v1 := router.Group("/v1")
+
+	v1.Handle(http.MethodPost, "/players/:player/wallets", handler.Create)
+	v1.Handle(http.MethodGet, "/players/:player/wallets", handler.List)
+	v1.Handle(http.MethodPost, "/players/:player/wallets/:currency/deposit", handler.Deposit)
+}
@@ -0,0 +1,98 @@
+// Package apperror provides the error taxonomy shared by every domain so the presentation layer can respond to an
+// error without knowing which domain it came from.
+package apperror
+
+import (
+	"errors"
+)
+
+// Kind is the category of an error.
+type Kind string
+
+const (
+	// NotFound is a record that does not exist.
+	NotFound Kind = "not_found"
+	// Validation is a request that is not valid.
+	Validation Kind = "validation"
+	// Conflict is a request that conflicts with the current state of a record.
+	Conflict Kind = "conflict"
+	// LimitExceeded is a request that goes beyond a limit, such as a table limit or the funds of a wallet.
+	LimitExceeded Kind = "limit_exceeded"
+	// Forbidden is a request for a record that belongs to someone else.
+	Forbidden Kind = "forbidden"
+	// PreconditionFailed is a request to change a record that has changed since the version the request was based on.
+	PreconditionFailed Kind = "precondition_failed"
+	// PreconditionRequired is a request to change a record that does not say which version it is based on.
+	PreconditionRequired Kind = "precondition_required"
+	// Internal is a failure of the service, it is the Kind of every error that has not been categorised.
+	Internal Kind = "internal"
+)
+
+// Error is a domain error of a Kind.
+type Error struct {
+	Kind    Kind
+	Message string
+}
+
+// New initializes a new Error, it is intended for declaring the sentinel errors of a domain.
+func New(kind Kind, message string) *Error {
+	return &Error{
+		Kind:    kind,
+		Message: message,
+	}
+}
+
+// Error returns the message of the Error.
+func (e *Error) Error() string {
+	return e.Message
+}
+
+// Field describes why a single field of a request is not valid.
+type Field struct {
+	Field   string
+	Message string
+}
+
+// FieldsError wraps an error with the fields of the request at fault.
+type FieldsError struct {
+	Err    error
+	Fields []Field
+}
+
+// WithFields wraps the error with the fields of the request at fault.
+func WithFields(err error, fields ...Field) error {
+	return FieldsError{
+		Err:    err,
+		Fields: fields,
+	}
+}
+
+// Error returns the message of the wrapped error.
+func (e FieldsError) Error() string {
+	return e.Err.Error()
+}
+
+// Unwrap returns the wrapped error so it can be matched with errors.Is.
+func (e FieldsError) Unwrap() error {
+	return e.Err
+}
+
+// KindOf returns the Kind of the error, any error that is not an Error is Internal.
+func KindOf(err error) Kind {
+	var e *Error
+	if errors.As(err, &e) {
+		return e.Kind
+	}
+
+	return Internal
+}
+
+// FieldsOf returns the fields at fault for the error, if any.
+func FieldsOf(err error) []Field {
+	var e FieldsError
+	if errors.As(err, &e) {
+		return e.Fields
+	}
+
+	return nil
+}
@@ -0,0 +1,89 @@
+package apperror
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+var errFoo = New(NotFound, "foo not found")
+
+func TestKindOf(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want Kind
+	}{
+		{
+			name: "expect kind given Error",
+			err:  errFoo,
+			want: NotFound,
+		},
+		{
+			name: "expect kind given wrapped Error",
+			err:  fmt.Errorf("bar: %w", errFoo),
+			want: NotFound,
+		},
+		{
+			name: "expect kind given Error with fields",
+			err:  WithFields(New(Validation, "foo"), Field{Field: "bar", Message: "baz"}),
+			want: Validation,
+		},
+		{
+			name: "expect internal given any other error",
+			err:  errors.New("foo"),
+			want: Internal,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := KindOf(tt.err)
+
+			if !cmp.Equal(got, tt.want) {
+				t.Error(cmp.Diff(got, tt.want))
+			}
+		})
+	}
+}
+
+func TestFieldsOf(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want []Field
+	}{
+		{
+			name: "expect fields given Error with fields",
+			err:  WithFields(errFoo, Field{Field: "bar", Message: "baz"}),
+			want: []Field{{Field: "bar", Message: "baz"}},
+		},
+		{
+			name: "expect no fields given Error",
+			err:  errFoo,
+			want: nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := FieldsOf(tt.err)
+
+			if !cmp.Equal(got, tt.want) {
+				t.Error(cmp.Diff(got, tt.want))
+			}
+		})
+	}
+}
+
+func TestWithFields(t *testing.T) {
+	err := WithFields(errFoo, Field{Field: "bar", Message: "baz"})
+
+	if !errors.Is(err, errFoo) {
+		t.Error("expect error to wrap", errFoo)
+	}
+
+	if !cmp.Equal(err.Error(), errFoo.Error()) {
+		t.Error(cmp.Diff(err.Error(), errFoo.Error()))
+	}
+}
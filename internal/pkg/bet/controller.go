@@ -2,49 +2,207 @@ package bet
 
 import (
 	"context"
-	"crypto/rand"
 	"errors"
-	"math/big"
-	"strconv"
-
+	"strings"
+
+	"github.com/clarke94/roulette-service/internal/pkg/apperror"
+	"github.com/clarke94/roulette-service/internal/pkg/event"
+	"github.com/clarke94/roulette-service/internal/pkg/fair"
+	"github.com/clarke94/roulette-service/internal/pkg/ledger"
+	"github.com/clarke94/roulette-service/internal/pkg/page"
+	"github.com/clarke94/roulette-service/internal/pkg/round"
+	"github.com/clarke94/roulette-service/internal/pkg/table"
+	"github.com/clarke94/roulette-service/internal/pkg/wallet"
 	"github.com/google/uuid"
 	"github.com/sirupsen/logrus"
 )
 
 var (
-	ErrCreate = errors.New("unable to create bet")
-	ErrList   = errors.New("unable to fetch all bets")
-	ErrUpdate = errors.New("unable to update bet")
-	ErrDelete = errors.New("unable to delete bet")
+	ErrCreate      = apperror.New(apperror.Internal, "unable to create bet")
+	ErrList        = apperror.New(apperror.Internal, "unable to fetch all bets")
+	ErrGet         = apperror.New(apperror.Internal, "unable to fetch bet")
+	ErrUpdate      = apperror.New(apperror.Internal, "unable to update bet")
+	ErrDelete      = apperror.New(apperror.Internal, "unable to delete bet")
+	ErrBet         = apperror.New(apperror.Validation, "bet is not valid for the bet type")
+	ErrType        = apperror.New(apperror.Validation, "bet type is not supported")
+	ErrClosed      = apperror.New(apperror.Conflict, "betting is closed for the current round")
+	ErrInPlay      = apperror.New(apperror.Conflict, "table is already being played")
+	ErrPlay        = apperror.New(apperror.Internal, "unable to play round")
+	ErrFunds       = apperror.New(apperror.LimitExceeded, "insufficient funds to place bet")
+	ErrOwner       = apperror.New(apperror.Forbidden, "bet belongs to another player")
+	ErrTable       = apperror.New(apperror.NotFound, "table not found")
+	ErrNotFound    = apperror.New(apperror.NotFound, "bet not found")
+	ErrCurrency    = apperror.New(apperror.Validation, "bet currency does not match the table currency")
+	ErrLimit       = apperror.New(apperror.LimitExceeded, "bet amount is outside the table limits")
+	ErrPlayerStake = apperror.New(apperror.LimitExceeded, "bet exceeds the maximum stake per player for the round")
+	ErrExposure    = apperror.New(apperror.LimitExceeded, "bet exceeds the maximum exposure for a number")
+	ErrImprisoned  = apperror.New(apperror.Conflict, "bet is held en prison until the next round is played")
+	ErrVersion     = apperror.New(apperror.PreconditionFailed, "bet has changed since it was fetched")
+	ErrOpenBets    = apperror.New(apperror.Conflict, "table has bets in play, delete it with force to refund them")
+	ErrDeleteTable = apperror.New(apperror.Internal, "unable to delete table")
+	ErrSettle      = apperror.New(apperror.Internal, "bet is not on the wheel of the table")
 )
 
+// clientSeedSeparator joins the client seeds of the bets on a round into the client seed of the round.
+const clientSeedSeparator = ","
+
 // StorageProvider provides an interface to the Storage layer.
 type StorageProvider interface {
 	Create(ctx context.Context, model Bet) (string, error)
-	List(ctx context.Context, tableID string, filters ...Bet) ([]Bet, error)
+	List(ctx context.Context, tableID string, filter Filter) ([]Bet, string, error)
+	Get(ctx context.Context, tableID, id string) (Bet, error)
 	Update(ctx context.Context, model Bet) (string, error)
 	Delete(ctx context.Context, tableID, id string) (string, error)
 }
 
+// TableProvider provides an interface to the tables bets are placed on.
+type TableProvider interface {
+	Get(ctx context.Context, id string) (table.Table, error)
+	Delete(ctx context.Context, id string) (string, error)
+}
+
+// RoundProvider provides an interface to the round lifecycle of a table.
+type RoundProvider interface {
+	Open(ctx context.Context, tableID string) (round.Round, error)
+	Current(ctx context.Context, tableID string) (round.Round, error)
+	Find(ctx context.Context, tableID string) (round.Round, error)
+	Close(ctx context.Context, model round.Round) (round.Round, error)
+	Spin(ctx context.Context, model round.Round, number int, color string) (round.Round, error)
+	Settle(ctx context.Context, model round.Round) (round.Round, error)
+}
+
+// WalletProvider provides an interface to the funds of a player.
+type WalletProvider interface {
+	Reserve(ctx context.Context, playerID, currency string, amount int64) error
+	Release(ctx context.Context, playerID, currency string, amount int64) error
+	Settle(ctx context.Context, playerID, currency string, stake, payout int64) error
+}
+
+// LedgerProvider provides an interface to record every money movement of a bet.
+type LedgerProvider interface {
+	Record(ctx context.Context, entries ...ledger.Entry) error
+}
+
+// TransactionProvider provides an interface to run work in a single database transaction, taking locks on a table
+// that are held until the transaction ends.
+type TransactionProvider interface {
+	Transaction(ctx context.Context, fn func(ctx context.Context) error) error
+	Lock(ctx context.Context, key string) error
+	LockShared(ctx context.Context, key string) error
+	TryLock(ctx context.Context, key string) (bool, error)
+}
+
+// RandomProvider provides an interface to the source of random numbers that spins the wheel.
+type RandomProvider interface {
+	Intn(n int) (int, error)
+}
+
+// EventProvider provides an interface to publish what happens at a table as it happens.
+type EventProvider interface {
+	Publish(e event.Event)
+	Remove(tableID string)
+}
+
 // Controller provides a domain controller.
 type Controller struct {
-	Logger  *logrus.Logger
-	Storage StorageProvider
+	Logger      *logrus.Logger
+	Storage     StorageProvider
+	Tables      TableProvider
+	Rounds      RoundProvider
+	Wallets     WalletProvider
+	Ledger      LedgerProvider
+	Transaction TransactionProvider
+	Random      RandomProvider
+	Events      EventProvider
 }
 
 // New initializes a new Controller.
-func New(logger *logrus.Logger, storage StorageProvider) Controller {
+func New(
+	logger *logrus.Logger,
+	storage StorageProvider,
+	tables TableProvider,
+	rounds RoundProvider,
+	wallets WalletProvider,
+	ledger LedgerProvider,
+	transaction TransactionProvider,
+	random RandomProvider,
+	events EventProvider,
+) Controller {
 	return Controller{
-		Logger:  logger,
-		Storage: storage,
+		Logger:      logger,
+		Storage:     storage,
+		Tables:      tables,
+		Rounds:      rounds,
+		Wallets:     wallets,
+		Ledger:      ledger,
+		Transaction: transaction,
+		Random:      random,
+		Events:      events,
 	}
 }
 
-// Create validates the model and invokes the repository.
+// Create validates the model against its table and invokes the repository.
 func (c Controller) Create(ctx context.Context, model Bet) (string, error) {
+	t, err := c.Tables.Get(ctx, model.TableID)
+	if errors.Is(err, table.ErrNotFound) {
+		return "", ErrTable
+	}
+
+	if err != nil {
+		return "", ErrCreate
+	}
+
+	model, err = c.validate(t, model)
+	if err != nil {
+		return "", err
+	}
+
+	if err := checkTable(t, model); err != nil {
+		return "", err
+	}
+
 	model.ID = uuid.New().String()
 
-	id, err := c.Storage.Create(ctx, model)
+	err = c.Transaction.Transaction(ctx, func(ctx context.Context) error {
+		if err := c.lock(ctx, t); err != nil {
+			return err
+		}
+
+		if err := c.exists(ctx, model.TableID); err != nil {
+			return err
+		}
+
+		r, err := c.Rounds.Current(ctx, model.TableID)
+		if err != nil {
+			return err
+		}
+
+		if r.State != round.StateOpen {
+			return ErrClosed
+		}
+
+		model.RoundID = r.ID
+
+		if err := c.checkRound(ctx, t, model); err != nil {
+			return err
+		}
+
+		if err := c.reserve(ctx, model); err != nil {
+			return err
+		}
+
+		_, err = c.Storage.Create(ctx, model)
+
+		return err
+	})
+	if rejected(err) {
+		return "", err
+	}
+
+	if errors.Is(err, wallet.ErrInsufficientFunds) {
+		return "", ErrFunds
+	}
+
 	if err != nil {
 		c.Logger.WithFields(logrus.Fields{
 			"error": err.Error(),
@@ -53,26 +211,160 @@ func (c Controller) Create(ctx context.Context, model Bet) (string, error) {
 		return "", ErrCreate
 	}
 
-	return id, nil
+	c.publish(event.TypeBetPlaced, model.TableID, model)
+
+	return model.ID, nil
+}
+
+// List returns a page of the bets placed on the current round of a table and the cursor of the next page.
+func (c Controller) List(ctx context.Context, tableID string, filter Filter) ([]Bet, string, error) {
+	if err := checkFilter(filter); err != nil {
+		return []Bet{}, "", err
+	}
+
+	r, err := c.Rounds.Current(ctx, tableID)
+	if err != nil {
+		return []Bet{}, "", ErrList
+	}
+
+	filter.RoundID = r.ID
+
+	bets, next, err := c.Storage.List(ctx, tableID, filter)
+	if errors.Is(err, page.ErrCursor) {
+		return []Bet{}, "", page.ErrCursor
+	}
+
+	if err != nil {
+		c.Logger.WithFields(logrus.Fields{
+			"error": err.Error(),
+		}).Error(ErrList.Error())
+
+		return []Bet{}, "", ErrList
+	}
+
+	return bets, next, nil
 }
 
-// List returns all bets from the storage layer.
-func (c Controller) List(ctx context.Context, tableID string) ([]Bet, error) {
-	bets, err := c.Storage.List(ctx, tableID)
+// Get returns a single bet placed on a table.
+func (c Controller) Get(ctx context.Context, tableID, id string) (Bet, error) {
+	model, err := c.Storage.Get(ctx, tableID, id)
+	if errors.Is(err, ErrNotFound) {
+		return Bet{}, ErrNotFound
+	}
+
+	if err != nil {
+		c.Logger.WithFields(logrus.Fields{
+			"error": err.Error(),
+		}).Error(ErrGet.Error())
+
+		return Bet{}, ErrGet
+	}
+
+	return model, nil
+}
+
+// ListPlayer returns a page of the bets placed by a player across all tables and rounds and the cursor of the next
+// page.
+func (c Controller) ListPlayer(ctx context.Context, playerID string, filter Filter) ([]Bet, string, error) {
+	if err := checkFilter(filter); err != nil {
+		return []Bet{}, "", err
+	}
+
+	filter.PlayerID = playerID
+
+	bets, next, err := c.Storage.List(ctx, "", filter)
+	if errors.Is(err, page.ErrCursor) {
+		return []Bet{}, "", page.ErrCursor
+	}
+
 	if err != nil {
 		c.Logger.WithFields(logrus.Fields{
 			"error": err.Error(),
 		}).Error(ErrList.Error())
 
-		return []Bet{}, ErrList
+		return []Bet{}, "", ErrList
 	}
 
-	return bets, nil
+	return bets, next, nil
 }
 
-// Update validates the model and invokes the repository.
+// Update validates the model against its table, adjusts the funds reserved for the bet and invokes the repository,
+// rejecting it when the bet is no longer at the version the model is based on.
 func (c Controller) Update(ctx context.Context, model Bet) (string, error) {
-	id, err := c.Storage.Update(ctx, model)
+	t, err := c.Tables.Get(ctx, model.TableID)
+	if errors.Is(err, table.ErrNotFound) {
+		return "", ErrTable
+	}
+
+	if err != nil {
+		return "", ErrUpdate
+	}
+
+	model, err = c.validate(t, model)
+	if err != nil {
+		return "", err
+	}
+
+	if err := checkTable(t, model); err != nil {
+		return "", err
+	}
+
+	err = c.Transaction.Transaction(ctx, func(ctx context.Context) error {
+		if err := c.lock(ctx, t); err != nil {
+			return err
+		}
+
+		if err := c.exists(ctx, model.TableID); err != nil {
+			return err
+		}
+
+		existing, err := c.Storage.Get(ctx, model.TableID, model.ID)
+		if err != nil {
+			return err
+		}
+
+		if existing.PlayerID != model.PlayerID {
+			return ErrOwner
+		}
+
+		if existing.Version != model.Version {
+			return ErrVersion
+		}
+
+		if existing.Imprisoned {
+			return ErrImprisoned
+		}
+
+		if err := c.open(ctx, existing); err != nil {
+			return err
+		}
+
+		model.RoundID = existing.RoundID
+
+		if err := c.checkRound(ctx, t, model); err != nil {
+			return err
+		}
+
+		if err := c.release(ctx, existing); err != nil {
+			return err
+		}
+
+		if err := c.reserve(ctx, model); err != nil {
+			return err
+		}
+
+		_, err = c.Storage.Update(ctx, model)
+
+		return err
+	})
+	if rejected(err) {
+		return "", err
+	}
+
+	if errors.Is(err, wallet.ErrInsufficientFunds) {
+		return "", ErrFunds
+	}
+
 	if err != nil {
 		c.Logger.WithFields(logrus.Fields{
 			"error": err.Error(),
@@ -81,12 +373,49 @@ func (c Controller) Update(ctx context.Context, model Bet) (string, error) {
 		return "", ErrUpdate
 	}
 
-	return id, nil
+	c.publish(event.TypeBetUpdated, model.TableID, model)
+
+	return model.ID, nil
 }
 
-// Delete deletes one from the repository.
-func (c Controller) Delete(ctx context.Context, tableID, id string) (string, error) {
-	deletedID, err := c.Storage.Delete(ctx, tableID, id)
+// Delete deletes one of the player's bets from the repository and releases the funds reserved for it.
+func (c Controller) Delete(ctx context.Context, tableID, id, playerID string) (string, error) {
+	var cancelled Bet
+
+	err := c.Transaction.Transaction(ctx, func(ctx context.Context) error {
+		if err := c.Transaction.LockShared(ctx, tableID); err != nil {
+			return err
+		}
+
+		existing, err := c.Storage.Get(ctx, tableID, id)
+		if err != nil {
+			return err
+		}
+
+		cancelled = existing
+
+		if existing.PlayerID != playerID {
+			return ErrOwner
+		}
+
+		if existing.Imprisoned {
+			return ErrImprisoned
+		}
+
+		if err := c.open(ctx, existing); err != nil {
+			return err
+		}
+
+		if _, err := c.Storage.Delete(ctx, tableID, id); err != nil {
+			return err
+		}
+
+		return c.release(ctx, existing)
+	})
+	if rejected(err) {
+		return "", err
+	}
+
 	if err != nil {
 		c.Logger.WithFields(logrus.Fields{
 			"error": err.Error(),
@@ -95,74 +424,490 @@ func (c Controller) Delete(ctx context.Context, tableID, id string) (string, err
 		return "", ErrDelete
 	}
 
-	return deletedID, nil
+	c.publish(event.TypeBetCancelled, tableID, cancelled)
+
+	return id, nil
 }
 
-// Play runs the roulette algorithm, clears the table and returns the winners.
+// DeleteTable deletes a table once it has no bets in play, failing with ErrOpenBets while it does.
+// With force every bet in play is cancelled and the funds reserved for it released before the table is deleted.
+// The rounds already played at the table are kept.
+func (c Controller) DeleteTable(ctx context.Context, tableID string, force bool) (string, error) {
+	var cancelled []Bet
+
+	err := c.Transaction.Transaction(ctx, func(ctx context.Context) error {
+		if err := c.Transaction.Lock(ctx, tableID); err != nil {
+			return err
+		}
+
+		if err := c.exists(ctx, tableID); err != nil {
+			return err
+		}
+
+		// A table without a round in play has no bets to cancel, and deleting it must not open one.
+		var bets []Bet
+
+		r, err := c.Rounds.Find(ctx, tableID)
+		if err != nil && !errors.Is(err, round.ErrNotFound) {
+			return err
+		}
+
+		if err == nil {
+			if bets, err = c.all(ctx, tableID, Filter{RoundID: r.ID}); err != nil {
+				return err
+			}
+		}
+
+		if len(bets) > 0 && !force {
+			return ErrOpenBets
+		}
+
+		for i := range bets {
+			if _, err := c.Storage.Delete(ctx, tableID, bets[i].ID); err != nil {
+				return err
+			}
+
+			if err := c.release(ctx, bets[i]); err != nil {
+				return err
+			}
+		}
+
+		cancelled = bets
+
+		_, err = c.Tables.Delete(ctx, tableID)
+
+		return err
+	})
+	if errors.Is(err, ErrTable) || errors.Is(err, ErrOpenBets) {
+		return "", err
+	}
+
+	if err != nil {
+		c.Logger.WithFields(logrus.Fields{
+			"error": err.Error(),
+		}).Error(ErrDeleteTable.Error())
+
+		return "", ErrDeleteTable
+	}
+
+	for i := range cancelled {
+		c.publish(event.TypeBetCancelled, tableID, cancelled[i])
+	}
+
+	c.Events.Remove(tableID)
+
+	return tableID, nil
+}
+
+// Play closes betting on the current round, spins the wheel, settles the round and returns the winners.
+// A new round is opened on the table once the current round is settled.
+// A table is played by one caller at a time, any other caller fails with ErrInPlay rather than playing it again.
+// Betting is closed under the exclusive lock of the table, so a bet being placed, changed or cancelled holds the
+// play back until it is done rather than failing it.
+// On a round opened provably fair the client seeds the players placed their bets with are mixed with the server seed
+// of the round to spin it.
 func (c Controller) Play(ctx context.Context, tableID string) (Result, error) {
-	number := c.getNumber()
-	color := c.getColor(number)
+	t, err := c.Tables.Get(ctx, tableID)
+	if errors.Is(err, table.ErrNotFound) {
+		return Result{}, ErrTable
+	}
 
-	filters := c.winnerFilters(number, color)
+	if err != nil {
+		return Result{}, ErrPlay
+	}
+
+	var (
+		r        round.Round
+		winners  []Winner
+		held     []Bet
+		happened []event.Event
+	)
+
+	err = c.Transaction.Transaction(ctx, func(ctx context.Context) error {
+		locked, err := c.Transaction.TryLock(ctx, playKey(tableID))
+		if err != nil {
+			return err
+		}
+
+		if !locked {
+			return ErrInPlay
+		}
+
+		if err := c.Transaction.Lock(ctx, tableID); err != nil {
+			return err
+		}
+
+		if err := c.exists(ctx, tableID); err != nil {
+			return err
+		}
+
+		if r, err = c.Rounds.Current(ctx, tableID); err != nil {
+			return err
+		}
+
+		if r.State == round.StateOpen {
+			if r, err = c.Rounds.Close(ctx, r); err != nil {
+				return err
+			}
+
+			happened = append(happened, newEvent(event.TypeBettingClosed, tableID, r))
+		}
+
+		bets, err := c.all(ctx, tableID, Filter{RoundID: r.ID})
+		if err != nil {
+			return err
+		}
+
+		if r.State == round.StateClosed {
+			var number int
+
+			r = seed(t, r, bets)
+
+			if number, err = c.getNumber(r); err != nil {
+				return err
+			}
+
+			if r, err = c.Rounds.Spin(ctx, r, number, Color(number)); err != nil {
+				return err
+			}
+
+			happened = append(happened, newEvent(event.TypeRoundSpun, tableID, r))
+		}
+
+		var settled []Bet
+
+		settled, held = imprison(t, bets, r.Number)
+
+		if winners, err = c.winners(t, settled, r.Number); err != nil {
+			return err
+		}
+
+		if err := c.settle(ctx, settled, winners); err != nil {
+			return err
+		}
+
+		r.Winners = winnerListToRound(winners)
+		r.TotalStaked, r.TotalPaid = totals(settled, winners)
+
+		if _, err := c.Rounds.Settle(ctx, r); err != nil {
+			return err
+		}
+
+		next, err := c.Rounds.Open(ctx, tableID)
+		if err != nil {
+			return err
+		}
+
+		return c.hold(ctx, held, next)
+	})
+	if errors.Is(err, ErrInPlay) {
+		return Result{}, ErrInPlay
+	}
+
+	if errors.Is(err, ErrTable) {
+		return Result{}, ErrTable
+	}
 
-	bets, err := c.Storage.List(ctx, tableID, filters...)
 	if err != nil {
 		c.Logger.WithFields(logrus.Fields{
 			"error": err.Error(),
-		}).Error(ErrList.Error())
+		}).Error(ErrPlay.Error())
 
-		return Result{}, ErrList
+		return Result{}, ErrPlay
 	}
 
 	result := Result{
-		Number:  number,
-		Color:   color,
-		Winners: betListToWinner(bets),
+		RoundID:        r.ID,
+		Number:         r.Number,
+		Pocket:         round.Pocket(r.Number),
+		Color:          r.Color,
+		Winners:        winners,
+		Imprisoned:     betIDs(held),
+		ProvablyFair:   r.ProvablyFair,
+		ServerSeed:     r.ServerSeed,
+		ServerSeedHash: r.ServerSeedHash,
+		ClientSeed:     r.ClientSeed,
+		Nonce:          r.Nonce,
+	}
+
+	for _, e := range happened {
+		c.Events.Publish(e)
 	}
 
+	c.publish(event.TypeRoundSettled, tableID, result)
+
 	return result, nil
 }
 
-func (c Controller) winnerFilters(number int, color string) []Bet {
-	filters := make([]Bet, 0)
+// Close stops the round accepting any more bets and publishes that betting is closed.
+// The round must still be the current round of its table, otherwise it has already been played.
+func (c Controller) Close(ctx context.Context, model round.Round) (round.Round, error) {
+	var r round.Round
 
-	filters = append(filters, Bet{
-		Bet:  strconv.Itoa(number),
-		Type: TypeStraight,
-	})
+	err := c.Transaction.Transaction(ctx, func(ctx context.Context) error {
+		if err := c.Transaction.Lock(ctx, model.TableID); err != nil {
+			return err
+		}
+
+		current, err := c.Rounds.Current(ctx, model.TableID)
+		if err != nil {
+			return err
+		}
+
+		if current.ID != model.ID {
+			return round.ErrState
+		}
 
-	filters = append(filters, Bet{
-		Bet:  color,
-		Type: TypeRedBlack,
+		r, err = c.Rounds.Close(ctx, current)
+
+		return err
 	})
+	if err != nil {
+		return round.Round{}, err
+	}
+
+	c.publish(event.TypeBettingClosed, r.TableID, r)
 
-	return filters
+	return r, nil
 }
 
-func (c Controller) getNumber() int {
-	bg := big.NewInt(36)
+// open checks that the round the Bet is placed on is still accepting bets.
+func (c Controller) open(ctx context.Context, model Bet) error {
+	r, err := c.Rounds.Current(ctx, model.TableID)
+	if err != nil {
+		return err
+	}
 
-	number, _ := rand.Int(rand.Reader, bg)
+	if r.ID != model.RoundID || r.State != round.StateOpen {
+		return ErrClosed
+	}
 
-	return int(number.Int64())
+	return nil
 }
 
-func (c Controller) getColor(number int) string {
-	if number == 0 {
-		return colorGreen
+// playKey returns the key of the lock held by the caller playing the table, so callers racing to play it, such as
+// the scheduler and a manual play, are turned away at once instead of queueing behind the lock of the table.
+func playKey(tableID string) string {
+	return "play:" + tableID
+}
+
+// exists checks that the table has not been deleted, so once a lock on the table is held nothing more is played on a
+// deleted table.
+func (c Controller) exists(ctx context.Context, tableID string) error {
+	_, err := c.Tables.Get(ctx, tableID)
+	if errors.Is(err, table.ErrNotFound) {
+		return ErrTable
 	}
 
-	if number >= 1 && number <= 10 || number >= 19 && number <= 28 {
-		if number%2 == 0 {
-			return colorBlack
+	return err
+}
+
+// hold moves the imprisoned bets onto the next round, keeping the funds reserved for them until they are settled.
+func (c Controller) hold(ctx context.Context, bets []Bet, next round.Round) error {
+	for i := range bets {
+		bets[i].RoundID = next.ID
+		bets[i].Imprisoned = true
+
+		if _, err := c.Storage.Update(ctx, bets[i]); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// reserve holds the funds for a bet against the wallet of its player and records it in the ledger.
+func (c Controller) reserve(ctx context.Context, model Bet) error {
+	if err := c.Wallets.Reserve(ctx, model.PlayerID, model.Currency, model.Amount); err != nil {
+		return err
+	}
+
+	return c.Ledger.Record(ctx, reserved(model))
+}
+
+// release returns the funds reserved for a bet to its player and records the refund in the ledger.
+func (c Controller) release(ctx context.Context, model Bet) error {
+	// Bets placed before wallets were introduced have no player and nothing reserved.
+	if model.PlayerID == "" {
+		return nil
+	}
+
+	if err := c.Wallets.Release(ctx, model.PlayerID, model.Currency, model.Amount); err != nil {
+		return err
+	}
+
+	return c.Ledger.Record(ctx, refunded(model))
+}
+
+// settle takes the stake of every bet from the wallet of its player and credits the return of the winners, recording
+// the settlement of each bet in the ledger.
+func (c Controller) settle(ctx context.Context, bets []Bet, winners []Winner) error {
+	payouts := make(map[string]int64, len(winners))
+	for i := range winners {
+		payouts[winners[i].BetID] = winners[i].Return
+	}
+
+	for i := range bets {
+		if bets[i].PlayerID == "" {
+			continue
+		}
+
+		err := c.Wallets.Settle(ctx, bets[i].PlayerID, bets[i].Currency, bets[i].Amount, payouts[bets[i].ID])
+		if err != nil {
+			return err
+		}
+
+		if err := c.Ledger.Record(ctx, settlement(bets[i], payouts[bets[i].ID])...); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// lock takes the lock on the table for placing or changing a bet. A bet on a table with round limits is checked
+// against the other bets placed on the round, so it is placed under an exclusive lock for the check to hold until it
+// is stored. Otherwise bets are placed concurrently under a shared lock.
+func (c Controller) lock(ctx context.Context, t table.Table) error {
+	if t.MaximumPlayerStake == 0 && t.MaximumExposure == 0 {
+		return c.Transaction.LockShared(ctx, t.ID)
+	}
+
+	return c.Transaction.Lock(ctx, t.ID)
+}
+
+// checkRound checks the Bet against the round limits of the table given the other bets placed on the round.
+func (c Controller) checkRound(ctx context.Context, t table.Table, model Bet) error {
+	if t.MaximumPlayerStake == 0 && t.MaximumExposure == 0 {
+		return nil
+	}
+
+	bets, err := c.all(ctx, model.TableID, Filter{RoundID: model.RoundID})
+	if err != nil {
+		return err
+	}
+
+	return checkRound(t, bets, model)
+}
+
+// all returns every bet matching the filter, reading them from the storage layer a page at a time.
+func (c Controller) all(ctx context.Context, tableID string, filter Filter) ([]Bet, error) {
+	bets := make([]Bet, 0)
+	filter.Limit = page.MaxLimit
+
+	for {
+		p, next, err := c.Storage.List(ctx, tableID, filter)
+		if err != nil {
+			return []Bet{}, err
+		}
+
+		bets = append(bets, p...)
+
+		if next == "" {
+			return bets, nil
+		}
+
+		filter.Cursor = next
+	}
+}
+
+// validate rejects a Bet of a type the wheel of the table does not support or with a selection the type does not
+// cover, returning the Bet with its selection in canonical form.
+func (c Controller) validate(t table.Table, model Bet) (Bet, error) {
+	if _, ok := catalogues[t.Wheel][model.Type]; !ok {
+		return Bet{}, invalid(ErrType, "type", typeMessage(t.Wheel))
+	}
+
+	s, ok := findSelection(t.Wheel, model.Type, model.Bet)
+	if !ok {
+		return Bet{}, invalid(ErrBet, "bet", selectionMessage(t.Wheel, model.Type))
+	}
+
+	model.Bet = s.Bet
+
+	return model, nil
+}
+
+// winners returns the bets that return any of their stake given the number spun under the rules of the table, failing
+// when any bet cannot be settled on the wheel of the table.
+func (c Controller) winners(t table.Table, bets []Bet, number int) ([]Winner, error) {
+	winners := make([]Winner, 0)
+
+	for i := range bets {
+		w, ok, err := settleBet(t, bets[i], number)
+		if err != nil {
+			return nil, err
+		}
+
+		if ok {
+			winners = append(winners, w)
 		}
+	}
+
+	return winners, nil
+}
+
+// seed records the wheel the round is spun on and, on a round opened provably fair, the seeds the spin is derived from.
+// The client seed joins the client seeds of the bets on the round in the order they were placed, so every player that
+// gave one has a hand in the spin, and is the round ID when no player gave one. The nonce is always 0 as every round
+// commits to a server seed of its own, so a pair of seeds is never spun twice.
+// Rounds opened without a server seed are spun from the random source.
+func seed(t table.Table, r round.Round, bets []Bet) round.Round {
+	r.Wheel = t.Wheel
+
+	if !r.ProvablyFair || r.ServerSeed == "" {
+		return r
+	}
 
-		return colorRed
+	seeds := make([]string, 0, len(bets))
+	for i := range bets {
+		if bets[i].ClientSeed != "" {
+			seeds = append(seeds, bets[i].ClientSeed)
+		}
 	}
 
-	if number%2 == 0 {
-		return colorRed
+	r.ClientSeed = strings.Join(seeds, clientSeedSeparator)
+	r.Nonce = 0
+
+	if r.ClientSeed == "" {
+		r.ClientSeed = r.ID
 	}
 
-	return colorBlack
+	return r
+}
+
+// publish sends an event of what happened at the table to its subscribers.
+func (c Controller) publish(eventType, tableID string, data interface{}) {
+	c.Events.Publish(newEvent(eventType, tableID, data))
+}
+
+// newEvent returns an event of what happened at the table.
+func newEvent(eventType, tableID string, data interface{}) event.Event {
+	return event.Event{
+		Type:    eventType,
+		TableID: tableID,
+		Data:    data,
+	}
+}
+
+// getNumber returns a pocket of the wheel the round is spun on, where the last pocket of an American wheel is 00.
+// A provably fair round derives the pocket from its seeds, any other round draws it from the random source.
+func (c Controller) getNumber(r round.Round) (int, error) {
+	if r.ProvablyFair {
+		return fair.Outcome(r.ServerSeed, r.ClientSeed, r.Nonce, table.Pockets(r.Wheel)), nil
+	}
+
+	return c.Random.Intn(table.Pockets(r.Wheel))
+}
+
+// rejected reports whether the error rejects the Bet itself rather than being a failure to store it.
+func rejected(err error) bool {
+	return errors.Is(err, ErrNotFound) ||
+		errors.Is(err, ErrTable) ||
+		errors.Is(err, ErrClosed) ||
+		errors.Is(err, ErrOwner) ||
+		errors.Is(err, ErrImprisoned) ||
+		errors.Is(err, ErrVersion) ||
+		errors.Is(err, ErrPlayerStake) ||
+		errors.Is(err, ErrExposure)
 }
@@ -3,8 +3,17 @@ package bet
 import (
 	"context"
 	"errors"
+	"strconv"
 	"testing"
 
+	"github.com/clarke94/roulette-service/internal/pkg/event"
+	"github.com/clarke94/roulette-service/internal/pkg/fair"
+	"github.com/clarke94/roulette-service/internal/pkg/ledger"
+	"github.com/clarke94/roulette-service/internal/pkg/page"
+	"github.com/clarke94/roulette-service/internal/pkg/rng"
+	"github.com/clarke94/roulette-service/internal/pkg/round"
+	"github.com/clarke94/roulette-service/internal/pkg/table"
+	"github.com/clarke94/roulette-service/internal/pkg/wallet"
 	"github.com/google/go-cmp/cmp"
 	"github.com/google/go-cmp/cmp/cmpopts"
 	"github.com/google/uuid"
@@ -19,13 +28,20 @@ func TestNew(t *testing.T) {
 		{
 			name: "expect Controller to init",
 			want: Controller{
-				Storage: mockStorage{},
+				Storage:     mockStorage{},
+				Tables:      mockTables{},
+				Rounds:      mockRounds{},
+				Wallets:     mockWallets{},
+				Ledger:      mockLedger{},
+				Transaction: mockTransaction{},
+				Random:      mockRandom{},
+				Events:      &mockEvents{},
 			},
 		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			got := New(logrus.New(), mockStorage{})
+			got := New(logrus.New(), mockStorage{}, mockTables{}, mockRounds{}, mockWallets{}, mockLedger{}, mockTransaction{}, mockRandom{}, &mockEvents{})
 			if !cmp.Equal(got, tt.want, cmpopts.IgnoreUnexported(Controller{}), cmpopts.IgnoreFields(Controller{}, "Logger")) {
 				t.Error(cmp.Diff(got, tt.want, cmpopts.IgnoreUnexported(Controller{}), cmpopts.IgnoreFields(Controller{}, "Logger")))
 			}
@@ -38,12 +54,18 @@ func TestController_Create(t *testing.T) {
 		name    string
 		Logger  *logrus.Logger
 		Storage StorageProvider
+		Tables  TableProvider
+		Rounds  RoundProvider
+		Wallets WalletProvider
 		model   Bet
 		wantErr error
 	}{
 		{
 			name:    "expect success given valid bet",
 			Logger:  logrus.New(),
+			Tables:  mockTables{GivenTable: gbpTable},
+			Rounds:  mockRounds{GivenRound: openRound},
+			Wallets: mockWallets{},
 			Storage: mockStorage{},
 			model: Bet{
 				ID:       uuid.New().String(),
@@ -56,8 +78,11 @@ func TestController_Create(t *testing.T) {
 			wantErr: nil,
 		},
 		{
-			name:   "expect fail given storage error",
-			Logger: logrus.New(),
+			name:    "expect fail given storage error",
+			Logger:  logrus.New(),
+			Tables:  mockTables{GivenTable: gbpTable},
+			Rounds:  mockRounds{GivenRound: openRound},
+			Wallets: mockWallets{},
 			Storage: mockStorage{
 				GivenError: errors.New("foo"),
 			},
@@ -71,15 +96,311 @@ func TestController_Create(t *testing.T) {
 			},
 			wantErr: ErrCreate,
 		},
+		{
+			name:    "expect success given split bet in any order",
+			Logger:  logrus.New(),
+			Tables:  mockTables{GivenTable: gbpTable},
+			Rounds:  mockRounds{GivenRound: openRound},
+			Wallets: mockWallets{},
+			Storage: mockStorage{},
+			model: Bet{
+				TableID:  uuid.New().String(),
+				Bet:      "20-17",
+				Type:     TypeSplit,
+				Amount:   100,
+				Currency: "GBP",
+			},
+			wantErr: nil,
+		},
+		{
+			name:    "expect fail given split of numbers that are not adjacent",
+			Logger:  logrus.New(),
+			Tables:  mockTables{GivenTable: gbpTable},
+			Rounds:  mockRounds{GivenRound: openRound},
+			Wallets: mockWallets{},
+			Storage: mockStorage{},
+			model: Bet{
+				TableID:  uuid.New().String(),
+				Bet:      "17-21",
+				Type:     TypeSplit,
+				Amount:   100,
+				Currency: "GBP",
+			},
+			wantErr: ErrBet,
+		},
+		{
+			name:    "expect fail given unknown bet type",
+			Logger:  logrus.New(),
+			Tables:  mockTables{GivenTable: gbpTable},
+			Rounds:  mockRounds{GivenRound: openRound},
+			Wallets: mockWallets{},
+			Storage: mockStorage{},
+			model: Bet{
+				TableID:  uuid.New().String(),
+				Bet:      "17",
+				Type:     "foo",
+				Amount:   100,
+				Currency: "GBP",
+			},
+			wantErr: ErrType,
+		},
+		{
+			name:    "expect fail given straight that is not on the wheel",
+			Logger:  logrus.New(),
+			Tables:  mockTables{GivenTable: gbpTable},
+			Rounds:  mockRounds{GivenRound: openRound},
+			Wallets: mockWallets{},
+			Storage: mockStorage{},
+			model: Bet{
+				TableID:  uuid.New().String(),
+				Bet:      "37",
+				Type:     TypeStraight,
+				Amount:   100,
+				Currency: "GBP",
+			},
+			wantErr: ErrBet,
+		},
+		{
+			name:    "expect fail given color that does not exist",
+			Logger:  logrus.New(),
+			Tables:  mockTables{GivenTable: gbpTable},
+			Rounds:  mockRounds{GivenRound: openRound},
+			Wallets: mockWallets{},
+			Storage: mockStorage{},
+			model: Bet{
+				TableID:  uuid.New().String(),
+				Bet:      "purple",
+				Type:     TypeRedBlack,
+				Amount:   100,
+				Currency: "GBP",
+			},
+			wantErr: ErrBet,
+		},
+		{
+			name:    "expect fail given dozen that does not exist",
+			Logger:  logrus.New(),
+			Tables:  mockTables{GivenTable: gbpTable},
+			Rounds:  mockRounds{GivenRound: openRound},
+			Wallets: mockWallets{},
+			Storage: mockStorage{},
+			model: Bet{
+				TableID:  uuid.New().String(),
+				Bet:      "4th",
+				Type:     TypeDozen,
+				Amount:   100,
+				Currency: "GBP",
+			},
+			wantErr: ErrBet,
+		},
+		{
+			name:    "expect fail given corner that does not exist on the layout",
+			Logger:  logrus.New(),
+			Tables:  mockTables{GivenTable: gbpTable},
+			Rounds:  mockRounds{GivenRound: openRound},
+			Wallets: mockWallets{},
+			Storage: mockStorage{},
+			model: Bet{
+				TableID:  uuid.New().String(),
+				Bet:      "3-4-6-7",
+				Type:     TypeCorner,
+				Amount:   100,
+				Currency: "GBP",
+			},
+			wantErr: ErrBet,
+		},
+		{
+			name:    "expect fail given betting closed for the round",
+			Logger:  logrus.New(),
+			Tables:  mockTables{GivenTable: gbpTable},
+			Rounds:  mockRounds{GivenRound: round.Round{ID: openRound.ID, State: round.StateClosed}},
+			Wallets: mockWallets{},
+			Storage: mockStorage{},
+			model: Bet{
+				TableID:  uuid.New().String(),
+				Bet:      "10",
+				Type:     TypeStraight,
+				Amount:   100,
+				Currency: "GBP",
+			},
+			wantErr: ErrClosed,
+		},
+		{
+			name:    "expect fail given insufficient funds",
+			Logger:  logrus.New(),
+			Tables:  mockTables{GivenTable: gbpTable},
+			Rounds:  mockRounds{GivenRound: openRound},
+			Wallets: mockWallets{GivenError: wallet.ErrInsufficientFunds},
+			Storage: mockStorage{},
+			model: Bet{
+				TableID:  uuid.New().String(),
+				PlayerID: uuid.New().String(),
+				Bet:      "10",
+				Type:     TypeStraight,
+				Amount:   100,
+				Currency: "GBP",
+			},
+			wantErr: ErrFunds,
+		},
+		{
+			name:    "expect fail given wallet error",
+			Logger:  logrus.New(),
+			Tables:  mockTables{GivenTable: gbpTable},
+			Rounds:  mockRounds{GivenRound: openRound},
+			Wallets: mockWallets{GivenError: errors.New("foo")},
+			Storage: mockStorage{},
+			model: Bet{
+				TableID:  uuid.New().String(),
+				PlayerID: uuid.New().String(),
+				Bet:      "10",
+				Type:     TypeStraight,
+				Amount:   100,
+				Currency: "GBP",
+			},
+			wantErr: ErrCreate,
+		},
+		{
+			name:    "expect fail given table that does not exist",
+			Logger:  logrus.New(),
+			Tables:  mockTables{GivenError: table.ErrNotFound},
+			Rounds:  mockRounds{GivenRound: openRound},
+			Wallets: mockWallets{},
+			Storage: mockStorage{},
+			model: Bet{
+				TableID:  uuid.New().String(),
+				PlayerID: uuid.New().String(),
+				Bet:      "10",
+				Type:     TypeStraight,
+				Amount:   100,
+				Currency: "GBP",
+			},
+			wantErr: ErrTable,
+		},
+		{
+			name:    "expect fail given currency that does not match the table",
+			Logger:  logrus.New(),
+			Tables:  mockTables{GivenTable: gbpTable},
+			Rounds:  mockRounds{GivenRound: openRound},
+			Wallets: mockWallets{},
+			Storage: mockStorage{},
+			model: Bet{
+				TableID:  uuid.New().String(),
+				PlayerID: uuid.New().String(),
+				Bet:      "10",
+				Type:     TypeStraight,
+				Amount:   100,
+				Currency: "USD",
+			},
+			wantErr: ErrCurrency,
+		},
+		{
+			name:    "expect fail given amount below the table minimum",
+			Logger:  logrus.New(),
+			Tables:  mockTables{GivenTable: gbpTable},
+			Rounds:  mockRounds{GivenRound: openRound},
+			Wallets: mockWallets{},
+			Storage: mockStorage{},
+			model: Bet{
+				TableID:  uuid.New().String(),
+				PlayerID: uuid.New().String(),
+				Bet:      "10",
+				Type:     TypeStraight,
+				Amount:   5,
+				Currency: "GBP",
+			},
+			wantErr: ErrLimit,
+		},
+		{
+			name:    "expect fail given amount above the table maximum",
+			Logger:  logrus.New(),
+			Tables:  mockTables{GivenTable: gbpTable},
+			Rounds:  mockRounds{GivenRound: openRound},
+			Wallets: mockWallets{},
+			Storage: mockStorage{},
+			model: Bet{
+				TableID:  uuid.New().String(),
+				PlayerID: uuid.New().String(),
+				Bet:      "10",
+				Type:     TypeStraight,
+				Amount:   5000,
+				Currency: "GBP",
+			},
+			wantErr: ErrLimit,
+		},
+		{
+			name:    "expect fail given player stake above the round limit",
+			Logger:  logrus.New(),
+			Tables:  mockTables{GivenTable: limitedTable},
+			Rounds:  mockRounds{GivenRound: openRound},
+			Wallets: mockWallets{},
+			Storage: mockStorage{
+				GivenList: []Bet{
+					{
+						ID:       uuid.New().String(),
+						PlayerID: "9b2f3c1e-5a4d-4e7f-8c6b-1d2e3f4a5b6c",
+						Bet:      "red",
+						Type:     TypeRedBlack,
+						Amount:   450,
+						Currency: "GBP",
+					},
+				},
+			},
+			model: Bet{
+				TableID:  uuid.New().String(),
+				PlayerID: "9b2f3c1e-5a4d-4e7f-8c6b-1d2e3f4a5b6c",
+				Bet:      "black",
+				Type:     TypeRedBlack,
+				Amount:   100,
+				Currency: "GBP",
+			},
+			wantErr: ErrPlayerStake,
+		},
+		{
+			name:    "expect fail given exposure on a number above the round limit",
+			Logger:  logrus.New(),
+			Tables:  mockTables{GivenTable: limitedTable},
+			Rounds:  mockRounds{GivenRound: openRound},
+			Wallets: mockWallets{},
+			Storage: mockStorage{
+				GivenList: []Bet{
+					{
+						ID:       uuid.New().String(),
+						PlayerID: uuid.New().String(),
+						Bet:      "17",
+						Type:     TypeStraight,
+						Amount:   200,
+						Currency: "GBP",
+					},
+				},
+			},
+			model: Bet{
+				TableID:  uuid.New().String(),
+				PlayerID: uuid.New().String(),
+				Bet:      "17-20",
+				Type:     TypeSplit,
+				Amount:   200,
+				Currency: "GBP",
+			},
+			wantErr: ErrExposure,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			c := New(tt.Logger, tt.Storage)
+			events := &mockEvents{}
+			c := New(tt.Logger, tt.Storage, tt.Tables, tt.Rounds, tt.Wallets, mockLedger{}, mockTransaction{}, mockRandom{}, events)
 			_, err := c.Create(context.Background(), tt.model)
 
 			if !cmp.Equal(err, tt.wantErr, cmpopts.EquateErrors()) {
 				t.Error(cmp.Diff(err, tt.wantErr, cmpopts.EquateErrors()))
 			}
+
+			var wantEvents []string
+			if tt.wantErr == nil {
+				wantEvents = []string{event.TypeBetPlaced}
+			}
+
+			if !cmp.Equal(events.Published, wantEvents) {
+				t.Error(cmp.Diff(events.Published, wantEvents))
+			}
 		})
 	}
 }
@@ -89,12 +410,16 @@ func TestController_List(t *testing.T) {
 		name     string
 		Logger   *logrus.Logger
 		Storage  StorageProvider
+		Rounds   RoundProvider
+		filter   Filter
 		wantBets []Bet
+		wantNext string
 		wantErr  error
 	}{
 		{
 			name:   "expect success given no Bets found",
 			Logger: logrus.New(),
+			Rounds: mockRounds{GivenRound: openRound},
 			Storage: mockStorage{
 				GivenList: []Bet{},
 			},
@@ -104,6 +429,7 @@ func TestController_List(t *testing.T) {
 		{
 			name:   "expect success given Bets found",
 			Logger: logrus.New(),
+			Rounds: mockRounds{GivenRound: openRound},
 
 			Storage: mockStorage{
 				GivenList: []Bet{
@@ -116,6 +442,7 @@ func TestController_List(t *testing.T) {
 						Currency: "GBP",
 					},
 				},
+				GivenNext: "foo",
 			},
 			wantBets: []Bet{
 				{
@@ -127,11 +454,32 @@ func TestController_List(t *testing.T) {
 					Currency: "GBP",
 				},
 			},
-			wantErr: nil,
+			wantNext: "foo",
+			wantErr:  nil,
+		},
+		{
+			name:   "expect fail given invalid cursor",
+			Logger: logrus.New(),
+			Rounds: mockRounds{GivenRound: openRound},
+			Storage: mockStorage{
+				GivenError: page.ErrCursor,
+			},
+			wantBets: []Bet{},
+			wantErr:  page.ErrCursor,
+		},
+		{
+			name:     "expect ErrType given unknown bet type",
+			Logger:   logrus.New(),
+			Rounds:   mockRounds{GivenRound: openRound},
+			Storage:  mockStorage{},
+			filter:   Filter{Type: "foo"},
+			wantBets: []Bet{},
+			wantErr:  ErrType,
 		},
 		{
 			name:   "expect fail given storage error",
 			Logger: logrus.New(),
+			Rounds: mockRounds{GivenRound: openRound},
 			Storage: mockStorage{
 				GivenError: errors.New("foo"),
 				GivenList:  []Bet{},
@@ -142,8 +490,8 @@ func TestController_List(t *testing.T) {
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			c := New(tt.Logger, tt.Storage)
-			bets, err := c.List(context.Background(), uuid.New().String())
+			c := New(tt.Logger, tt.Storage, mockTables{}, tt.Rounds, mockWallets{}, mockLedger{}, mockTransaction{}, mockRandom{}, &mockEvents{})
+			bets, next, err := c.List(context.Background(), uuid.New().String(), tt.filter)
 
 			if !cmp.Equal(err, tt.wantErr, cmpopts.EquateErrors()) {
 				t.Error(cmp.Diff(err, tt.wantErr, cmpopts.EquateErrors()))
@@ -152,129 +500,810 @@ func TestController_List(t *testing.T) {
 			if !cmp.Equal(bets, tt.wantBets) {
 				t.Error(cmp.Diff(bets, tt.wantBets))
 			}
+
+			if !cmp.Equal(next, tt.wantNext) {
+				t.Error(cmp.Diff(next, tt.wantNext))
+			}
 		})
 	}
 }
 
-func TestController_Update(t *testing.T) {
+func TestController_all(t *testing.T) {
 	tests := []struct {
-		name    string
-		Logger  *logrus.Logger
-		Storage StorageProvider
-		model   Bet
-		wantErr error
+		name     string
+		Storage  StorageProvider
+		wantBets []Bet
+		wantErr  error
 	}{
 		{
-			name:    "expect success given valid bet",
-			Logger:  logrus.New(),
-			Storage: mockStorage{},
-			model: Bet{
-				ID:       "8117bb87-148c-4fb1-8971-a2d4373b3f19",
-				TableID:  "8117bb87-148c-4fb1-8971-a2d4373b3f19",
-				Bet:      "10",
-				Type:     TypeStraight,
-				Amount:   100,
-				Currency: "GBP",
-			},
-			wantErr: nil,
+			name:     "expect every bet given many pages",
+			Storage:  mockPages{GivenPages: [][]Bet{{{ID: "foo"}, {ID: "bar"}}, {{ID: "baz"}}}},
+			wantBets: []Bet{{ID: "foo"}, {ID: "bar"}, {ID: "baz"}},
+			wantErr:  nil,
 		},
 		{
-			name:   "expect fail given storage error",
-			Logger: logrus.New(),
-			Storage: mockStorage{
-				GivenError: errors.New("foo"),
-			},
-			model: Bet{
-				ID:       "8117bb87-148c-4fb1-8971-a2d4373b3f19",
-				TableID:  "8117bb87-148c-4fb1-8971-a2d4373b3f19",
-				Bet:      "10",
-				Type:     TypeStraight,
-				Amount:   100,
-				Currency: "GBP",
+			name:     "expect no bets given an empty page",
+			Storage:  mockPages{GivenPages: [][]Bet{{}}},
+			wantBets: []Bet{},
+			wantErr:  nil,
+		},
+		{
+			name: "expect fail given storage error",
+			Storage: mockPages{
+				mockStorage: mockStorage{GivenError: errors.New("foo")},
+				GivenPages:  [][]Bet{{{ID: "foo"}}, {{ID: "bar"}}},
 			},
-			wantErr: ErrUpdate,
+			wantBets: []Bet{},
+			wantErr:  errors.New("foo"),
 		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			c := New(tt.Logger, tt.Storage)
-			_, err := c.Update(context.Background(), tt.model)
+			c := New(logrus.New(), tt.Storage, mockTables{}, mockRounds{}, mockWallets{}, mockLedger{}, mockTransaction{}, mockRandom{}, &mockEvents{})
+			bets, err := c.all(context.Background(), uuid.New().String(), Filter{})
 
-			if !cmp.Equal(err, tt.wantErr, cmpopts.EquateErrors()) {
+			if !cmp.Equal(err != nil, tt.wantErr != nil) {
 				t.Error(cmp.Diff(err, tt.wantErr, cmpopts.EquateErrors()))
 			}
+
+			if !cmp.Equal(bets, tt.wantBets) {
+				t.Error(cmp.Diff(bets, tt.wantBets))
+			}
 		})
 	}
 }
 
-func TestController_Delete(t *testing.T) {
+func TestController_Get(t *testing.T) {
 	tests := []struct {
 		name    string
-		Logger  *logrus.Logger
 		Storage StorageProvider
-		id      string
+		want    Bet
 		wantErr error
 	}{
 		{
-			name:    "expect success given valid bet",
-			Logger:  logrus.New(),
-			Storage: mockStorage{},
-			id:      uuid.New().String(),
+			name:    "expect bet given bet found",
+			Storage: mockStorage{GivenBet: Bet{ID: "foo", Bet: "17", Type: TypeStraight, Amount: 100}},
+			want:    Bet{ID: "foo", Bet: "17", Type: TypeStraight, Amount: 100},
 			wantErr: nil,
 		},
 		{
-			name:   "expect fail given storage error",
-			Logger: logrus.New(),
-			Storage: mockStorage{
-				GivenError: errors.New("foo"),
-			},
-			id:      uuid.New().String(),
-			wantErr: ErrDelete,
+			name:    "expect fail given bet not found",
+			Storage: mockStorage{GivenError: ErrNotFound},
+			want:    Bet{},
+			wantErr: ErrNotFound,
+		},
+		{
+			name:    "expect fail given storage error",
+			Storage: mockStorage{GivenError: errors.New("foo")},
+			want:    Bet{},
+			wantErr: ErrGet,
 		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			c := New(tt.Logger, tt.Storage)
-			_, err := c.Delete(context.Background(), uuid.New().String(), tt.id)
+			c := New(logrus.New(), tt.Storage, mockTables{}, mockRounds{}, mockWallets{}, mockLedger{}, mockTransaction{}, mockRandom{}, &mockEvents{})
+			got, err := c.Get(context.Background(), uuid.New().String(), "foo")
 
 			if !cmp.Equal(err, tt.wantErr, cmpopts.EquateErrors()) {
 				t.Error(cmp.Diff(err, tt.wantErr, cmpopts.EquateErrors()))
 			}
+
+			if !cmp.Equal(got, tt.want) {
+				t.Error(cmp.Diff(got, tt.want))
+			}
 		})
 	}
 }
 
-func TestController_Play(t *testing.T) {
+func TestController_ListPlayer(t *testing.T) {
+	tests := []struct {
+		name     string
+		Storage  StorageProvider
+		filter   Filter
+		wantBets []Bet
+		wantNext string
+		wantErr  error
+	}{
+		{
+			name: "expect bets given player with bets",
+			Storage: mockStorage{
+				GivenList: []Bet{
+					{
+						ID:       "8117bb87-148c-4fb1-8971-a2d4373b3f19",
+						TableID:  "8117bb87-148c-4fb1-8971-a2d4373b3f19",
+						PlayerID: "9b2f3c1e-5a4d-4e7f-8c6b-1d2e3f4a5b6c",
+						Bet:      "10",
+						Type:     TypeStraight,
+						Amount:   100,
+						Currency: "GBP",
+					},
+				},
+				GivenNext: "foo",
+			},
+			wantBets: []Bet{
+				{
+					ID:       "8117bb87-148c-4fb1-8971-a2d4373b3f19",
+					TableID:  "8117bb87-148c-4fb1-8971-a2d4373b3f19",
+					PlayerID: "9b2f3c1e-5a4d-4e7f-8c6b-1d2e3f4a5b6c",
+					Bet:      "10",
+					Type:     TypeStraight,
+					Amount:   100,
+					Currency: "GBP",
+				},
+			},
+			wantNext: "foo",
+			wantErr:  nil,
+		},
+		{
+			name:     "expect fail given invalid cursor",
+			Storage:  mockStorage{GivenError: page.ErrCursor},
+			wantBets: []Bet{},
+			wantErr:  page.ErrCursor,
+		},
+		{
+			name:     "expect ErrType given unknown bet type",
+			Storage:  mockStorage{},
+			filter:   Filter{Type: "foo"},
+			wantBets: []Bet{},
+			wantErr:  ErrType,
+		},
+		{
+			name: "expect fail given storage error",
+			Storage: mockStorage{
+				GivenError: errors.New("foo"),
+			},
+			wantBets: []Bet{},
+			wantErr:  ErrList,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := New(logrus.New(), tt.Storage, mockTables{}, mockRounds{}, mockWallets{}, mockLedger{}, mockTransaction{}, mockRandom{}, &mockEvents{})
+			bets, next, err := c.ListPlayer(context.Background(), "9b2f3c1e-5a4d-4e7f-8c6b-1d2e3f4a5b6c", tt.filter)
+
+			if !cmp.Equal(err, tt.wantErr, cmpopts.EquateErrors()) {
+				t.Error(cmp.Diff(err, tt.wantErr, cmpopts.EquateErrors()))
+			}
+
+			if !cmp.Equal(bets, tt.wantBets) {
+				t.Error(cmp.Diff(bets, tt.wantBets))
+			}
+
+			if !cmp.Equal(next, tt.wantNext) {
+				t.Error(cmp.Diff(next, tt.wantNext))
+			}
+		})
+	}
+}
+
+func TestController_Update(t *testing.T) {
 	tests := []struct {
 		name    string
 		Logger  *logrus.Logger
 		Storage StorageProvider
-		tableID string
-		want    Result
+		Rounds  RoundProvider
+		Tables  TableProvider
+		model   Bet
 		wantErr error
 	}{
 		{
-			name:   "expect success given valid input",
+			name:   "expect success given valid bet",
+			Logger: logrus.New(),
+			Rounds: mockRounds{GivenRound: openRound},
+			Tables: mockTables{GivenTable: gbpTable},
+			Storage: mockStorage{
+				GivenBet: Bet{PlayerID: "9b2f3c1e-5a4d-4e7f-8c6b-1d2e3f4a5b6c", RoundID: openRound.ID},
+			},
+			model: Bet{
+				ID:       "8117bb87-148c-4fb1-8971-a2d4373b3f19",
+				TableID:  "8117bb87-148c-4fb1-8971-a2d4373b3f19",
+				PlayerID: "9b2f3c1e-5a4d-4e7f-8c6b-1d2e3f4a5b6c",
+				Bet:      "10",
+				Type:     TypeStraight,
+				Amount:   100,
+				Currency: "GBP",
+			},
+			wantErr: nil,
+		},
+		{
+			name:   "expect fail given storage error",
+			Logger: logrus.New(),
+			Rounds: mockRounds{GivenRound: openRound},
+			Tables: mockTables{GivenTable: gbpTable},
+			Storage: mockStorage{
+				GivenError: errors.New("foo"),
+			},
+			model: Bet{
+				ID:       "8117bb87-148c-4fb1-8971-a2d4373b3f19",
+				TableID:  "8117bb87-148c-4fb1-8971-a2d4373b3f19",
+				Bet:      "10",
+				Type:     TypeStraight,
+				Amount:   100,
+				Currency: "GBP",
+			},
+			wantErr: ErrUpdate,
+		},
+		{
+			name:   "expect fail given bet owned by another player",
+			Logger: logrus.New(),
+			Rounds: mockRounds{GivenRound: openRound},
+			Tables: mockTables{GivenTable: gbpTable},
+			Storage: mockStorage{
+				GivenBet: Bet{PlayerID: "9b2f3c1e-5a4d-4e7f-8c6b-1d2e3f4a5b6c", RoundID: openRound.ID},
+			},
+			model: Bet{
+				ID:       "8117bb87-148c-4fb1-8971-a2d4373b3f19",
+				TableID:  "8117bb87-148c-4fb1-8971-a2d4373b3f19",
+				PlayerID: uuid.New().String(),
+				Bet:      "10",
+				Type:     TypeStraight,
+				Amount:   100,
+				Currency: "GBP",
+			},
+			wantErr: ErrOwner,
+		},
+		{
+			name:   "expect fail given bet has changed",
+			Logger: logrus.New(),
+			Rounds: mockRounds{GivenRound: openRound},
+			Tables: mockTables{GivenTable: gbpTable},
+			Storage: mockStorage{
+				GivenBet: Bet{PlayerID: "9b2f3c1e-5a4d-4e7f-8c6b-1d2e3f4a5b6c", RoundID: openRound.ID, Version: 2},
+			},
+			model: Bet{
+				ID:       "8117bb87-148c-4fb1-8971-a2d4373b3f19",
+				TableID:  "8117bb87-148c-4fb1-8971-a2d4373b3f19",
+				PlayerID: "9b2f3c1e-5a4d-4e7f-8c6b-1d2e3f4a5b6c",
+				Bet:      "10",
+				Type:     TypeStraight,
+				Amount:   100,
+				Currency: "GBP",
+				Version:  1,
+			},
+			wantErr: ErrVersion,
+		},
+		{
+			name:   "expect fail given imprisoned bet",
+			Logger: logrus.New(),
+			Rounds: mockRounds{GivenRound: openRound},
+			Tables: mockTables{GivenTable: gbpTable},
+			Storage: mockStorage{
+				GivenBet: Bet{PlayerID: "9b2f3c1e-5a4d-4e7f-8c6b-1d2e3f4a5b6c", Imprisoned: true},
+			},
+			model: Bet{
+				ID:       "8117bb87-148c-4fb1-8971-a2d4373b3f19",
+				TableID:  "8117bb87-148c-4fb1-8971-a2d4373b3f19",
+				PlayerID: "9b2f3c1e-5a4d-4e7f-8c6b-1d2e3f4a5b6c",
+				Bet:      "red",
+				Type:     TypeRedBlack,
+				Amount:   100,
+				Currency: "GBP",
+			},
+			wantErr: ErrImprisoned,
+		},
+		{
+			name:   "expect ErrClosed given betting closed on the round of the bet",
+			Logger: logrus.New(),
+			Rounds: mockRounds{GivenRound: round.Round{ID: openRound.ID, State: round.StateClosed}},
+			Tables: mockTables{GivenTable: gbpTable},
+			Storage: mockStorage{
+				GivenBet: Bet{PlayerID: "9b2f3c1e-5a4d-4e7f-8c6b-1d2e3f4a5b6c", RoundID: openRound.ID},
+			},
+			model: Bet{
+				ID:       "8117bb87-148c-4fb1-8971-a2d4373b3f19",
+				TableID:  "8117bb87-148c-4fb1-8971-a2d4373b3f19",
+				PlayerID: "9b2f3c1e-5a4d-4e7f-8c6b-1d2e3f4a5b6c",
+				Bet:      "10",
+				Type:     TypeStraight,
+				Amount:   100,
+				Currency: "GBP",
+			},
+			wantErr: ErrClosed,
+		},
+		{
+			name:    "expect fail given street that does not exist on the layout",
+			Logger:  logrus.New(),
+			Rounds:  mockRounds{GivenRound: openRound},
+			Tables:  mockTables{GivenTable: gbpTable},
+			Storage: mockStorage{},
+			model: Bet{
+				ID:       "8117bb87-148c-4fb1-8971-a2d4373b3f19",
+				TableID:  "8117bb87-148c-4fb1-8971-a2d4373b3f19",
+				Bet:      "2-3-4",
+				Type:     TypeStreet,
+				Amount:   100,
+				Currency: "GBP",
+			},
+			wantErr: ErrBet,
+		},
+		{
+			name:   "expect fail given currency that does not match the table",
+			Logger: logrus.New(),
+			Rounds: mockRounds{GivenRound: openRound},
+			Tables: mockTables{GivenTable: gbpTable},
+			Storage: mockStorage{
+				GivenBet: Bet{PlayerID: "9b2f3c1e-5a4d-4e7f-8c6b-1d2e3f4a5b6c", RoundID: openRound.ID},
+			},
+			model: Bet{
+				ID:       "8117bb87-148c-4fb1-8971-a2d4373b3f19",
+				TableID:  "8117bb87-148c-4fb1-8971-a2d4373b3f19",
+				PlayerID: "9b2f3c1e-5a4d-4e7f-8c6b-1d2e3f4a5b6c",
+				Bet:      "10",
+				Type:     TypeStraight,
+				Amount:   100,
+				Currency: "EUR",
+			},
+			wantErr: ErrCurrency,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			events := &mockEvents{}
+			c := New(tt.Logger, tt.Storage, tt.Tables, tt.Rounds, mockWallets{}, mockLedger{}, mockTransaction{}, mockRandom{}, events)
+			_, err := c.Update(context.Background(), tt.model)
+
+			if !cmp.Equal(err, tt.wantErr, cmpopts.EquateErrors()) {
+				t.Error(cmp.Diff(err, tt.wantErr, cmpopts.EquateErrors()))
+			}
+
+			var wantEvents []string
+			if tt.wantErr == nil {
+				wantEvents = []string{event.TypeBetUpdated}
+			}
+
+			if !cmp.Equal(events.Published, wantEvents) {
+				t.Error(cmp.Diff(events.Published, wantEvents))
+			}
+		})
+	}
+}
+
+func TestController_Delete(t *testing.T) {
+	tests := []struct {
+		name     string
+		Logger   *logrus.Logger
+		Storage  StorageProvider
+		Rounds   RoundProvider
+		id       string
+		playerID string
+		wantErr  error
+	}{
+		{
+			name:   "expect success given valid bet",
+			Logger: logrus.New(),
+			Rounds: mockRounds{GivenRound: openRound},
+			Storage: mockStorage{
+				GivenBet: Bet{PlayerID: "9b2f3c1e-5a4d-4e7f-8c6b-1d2e3f4a5b6c", RoundID: openRound.ID},
+			},
+			id:       uuid.New().String(),
+			playerID: "9b2f3c1e-5a4d-4e7f-8c6b-1d2e3f4a5b6c",
+			wantErr:  nil,
+		},
+		{
+			name:   "expect fail given bet owned by another player",
+			Logger: logrus.New(),
+			Rounds: mockRounds{GivenRound: openRound},
+			Storage: mockStorage{
+				GivenBet: Bet{PlayerID: "9b2f3c1e-5a4d-4e7f-8c6b-1d2e3f4a5b6c", RoundID: openRound.ID},
+			},
+			id:       uuid.New().String(),
+			playerID: uuid.New().String(),
+			wantErr:  ErrOwner,
+		},
+		{
+			name:   "expect fail given imprisoned bet",
+			Logger: logrus.New(),
+			Rounds: mockRounds{GivenRound: openRound},
+			Storage: mockStorage{
+				GivenBet: Bet{PlayerID: "9b2f3c1e-5a4d-4e7f-8c6b-1d2e3f4a5b6c", Imprisoned: true},
+			},
+			id:       uuid.New().String(),
+			playerID: "9b2f3c1e-5a4d-4e7f-8c6b-1d2e3f4a5b6c",
+			wantErr:  ErrImprisoned,
+		},
+		{
+			name:   "expect ErrClosed given bet placed on a round already played",
+			Logger: logrus.New(),
+			Rounds: mockRounds{GivenRound: openRound},
+			Storage: mockStorage{
+				GivenBet: Bet{PlayerID: "9b2f3c1e-5a4d-4e7f-8c6b-1d2e3f4a5b6c", RoundID: spunRound.ID},
+			},
+			id:       uuid.New().String(),
+			playerID: "9b2f3c1e-5a4d-4e7f-8c6b-1d2e3f4a5b6c",
+			wantErr:  ErrClosed,
+		},
+		{
+			name:   "expect fail given storage error",
+			Logger: logrus.New(),
+			Rounds: mockRounds{GivenRound: openRound},
+			Storage: mockStorage{
+				GivenError: errors.New("foo"),
+			},
+			id:      uuid.New().String(),
+			wantErr: ErrDelete,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			events := &mockEvents{}
+			c := New(tt.Logger, tt.Storage, mockTables{}, tt.Rounds, mockWallets{}, mockLedger{}, mockTransaction{}, mockRandom{}, events)
+			_, err := c.Delete(context.Background(), uuid.New().String(), tt.id, tt.playerID)
+
+			if !cmp.Equal(err, tt.wantErr, cmpopts.EquateErrors()) {
+				t.Error(cmp.Diff(err, tt.wantErr, cmpopts.EquateErrors()))
+			}
+
+			var wantEvents []string
+			if tt.wantErr == nil {
+				wantEvents = []string{event.TypeBetCancelled}
+			}
+
+			if !cmp.Equal(events.Published, wantEvents) {
+				t.Error(cmp.Diff(events.Published, wantEvents))
+			}
+		})
+	}
+}
+
+func TestController_DeleteTable(t *testing.T) {
+	inPlay := []Bet{
+		{ID: uuid.New().String(), PlayerID: uuid.New().String(), RoundID: openRound.ID, Amount: 100, Currency: "GBP"},
+		{ID: uuid.New().String(), PlayerID: uuid.New().String(), RoundID: openRound.ID, Amount: 50, Currency: "GBP", Imprisoned: true},
+	}
+
+	tests := []struct {
+		name        string
+		Logger      *logrus.Logger
+		Storage     StorageProvider
+		Tables      TableProvider
+		Rounds      RoundProvider
+		Wallets     WalletProvider
+		force       bool
+		wantErr     error
+		wantEvents  []string
+		wantRemoved []string
+	}{
+		{
+			name:        "expect success given no bets in play",
+			Logger:      logrus.New(),
+			Storage:     mockStorage{},
+			Tables:      mockTables{GivenTable: gbpTable},
+			Rounds:      mockRounds{GivenRound: openRound},
+			Wallets:     mockWallets{},
+			wantErr:     nil,
+			wantRemoved: []string{gbpTable.ID},
+		},
+		{
+			name:    "expect ErrOpenBets given bets in play",
+			Logger:  logrus.New(),
+			Storage: mockStorage{GivenList: inPlay},
+			Tables:  mockTables{GivenTable: gbpTable},
+			Rounds:  mockRounds{GivenRound: openRound},
+			Wallets: mockWallets{},
+			wantErr: ErrOpenBets,
+		},
+		{
+			name:        "expect bets in play refunded given force",
+			Logger:      logrus.New(),
+			Storage:     mockStorage{GivenList: inPlay},
+			Tables:      mockTables{GivenTable: gbpTable},
+			Rounds:      mockRounds{GivenRound: openRound},
+			Wallets:     mockWallets{},
+			force:       true,
+			wantErr:     nil,
+			wantEvents:  []string{event.TypeBetCancelled, event.TypeBetCancelled},
+			wantRemoved: []string{gbpTable.ID},
+		},
+		{
+			name:        "expect success without a round opened given no round in play",
+			Logger:      logrus.New(),
+			Storage:     mockStorage{GivenList: inPlay},
+			Tables:      mockTables{GivenTable: gbpTable},
+			Rounds:      mockRounds{GivenError: errors.New("foo"), GivenFindError: round.ErrNotFound},
+			Wallets:     mockWallets{},
+			wantErr:     nil,
+			wantRemoved: []string{gbpTable.ID},
+		},
+		{
+			name:    "expect fail given round cannot be found",
+			Logger:  logrus.New(),
+			Storage: mockStorage{},
+			Tables:  mockTables{GivenTable: gbpTable},
+			Rounds:  mockRounds{GivenFindError: errors.New("foo")},
+			Wallets: mockWallets{},
+			wantErr: ErrDeleteTable,
+		},
+		{
+			name:    "expect ErrTable given table not found",
+			Logger:  logrus.New(),
+			Storage: mockStorage{},
+			Tables:  mockTables{GivenError: table.ErrNotFound},
+			Rounds:  mockRounds{GivenRound: openRound},
+			Wallets: mockWallets{},
+			wantErr: ErrTable,
+		},
+		{
+			name:    "expect fail given funds cannot be released",
+			Logger:  logrus.New(),
+			Storage: mockStorage{GivenList: inPlay},
+			Tables:  mockTables{GivenTable: gbpTable},
+			Rounds:  mockRounds{GivenRound: openRound},
+			Wallets: mockWallets{GivenError: errors.New("foo")},
+			force:   true,
+			wantErr: ErrDeleteTable,
+		},
+		{
+			name:    "expect fail given table cannot be deleted",
+			Logger:  logrus.New(),
+			Storage: mockStorage{},
+			Tables:  mockTables{GivenTable: gbpTable, GivenDeleteError: errors.New("foo")},
+			Rounds:  mockRounds{GivenRound: openRound},
+			Wallets: mockWallets{},
+			wantErr: ErrDeleteTable,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			events := &mockEvents{}
+			c := New(tt.Logger, tt.Storage, tt.Tables, tt.Rounds, tt.Wallets, mockLedger{}, mockTransaction{}, mockRandom{}, events)
+			_, err := c.DeleteTable(context.Background(), gbpTable.ID, tt.force)
+
+			if !cmp.Equal(err, tt.wantErr, cmpopts.EquateErrors()) {
+				t.Error(cmp.Diff(err, tt.wantErr, cmpopts.EquateErrors()))
+			}
+
+			if !cmp.Equal(events.Published, tt.wantEvents) {
+				t.Error(cmp.Diff(events.Published, tt.wantEvents))
+			}
+
+			if !cmp.Equal(events.Removed, tt.wantRemoved) {
+				t.Error(cmp.Diff(events.Removed, tt.wantRemoved))
+			}
+		})
+	}
+}
+
+func TestController_Play(t *testing.T) {
+	tests := []struct {
+		name        string
+		Logger      *logrus.Logger
+		Storage     StorageProvider
+		Tables      TableProvider
+		Rounds      RoundProvider
+		Random      RandomProvider
+		Transaction mockTransaction
+		tableID     string
+		want        Result
+		wantErr     error
+		wantEvents  []string
+		wantLocks   []string
+	}{
+		{
+			name:   "expect success given valid input",
+			Logger: logrus.New(),
+			Tables: mockTables{GivenTable: gbpTable},
+			Rounds: mockRounds{GivenRound: openRound},
+			Random: mockRandom{GivenNumber: 17},
+			Storage: mockStorage{
+				GivenList: []Bet{},
+			},
+			tableID: "8117bb87-148c-4fb1-8971-a2d4373b3f19",
+			want: Result{
+				RoundID:    openRound.ID,
+				Number:     17,
+				Pocket:     "17",
+				Color:      "black",
+				Winners:    []Winner{},
+				Imprisoned: []string{},
+			},
+			wantErr:    nil,
+			wantEvents: []string{event.TypeBettingClosed, event.TypeRoundSpun, event.TypeRoundSettled},
+			wantLocks: []string{
+				"try play:8117bb87-148c-4fb1-8971-a2d4373b3f19",
+				"lock 8117bb87-148c-4fb1-8971-a2d4373b3f19",
+			},
+		},
+		{
+			name:   "expect success given valid input with found bets",
+			Logger: logrus.New(),
+			Tables: mockTables{GivenTable: gbpTable},
+			Rounds: mockRounds{GivenRound: spunRound},
+			Random: mockRandom{GivenNumber: 17},
+			Storage: mockStorage{
+				GivenList: []Bet{
+					{
+						ID:       "8117bb87-148c-4fb1-8971-a2d4373b3f19",
+						TableID:  "8117bb87-148c-4fb1-8971-a2d4373b3f19",
+						Bet:      "10",
+						Type:     "straight",
+						Amount:   1000,
+						Currency: "GBP",
+					},
+				},
+			},
+			tableID: "8117bb87-148c-4fb1-8971-a2d4373b3f19",
+			want: Result{
+				RoundID: spunRound.ID,
+				Number:  10,
+				Pocket:  "10",
+				Color:   "black",
+				Winners: []Winner{
+					{
+						BetID:      "8117bb87-148c-4fb1-8971-a2d4373b3f19",
+						Stake:      1000,
+						Multiplier: 35,
+						Winnings:   35000,
+						Return:     36000,
+						Currency:   "GBP",
+						Outcome:    OutcomeWin,
+					},
+				},
+				Imprisoned: []string{},
+			},
+			wantErr:    nil,
+			wantEvents: []string{event.TypeRoundSettled},
+		},
+		{
+			name:   "expect even money payout given found red/black bet",
+			Logger: logrus.New(),
+			Tables: mockTables{GivenTable: gbpTable},
+			Rounds: mockRounds{GivenRound: spunRound},
+			Random: mockRandom{GivenNumber: 17},
+			Storage: mockStorage{
+				GivenList: []Bet{
+					{
+						ID:       "8117bb87-148c-4fb1-8971-a2d4373b3f19",
+						TableID:  "8117bb87-148c-4fb1-8971-a2d4373b3f19",
+						Bet:      "red",
+						Type:     TypeRedBlack,
+						Amount:   1000,
+						Currency: "GBP",
+					},
+					{
+						ID:       "b0a1d3b6-5b52-4f0b-9d3a-9a7f2a3f0001",
+						TableID:  "8117bb87-148c-4fb1-8971-a2d4373b3f19",
+						Bet:      "black",
+						Type:     TypeRedBlack,
+						Amount:   1000,
+						Currency: "GBP",
+					},
+				},
+			},
+			tableID: "8117bb87-148c-4fb1-8971-a2d4373b3f19",
+			want: Result{
+				RoundID: spunRound.ID,
+				Number:  10,
+				Pocket:  "10",
+				Color:   "black",
+				Winners: []Winner{
+					{
+						BetID:      "b0a1d3b6-5b52-4f0b-9d3a-9a7f2a3f0001",
+						Stake:      1000,
+						Multiplier: 1,
+						Winnings:   1000,
+						Return:     2000,
+						Currency:   "GBP",
+						Outcome:    OutcomeWin,
+					},
+				},
+				Imprisoned: []string{},
+			},
+			wantErr:    nil,
+			wantEvents: []string{event.TypeRoundSettled},
+		},
+		{
+			name:   "expect straight to win given the wheel spins its number",
+			Logger: logrus.New(),
+			Tables: mockTables{GivenTable: gbpTable},
+			Rounds: mockRounds{GivenRound: openRound},
+			Random: mockRandom{GivenNumber: 17},
+			Storage: mockStorage{
+				GivenList: []Bet{
+					{
+						ID:       "b0a1d3b6-5b52-4f0b-9d3a-9a7f2a3f0001",
+						TableID:  "8117bb87-148c-4fb1-8971-a2d4373b3f19",
+						Bet:      "17",
+						Type:     TypeStraight,
+						Amount:   100,
+						Currency: "GBP",
+					},
+					{
+						ID:       "b0a1d3b6-5b52-4f0b-9d3a-9a7f2a3f0002",
+						TableID:  "8117bb87-148c-4fb1-8971-a2d4373b3f19",
+						Bet:      "18",
+						Type:     TypeStraight,
+						Amount:   100,
+						Currency: "GBP",
+					},
+				},
+			},
+			tableID: "8117bb87-148c-4fb1-8971-a2d4373b3f19",
+			want: Result{
+				RoundID: openRound.ID,
+				Number:  17,
+				Pocket:  "17",
+				Color:   "black",
+				Winners: []Winner{
+					{
+						BetID:      "b0a1d3b6-5b52-4f0b-9d3a-9a7f2a3f0001",
+						Stake:      100,
+						Multiplier: 35,
+						Winnings:   3500,
+						Return:     3600,
+						Currency:   "GBP",
+						Outcome:    OutcomeWin,
+					},
+				},
+				Imprisoned: []string{},
+			},
+			wantErr:    nil,
+			wantEvents: []string{event.TypeBettingClosed, event.TypeRoundSpun, event.TypeRoundSettled},
+		},
+		{
+			name:   "expect fail given random number error",
 			Logger: logrus.New(),
+			Tables: mockTables{GivenTable: gbpTable},
+			Rounds: mockRounds{GivenRound: openRound},
+			Random: mockRandom{GivenError: errors.New("foo")},
 			Storage: mockStorage{
 				GivenList: []Bet{},
 			},
+			tableID:    "8117bb87-148c-4fb1-8971-a2d4373b3f19",
+			want:       Result{},
+			wantErr:    ErrPlay,
+			wantEvents: nil,
+		},
+		{
+			name:   "expect half the stake returned given zero under la partage",
+			Logger: logrus.New(),
+			Tables: mockTables{GivenTable: table.Table{Wheel: table.WheelEuropean, Rules: table.RulesLaPartage}},
+			Rounds: mockRounds{GivenRound: zeroRound},
+			Random: mockRandom{GivenNumber: 17},
+			Storage: mockStorage{
+				GivenList: []Bet{
+					{
+						ID:       "b0a1d3b6-5b52-4f0b-9d3a-9a7f2a3f0001",
+						TableID:  "8117bb87-148c-4fb1-8971-a2d4373b3f19",
+						Bet:      "red",
+						Type:     TypeRedBlack,
+						Amount:   1000,
+						Currency: "GBP",
+					},
+				},
+			},
 			tableID: "8117bb87-148c-4fb1-8971-a2d4373b3f19",
 			want: Result{
-				Winners: []Winner{},
+				RoundID: zeroRound.ID,
+				Number:  0,
+				Pocket:  "0",
+				Color:   "green",
+				Winners: []Winner{
+					{
+						BetID:    "b0a1d3b6-5b52-4f0b-9d3a-9a7f2a3f0001",
+						Stake:    1000,
+						Return:   500,
+						Currency: "GBP",
+						Outcome:  OutcomeLaPartage,
+					},
+				},
+				Imprisoned: []string{},
 			},
-			wantErr: nil,
+			wantErr:    nil,
+			wantEvents: []string{event.TypeRoundSettled},
 		},
 		{
-			name:   "expect success given valid input with found bets",
+			name:   "expect bet imprisoned given zero under en prison",
 			Logger: logrus.New(),
+			Tables: mockTables{GivenTable: table.Table{Wheel: table.WheelEuropean, Rules: table.RulesEnPrison}},
+			Rounds: mockRounds{GivenRound: zeroRound},
+			Random: mockRandom{GivenNumber: 17},
 			Storage: mockStorage{
 				GivenList: []Bet{
 					{
-						ID:       "8117bb87-148c-4fb1-8971-a2d4373b3f19",
+						ID:       "b0a1d3b6-5b52-4f0b-9d3a-9a7f2a3f0001",
 						TableID:  "8117bb87-148c-4fb1-8971-a2d4373b3f19",
-						Bet:      "10",
-						Type:     "straight",
+						Bet:      "red",
+						Type:     TypeRedBlack,
 						Amount:   1000,
 						Currency: "GBP",
 					},
@@ -282,93 +1311,467 @@ func TestController_Play(t *testing.T) {
 			},
 			tableID: "8117bb87-148c-4fb1-8971-a2d4373b3f19",
 			want: Result{
+				RoundID:    zeroRound.ID,
+				Number:     0,
+				Pocket:     "0",
+				Color:      "green",
+				Winners:    []Winner{},
+				Imprisoned: []string{"b0a1d3b6-5b52-4f0b-9d3a-9a7f2a3f0001"},
+			},
+			wantErr:    nil,
+			wantEvents: []string{event.TypeRoundSettled},
+		},
+		{
+			name:   "expect stake returned given imprisoned bet wins under en prison",
+			Logger: logrus.New(),
+			Tables: mockTables{GivenTable: table.Table{Wheel: table.WheelEuropean, Rules: table.RulesEnPrison}},
+			Rounds: mockRounds{GivenRound: spunRound},
+			Random: mockRandom{GivenNumber: 17},
+			Storage: mockStorage{
+				GivenList: []Bet{
+					{
+						ID:         "b0a1d3b6-5b52-4f0b-9d3a-9a7f2a3f0001",
+						TableID:    "8117bb87-148c-4fb1-8971-a2d4373b3f19",
+						Bet:        "black",
+						Type:       TypeRedBlack,
+						Amount:     1000,
+						Currency:   "GBP",
+						Imprisoned: true,
+					},
+				},
+			},
+			tableID: "8117bb87-148c-4fb1-8971-a2d4373b3f19",
+			want: Result{
+				RoundID: spunRound.ID,
+				Number:  10,
+				Pocket:  "10",
+				Color:   "black",
 				Winners: []Winner{
 					{
-						BetID:    "8117bb87-148c-4fb1-8971-a2d4373b3f19",
-						Amount:   1000,
+						BetID:    "b0a1d3b6-5b52-4f0b-9d3a-9a7f2a3f0001",
+						Stake:    1000,
+						Return:   1000,
 						Currency: "GBP",
+						Outcome:  OutcomeEnPrison,
 					},
 				},
+				Imprisoned: []string{},
 			},
-			wantErr: nil,
+			wantErr:    nil,
+			wantEvents: []string{event.TypeRoundSettled},
 		},
 		{
 			name:   "expect fail given storage error",
 			Logger: logrus.New(),
+			Tables: mockTables{GivenTable: gbpTable},
+			Rounds: mockRounds{GivenRound: openRound},
+			Random: mockRandom{GivenNumber: 17},
 			Storage: mockStorage{
 				GivenList:  []Bet{},
 				GivenError: errors.New("foo"),
 			},
+			tableID:    "8117bb87-148c-4fb1-8971-a2d4373b3f19",
+			want:       Result{},
+			wantErr:    ErrPlay,
+			wantEvents: nil,
+		},
+		{
+			name:   "expect fail given round error",
+			Logger: logrus.New(),
+			Tables: mockTables{GivenTable: gbpTable},
+			Rounds: mockRounds{GivenRound: openRound, GivenError: errors.New("foo")},
+			Random: mockRandom{GivenNumber: 17},
+			Storage: mockStorage{
+				GivenList: []Bet{},
+			},
+			tableID:    "8117bb87-148c-4fb1-8971-a2d4373b3f19",
+			want:       Result{},
+			wantErr:    ErrPlay,
+			wantEvents: nil,
+		},
+		{
+			name:   "expect outcome derived from the seeds given round opened provably fair",
+			Logger: logrus.New(),
+			Tables: mockTables{GivenTable: fairTable},
+			Rounds: mockRounds{GivenRound: seededRound},
+			Random: mockRandom{GivenError: errors.New("foo")},
+			Storage: mockStorage{GivenList: []Bet{
+				{ID: "a1", RoundID: seededRound.ID, Bet: "0", Type: TypeStraight, Amount: 10, ClientSeed: "client"},
+				{ID: "a2", RoundID: seededRound.ID, Bet: "0", Type: TypeStraight, Amount: 10},
+			}},
+			tableID: "8117bb87-148c-4fb1-8971-a2d4373b3f19",
+			want: Result{
+				RoundID:        seededRound.ID,
+				Number:         19,
+				Pocket:         "19",
+				Color:          "red",
+				Winners:        []Winner{},
+				Imprisoned:     []string{},
+				ProvablyFair:   true,
+				ServerSeed:     "server",
+				ServerSeedHash: fair.Hash("server"),
+				ClientSeed:     "client",
+			},
+			wantErr:    nil,
+			wantEvents: []string{event.TypeBettingClosed, event.TypeRoundSpun, event.TypeRoundSettled},
+		},
+		{
+			name:   "expect client seeds of the bets joined in order given round opened provably fair",
+			Logger: logrus.New(),
+			Tables: mockTables{GivenTable: fairTable},
+			Rounds: mockRounds{GivenRound: seededRound},
+			Random: mockRandom{GivenError: errors.New("foo")},
+			Storage: mockStorage{GivenList: []Bet{
+				{ID: "a1", RoundID: seededRound.ID, Bet: "0", Type: TypeStraight, Amount: 10, ClientSeed: "alice"},
+				{ID: "a2", RoundID: seededRound.ID, Bet: "0", Type: TypeStraight, Amount: 10, ClientSeed: "bob"},
+			}},
+			tableID: "8117bb87-148c-4fb1-8971-a2d4373b3f19",
+			want: Result{
+				RoundID:        seededRound.ID,
+				Number:         34,
+				Pocket:         "34",
+				Color:          "red",
+				Winners:        []Winner{},
+				Imprisoned:     []string{},
+				ProvablyFair:   true,
+				ServerSeed:     "server",
+				ServerSeedHash: fair.Hash("server"),
+				ClientSeed:     "alice,bob",
+			},
+			wantErr:    nil,
+			wantEvents: []string{event.TypeBettingClosed, event.TypeRoundSpun, event.TypeRoundSettled},
+		},
+		{
+			name:    "expect round ID used as client seed given round opened provably fair without client seeds",
+			Logger:  logrus.New(),
+			Tables:  mockTables{GivenTable: fairTable},
+			Rounds:  mockRounds{GivenRound: seededRound},
+			Random:  mockRandom{GivenError: errors.New("foo")},
+			Storage: mockStorage{GivenList: []Bet{}},
+			tableID: "8117bb87-148c-4fb1-8971-a2d4373b3f19",
+			want: Result{
+				RoundID:        seededRound.ID,
+				Number:         fair.Outcome("server", seededRound.ID, 0, 37),
+				Pocket:         round.Pocket(fair.Outcome("server", seededRound.ID, 0, 37)),
+				Color:          Color(fair.Outcome("server", seededRound.ID, 0, 37)),
+				Winners:        []Winner{},
+				Imprisoned:     []string{},
+				ProvablyFair:   true,
+				ServerSeed:     "server",
+				ServerSeedHash: fair.Hash("server"),
+				ClientSeed:     seededRound.ID,
+			},
+			wantErr:    nil,
+			wantEvents: []string{event.TypeBettingClosed, event.TypeRoundSpun, event.TypeRoundSettled},
+		},
+		{
+			name:    "expect random source used given round opened before the table was provably fair",
+			Logger:  logrus.New(),
+			Tables:  mockTables{GivenTable: fairTable},
+			Rounds:  mockRounds{GivenRound: round.Round{ID: seededRound.ID, State: round.StateOpen, ServerSeed: "server"}},
+			Random:  mockRandom{GivenNumber: 17},
+			Storage: mockStorage{GivenList: []Bet{}},
+			tableID: "8117bb87-148c-4fb1-8971-a2d4373b3f19",
+			want: Result{
+				RoundID:    seededRound.ID,
+				Number:     17,
+				Pocket:     "17",
+				Color:      "black",
+				Winners:    []Winner{},
+				Imprisoned: []string{},
+				ServerSeed: "server",
+			},
+			wantErr:    nil,
+			wantEvents: []string{event.TypeBettingClosed, event.TypeRoundSpun, event.TypeRoundSettled},
+		},
+		{
+			name:    "expect random source used given round opened without a server seed",
+			Logger:  logrus.New(),
+			Tables:  mockTables{GivenTable: fairTable},
+			Rounds:  mockRounds{GivenRound: openRound},
+			Random:  mockRandom{GivenNumber: 17},
+			Storage: mockStorage{GivenList: []Bet{}},
 			tableID: "8117bb87-148c-4fb1-8971-a2d4373b3f19",
-			want:    Result{},
-			wantErr: ErrList,
+			want: Result{
+				RoundID:    openRound.ID,
+				Number:     17,
+				Pocket:     "17",
+				Color:      "black",
+				Winners:    []Winner{},
+				Imprisoned: []string{},
+			},
+			wantErr:    nil,
+			wantEvents: []string{event.TypeBettingClosed, event.TypeRoundSpun, event.TypeRoundSettled},
+		},
+		{
+			name:       "expect ErrTable given table not found",
+			Logger:     logrus.New(),
+			Tables:     mockTables{GivenError: table.ErrNotFound},
+			Rounds:     mockRounds{GivenRound: openRound},
+			Random:     mockRandom{GivenNumber: 17},
+			Storage:    mockStorage{},
+			tableID:    "8117bb87-148c-4fb1-8971-a2d4373b3f19",
+			want:       Result{},
+			wantErr:    ErrTable,
+			wantEvents: nil,
+		},
+		{
+			name:        "expect ErrInPlay given table already being played",
+			Logger:      logrus.New(),
+			Tables:      mockTables{},
+			Rounds:      mockRounds{GivenRound: openRound},
+			Random:      mockRandom{GivenNumber: 17},
+			Storage:     mockStorage{},
+			Transaction: mockTransaction{Busy: true},
+			tableID:     "8117bb87-148c-4fb1-8971-a2d4373b3f19",
+			want:        Result{},
+			wantErr:     ErrInPlay,
+			wantEvents:  nil,
+			wantLocks:   []string{"try play:8117bb87-148c-4fb1-8971-a2d4373b3f19"},
 		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			c := New(tt.Logger, tt.Storage)
+			var locks []string
+
+			tt.Transaction.Locks = &locks
+			events := &mockEvents{}
+			c := New(tt.Logger, tt.Storage, tt.Tables, tt.Rounds, mockWallets{}, mockLedger{}, tt.Transaction, tt.Random, events)
 
 			got, err := c.Play(context.Background(), tt.tableID)
 			if !cmp.Equal(err, tt.wantErr, cmpopts.EquateErrors()) {
 				t.Error(cmp.Diff(err, tt.wantErr, cmpopts.EquateErrors()))
 			}
 
-			if !cmp.Equal(got, tt.want, cmpopts.IgnoreFields(Result{}, "Number", "Color")) {
-				t.Error(cmp.Diff(err, tt.want, cmpopts.IgnoreFields(Result{}, "Number", "Color")))
+			if tt.wantLocks != nil && !cmp.Equal(locks, tt.wantLocks) {
+				t.Error(cmp.Diff(locks, tt.wantLocks))
+			}
+
+			if !cmp.Equal(events.Published, tt.wantEvents) {
+				t.Error(cmp.Diff(events.Published, tt.wantEvents))
+			}
+
+			if !cmp.Equal(got, tt.want) {
+				t.Error(cmp.Diff(got, tt.want))
+			}
+		})
+	}
+}
+
+func TestController_Close(t *testing.T) {
+	tests := []struct {
+		name       string
+		Rounds     RoundProvider
+		model      round.Round
+		want       round.Round
+		wantErr    error
+		wantEvents []string
+	}{
+		{
+			name:   "expect round closed given current round",
+			Rounds: mockRounds{GivenRound: openRound},
+			model:  openRound,
+			want: round.Round{
+				ID:      openRound.ID,
+				TableID: openRound.TableID,
+				State:   round.StateClosed,
+			},
+			wantErr:    nil,
+			wantEvents: []string{event.TypeBettingClosed},
+		},
+		{
+			name:       "expect ErrState given round already played",
+			Rounds:     mockRounds{GivenRound: seededRound},
+			model:      openRound,
+			want:       round.Round{},
+			wantErr:    round.ErrState,
+			wantEvents: nil,
+		},
+		{
+			name:       "expect fail given round error",
+			Rounds:     mockRounds{GivenRound: openRound, GivenError: round.ErrUpdate},
+			model:      openRound,
+			want:       round.Round{},
+			wantErr:    round.ErrUpdate,
+			wantEvents: nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			events := &mockEvents{}
+			c := New(logrus.New(), mockStorage{}, mockTables{}, tt.Rounds, mockWallets{}, mockLedger{}, mockTransaction{}, mockRandom{}, events)
+
+			got, err := c.Close(context.Background(), tt.model)
+			if !cmp.Equal(err, tt.wantErr, cmpopts.EquateErrors()) {
+				t.Error(cmp.Diff(err, tt.wantErr, cmpopts.EquateErrors()))
+			}
+
+			if !cmp.Equal(got, tt.want) {
+				t.Error(cmp.Diff(got, tt.want))
+			}
+
+			if !cmp.Equal(events.Published, tt.wantEvents) {
+				t.Error(cmp.Diff(events.Published, tt.wantEvents))
 			}
 		})
 	}
 }
 
-func TestController_getColor(t *testing.T) {
+func TestController_getNumber(t *testing.T) {
 	tests := []struct {
 		name    string
-		Logger  *logrus.Logger
-		Storage StorageProvider
+		model   round.Round
+		random  RandomProvider
+		want    int
+		wantErr error
+	}{
+		{
+			name:    "expect 36 given last pocket of European wheel",
+			model:   round.Round{Wheel: table.WheelEuropean},
+			random:  rng.NewScripted(36),
+			want:    36,
+			wantErr: nil,
+		},
+		{
+			name:    "expect fail given 00 on European wheel",
+			model:   round.Round{Wheel: table.WheelEuropean},
+			random:  rng.NewScripted(round.DoubleZero),
+			want:    0,
+			wantErr: rng.ErrOutcome,
+		},
+		{
+			name:    "expect 00 given last pocket of American wheel",
+			model:   round.Round{Wheel: table.WheelAmerican},
+			random:  rng.NewScripted(round.DoubleZero),
+			want:    round.DoubleZero,
+			wantErr: nil,
+		},
+		{
+			name: "expect outcome derived from the seeds given provably fair round",
+			model: round.Round{
+				Wheel:        table.WheelAmerican,
+				ProvablyFair: true,
+				ServerSeed:   "server",
+				ClientSeed:   "client",
+			},
+			random:  rng.NewScripted(),
+			want:    11,
+			wantErr: nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := New(logrus.New(), mockStorage{}, mockTables{}, mockRounds{}, mockWallets{}, mockLedger{}, mockTransaction{}, tt.random, &mockEvents{})
+
+			got, err := c.getNumber(tt.model)
+			if !cmp.Equal(err, tt.wantErr, cmpopts.EquateErrors()) {
+				t.Error(cmp.Diff(err, tt.wantErr, cmpopts.EquateErrors()))
+			}
+
+			if !cmp.Equal(got, tt.want) {
+				t.Error(cmp.Diff(got, tt.want))
+			}
+		})
+	}
+}
+
+func TestController_winners(t *testing.T) {
+	bets := []Bet{
+		{ID: "b0a1d3b6-5b52-4f0b-9d3a-9a7f2a3f0001", Bet: "17", Type: TypeStraight, Amount: 10, Currency: "GBP"},
+		{ID: "b0a1d3b6-5b52-4f0b-9d3a-9a7f2a3f0002", Bet: "17-20", Type: TypeSplit, Amount: 10, Currency: "GBP"},
+		{ID: "b0a1d3b6-5b52-4f0b-9d3a-9a7f2a3f0003", Bet: "black", Type: TypeRedBlack, Amount: 10, Currency: "GBP"},
+		{ID: "b0a1d3b6-5b52-4f0b-9d3a-9a7f2a3f0004", Bet: "1st", Type: TypeDozen, Amount: 10, Currency: "GBP"},
+		{ID: "b0a1d3b6-5b52-4f0b-9d3a-9a7f2a3f0005", Bet: "0-1-2-3", Type: TypeBasket, Amount: 10, Currency: "GBP"},
+		{ID: "b0a1d3b6-5b52-4f0b-9d3a-9a7f2a3f0006", Bet: "even", Type: TypeOddEven, Amount: 10, Currency: "GBP"},
+		{ID: "b0a1d3b6-5b52-4f0b-9d3a-9a7f2a3f0007", Bet: "00", Type: TypeStraight, Amount: 10, Currency: "GBP"},
+		{ID: "b0a1d3b6-5b52-4f0b-9d3a-9a7f2a3f0008", Bet: "0-00-1-2-3", Type: TypeFiveNumber, Amount: 10, Currency: "GBP"},
+	}
+
+	european := bets[:6]
+	american := append(bets[:4:4], bets[5:]...)
+
+	tests := []struct {
+		name    string
+		wheel   string
+		bets    []Bet
 		number  int
-		want    string
+		want    []string
+		wantErr error
 	}{
 		{
-			name:    "expect green given 0",
-			Logger:  logrus.New(),
-			Storage: mockStorage{},
-			number:  0,
-			want:    "green",
+			name:   "expect basket only given 0",
+			wheel:  table.WheelEuropean,
+			bets:   european,
+			number: 0,
+			want:   []string{"b0a1d3b6-5b52-4f0b-9d3a-9a7f2a3f0005"},
 		},
 		{
-			name:    "expect black given 2",
-			Logger:  logrus.New(),
-			Storage: mockStorage{},
-			number:  2,
-			want:    "black",
+			name:   "expect every covering bet given 17",
+			wheel:  table.WheelEuropean,
+			bets:   european,
+			number: 17,
+			want: []string{
+				"b0a1d3b6-5b52-4f0b-9d3a-9a7f2a3f0001",
+				"b0a1d3b6-5b52-4f0b-9d3a-9a7f2a3f0002",
+				"b0a1d3b6-5b52-4f0b-9d3a-9a7f2a3f0003",
+			},
 		},
 		{
-			name:    "expect red given 3",
-			Logger:  logrus.New(),
-			Storage: mockStorage{},
-			number:  3,
-			want:    "red",
+			name:   "expect dozen, basket and even given 2",
+			wheel:  table.WheelEuropean,
+			bets:   european,
+			number: 2,
+			want: []string{
+				"b0a1d3b6-5b52-4f0b-9d3a-9a7f2a3f0003",
+				"b0a1d3b6-5b52-4f0b-9d3a-9a7f2a3f0004",
+				"b0a1d3b6-5b52-4f0b-9d3a-9a7f2a3f0005",
+				"b0a1d3b6-5b52-4f0b-9d3a-9a7f2a3f0006",
+			},
 		},
 		{
-			name:    "expect black given 13",
-			Logger:  logrus.New(),
-			Storage: mockStorage{},
-			number:  13,
-			want:    "black",
+			name:   "expect straight and five-number given 00 on American wheel",
+			wheel:  table.WheelAmerican,
+			bets:   american,
+			number: round.DoubleZero,
+			want: []string{
+				"b0a1d3b6-5b52-4f0b-9d3a-9a7f2a3f0007",
+				"b0a1d3b6-5b52-4f0b-9d3a-9a7f2a3f0008",
+			},
 		},
 		{
-			name:    "expect red given 14",
-			Logger:  logrus.New(),
-			Storage: mockStorage{},
-			number:  14,
-			want:    "red",
+			name:   "expect five-number in place of basket given 2 on American wheel",
+			wheel:  table.WheelAmerican,
+			bets:   american,
+			number: 2,
+			want: []string{
+				"b0a1d3b6-5b52-4f0b-9d3a-9a7f2a3f0003",
+				"b0a1d3b6-5b52-4f0b-9d3a-9a7f2a3f0004",
+				"b0a1d3b6-5b52-4f0b-9d3a-9a7f2a3f0006",
+				"b0a1d3b6-5b52-4f0b-9d3a-9a7f2a3f0008",
+			},
+		},
+		{
+			name:    "expect ErrSettle given 00 bet on European wheel",
+			wheel:   table.WheelEuropean,
+			bets:    bets,
+			number:  17,
+			want:    []string{},
+			wantErr: ErrSettle,
 		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			c := New(tt.Logger, tt.Storage)
+			c := New(logrus.New(), mockStorage{}, mockTables{}, mockRounds{}, mockWallets{}, mockLedger{}, mockTransaction{}, mockRandom{}, &mockEvents{})
+
+			winners, err := c.winners(table.Table{Wheel: tt.wheel}, tt.bets, tt.number)
+			if !cmp.Equal(err, tt.wantErr, cmpopts.EquateErrors()) {
+				t.Fatal(cmp.Diff(err, tt.wantErr, cmpopts.EquateErrors()))
+			}
 
-			got := c.getColor(tt.number)
+			got := make([]string, len(winners))
+			for i := range winners {
+				got[i] = winners[i].BetID
+			}
 
 			if !cmp.Equal(got, tt.want) {
 				t.Error(cmp.Diff(got, tt.want))
@@ -379,10 +1782,16 @@ func TestController_getColor(t *testing.T) {
 
 type mockStorage struct {
 	GivenList  []Bet
+	GivenNext  string
+	GivenBet   Bet
 	GivenID    string
 	GivenError error
 }
 
+func (m mockStorage) Get(_ context.Context, _, _ string) (Bet, error) {
+	return m.GivenBet, m.GivenError
+}
+
 func (m mockStorage) Delete(_ context.Context, _, _ string) (string, error) {
 	return m.GivenID, m.GivenError
 }
@@ -391,10 +1800,216 @@ func (m mockStorage) Update(_ context.Context, _ Bet) (string, error) {
 	return m.GivenID, m.GivenError
 }
 
-func (m mockStorage) List(_ context.Context, _ string, _ ...Bet) ([]Bet, error) {
-	return m.GivenList, m.GivenError
+func (m mockStorage) List(_ context.Context, _ string, _ Filter) ([]Bet, string, error) {
+	return m.GivenList, m.GivenNext, m.GivenError
 }
 
 func (m mockStorage) Create(_ context.Context, _ Bet) (string, error) {
 	return m.GivenID, m.GivenError
 }
+
+// mockPages is a mockStorage that lists the given pages of bets one after another.
+type mockPages struct {
+	mockStorage
+	GivenPages [][]Bet
+}
+
+func (m mockPages) List(_ context.Context, _ string, filter Filter) ([]Bet, string, error) {
+	i, _ := strconv.Atoi(filter.Cursor)
+
+	next := ""
+	if i+1 < len(m.GivenPages) {
+		next = strconv.Itoa(i + 1)
+	}
+
+	return m.GivenPages[i], next, m.GivenError
+}
+
+type mockTables struct {
+	GivenTable       table.Table
+	GivenError       error
+	GivenDeleteError error
+}
+
+func (m mockTables) Get(_ context.Context, _ string) (table.Table, error) {
+	return m.GivenTable, m.GivenError
+}
+
+func (m mockTables) Delete(_ context.Context, id string) (string, error) {
+	return id, m.GivenDeleteError
+}
+
+var gbpTable = table.Table{
+	ID:         "8117bb87-148c-4fb1-8971-a2d4373b3f19",
+	MinimumBet: 10,
+	MaximumBet: 1000,
+	Currency:   "GBP",
+	Wheel:      table.WheelEuropean,
+}
+
+var limitedTable = table.Table{
+	ID:                 "8117bb87-148c-4fb1-8971-a2d4373b3f19",
+	MinimumBet:         10,
+	MaximumBet:         1000,
+	Currency:           "GBP",
+	MaximumPlayerStake: 500,
+	MaximumExposure:    10000,
+	Wheel:              table.WheelEuropean,
+}
+
+var fairTable = table.Table{
+	ID:           "8117bb87-148c-4fb1-8971-a2d4373b3f19",
+	MinimumBet:   10,
+	MaximumBet:   1000,
+	Currency:     "GBP",
+	Wheel:        table.WheelEuropean,
+	ProvablyFair: true,
+}
+
+var seededRound = round.Round{
+	ID:             "9b1c4d7e-0f2a-4b3c-8d5e-6f7a8b9c0d1e",
+	TableID:        "8117bb87-148c-4fb1-8971-a2d4373b3f19",
+	State:          round.StateOpen,
+	ServerSeed:     "server",
+	ServerSeedHash: fair.Hash("server"),
+	ProvablyFair:   true,
+}
+
+var openRound = round.Round{
+	ID:      "3c9a5e1e-2b5c-4c5f-a0d5-7f9e4b1c2d3e",
+	TableID: "8117bb87-148c-4fb1-8971-a2d4373b3f19",
+	State:   round.StateOpen,
+}
+
+var spunRound = round.Round{
+	ID:      "5d2f7c44-6e0b-4a4e-9b8e-2f6c1a7d9e10",
+	TableID: "8117bb87-148c-4fb1-8971-a2d4373b3f19",
+	State:   round.StateSpun,
+	Number:  10,
+	Color:   "black",
+}
+
+var zeroRound = round.Round{
+	ID:      "7a3e9b21-4c6d-4f8e-a1b2-3c4d5e6f7a8b",
+	TableID: "8117bb87-148c-4fb1-8971-a2d4373b3f19",
+	State:   round.StateSpun,
+	Number:  0,
+	Color:   "green",
+}
+
+type mockRounds struct {
+	GivenRound     round.Round
+	GivenError     error
+	GivenFindError error
+}
+
+func (m mockRounds) Open(_ context.Context, _ string) (round.Round, error) {
+	return m.GivenRound, m.GivenError
+}
+
+func (m mockRounds) Current(_ context.Context, _ string) (round.Round, error) {
+	return m.GivenRound, m.GivenError
+}
+
+func (m mockRounds) Find(_ context.Context, _ string) (round.Round, error) {
+	return m.GivenRound, m.GivenFindError
+}
+
+func (m mockRounds) Close(_ context.Context, model round.Round) (round.Round, error) {
+	model.State = round.StateClosed
+
+	return model, m.GivenError
+}
+
+func (m mockRounds) Spin(_ context.Context, model round.Round, number int, color string) (round.Round, error) {
+	model.State = round.StateSpun
+	model.Number = number
+	model.Color = color
+
+	return model, m.GivenError
+}
+
+func (m mockRounds) Settle(_ context.Context, model round.Round) (round.Round, error) {
+	model.State = round.StateSettled
+
+	return model, m.GivenError
+}
+
+type mockWallets struct {
+	GivenError error
+}
+
+func (m mockWallets) Reserve(_ context.Context, _, _ string, _ int64) error {
+	return m.GivenError
+}
+
+func (m mockWallets) Release(_ context.Context, _, _ string, _ int64) error {
+	return m.GivenError
+}
+
+func (m mockWallets) Settle(_ context.Context, _, _ string, _, _ int64) error {
+	return m.GivenError
+}
+
+type mockLedger struct {
+	GivenError error
+}
+
+func (m mockLedger) Record(_ context.Context, _ ...ledger.Entry) error {
+	return m.GivenError
+}
+
+type mockTransaction struct {
+	Busy  bool
+	Locks *[]string
+}
+
+func (m mockTransaction) Transaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	return fn(ctx)
+}
+
+func (m mockTransaction) Lock(_ context.Context, key string) error {
+	m.record("lock " + key)
+
+	return nil
+}
+
+func (m mockTransaction) LockShared(_ context.Context, key string) error {
+	m.record("share " + key)
+
+	return nil
+}
+
+func (m mockTransaction) TryLock(_ context.Context, key string) (bool, error) {
+	m.record("try " + key)
+
+	return !m.Busy, nil
+}
+
+func (m mockTransaction) record(lock string) {
+	if m.Locks != nil {
+		*m.Locks = append(*m.Locks, lock)
+	}
+}
+
+type mockEvents struct {
+	Published []string
+	Removed   []string
+}
+
+func (m *mockEvents) Publish(e event.Event) {
+	m.Published = append(m.Published, e.Type)
+}
+
+func (m *mockEvents) Remove(tableID string) {
+	m.Removed = append(m.Removed, tableID)
+}
+
+type mockRandom struct {
+	GivenNumber int
+	GivenError  error
+}
+
+func (m mockRandom) Intn(_ int) (int, error) {
+	return m.GivenNumber, m.GivenError
+}
@@ -0,0 +1,328 @@
+package bet
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/clarke94/roulette-service/internal/pkg/round"
+	"github.com/clarke94/roulette-service/internal/pkg/table"
+)
+
+const (
+	// pocketCount is the number of pockets on a single zero wheel.
+	pocketCount = 37
+	// rowCount is the number of rows of three numbers on the betting layout.
+	rowCount = 12
+	// rowSize is the amount of numbers in a single row of the betting layout.
+	rowSize = 3
+	// dozenSize is the amount of numbers covered by a dozen bet.
+	dozenSize = 12
+	// halfSize is the amount of numbers covered by a high/low bet.
+	halfSize = 18
+	// selectionSeparator separates the numbers of an inside bet selection, e.g. "17-20".
+	selectionSeparator = "-"
+)
+
+// selection is a single valid bet on the layout and the numbers it covers.
+type selection struct {
+	Bet     string
+	Numbers []int
+}
+
+// covers reports whether the selection wins when the given number is spun.
+func (s selection) covers(number int) bool {
+	for _, n := range s.Numbers {
+		if n == number {
+			return true
+		}
+	}
+
+	return false
+}
+
+// catalogues is every valid selection for each supported Bet type on each supported wheel.
+var catalogues = map[string]map[string][]selection{
+	table.WheelEuropean: newCatalogue(table.WheelEuropean),
+	table.WheelAmerican: newCatalogue(table.WheelAmerican),
+}
+
+// newCatalogue returns every valid selection for the given wheel.
+// The numbers 1 to 36 are laid out the same on both wheels, only the bets that include a zero differ.
+func newCatalogue(wheel string) map[string][]selection {
+	c := map[string][]selection{
+		TypeRedBlack: colorSelections(),
+		TypeOddEven:  oddEvenSelections(),
+		TypeHighLow:  highLowSelections(),
+		TypeDozen:    dozenSelections(),
+		TypeColumn:   columnSelections(),
+		TypeStraight: straightSelections(wheel),
+		TypeCorner:   cornerSelections(),
+		TypeSixLine:  sixLineSelections(),
+	}
+
+	if wheel == table.WheelAmerican {
+		c[TypeSplit] = splitSelections(
+			newSelection(0, round.DoubleZero),
+			newSelection(0, 1),
+			newSelection(0, 2),
+			newSelection(round.DoubleZero, 2),
+			newSelection(round.DoubleZero, 3),
+		)
+		c[TypeStreet] = streetSelections(
+			newSelection(0, 1, 2),
+			newSelection(0, round.DoubleZero, 2),
+			newSelection(round.DoubleZero, 2, 3),
+		)
+		c[TypeFiveNumber] = []selection{newSelection(0, round.DoubleZero, 1, 2, 3)}
+
+		return c
+	}
+
+	c[TypeSplit] = splitSelections(
+		newSelection(0, 1),
+		newSelection(0, 2),
+		newSelection(0, 3),
+	)
+	c[TypeStreet] = streetSelections(
+		newSelection(0, 1, 2),
+		newSelection(0, 2, 3),
+	)
+	c[TypeBasket] = []selection{newSelection(0, 1, 2, 3)}
+
+	return c
+}
+
+// newSelection creates a selection for the given numbers in its canonical form, with 00 following 0.
+func newSelection(numbers ...int) selection {
+	sorted := append([]int(nil), numbers...)
+	sort.Slice(sorted, func(i, j int) bool {
+		return layoutOrder(sorted[i]) < layoutOrder(sorted[j])
+	})
+
+	parts := make([]string, len(sorted))
+	for i, n := range sorted {
+		parts[i] = round.Pocket(n)
+	}
+
+	return selection{
+		Bet:     strings.Join(parts, selectionSeparator),
+		Numbers: sorted,
+	}
+}
+
+func colorSelections() []selection {
+	red := selection{Bet: ColorRed}
+	black := selection{Bet: ColorBlack}
+
+	for n := 1; n < pocketCount; n++ {
+		if Color(n) == ColorRed {
+			red.Numbers = append(red.Numbers, n)
+
+			continue
+		}
+
+		black.Numbers = append(black.Numbers, n)
+	}
+
+	return []selection{red, black}
+}
+
+func oddEvenSelections() []selection {
+	odd := selection{Bet: "odd"}
+	even := selection{Bet: "even"}
+
+	for n := 1; n < pocketCount; n++ {
+		if n%2 == 0 {
+			even.Numbers = append(even.Numbers, n)
+
+			continue
+		}
+
+		odd.Numbers = append(odd.Numbers, n)
+	}
+
+	return []selection{odd, even}
+}
+
+func highLowSelections() []selection {
+	low := selection{Bet: "1-18"}
+	high := selection{Bet: "19-36"}
+
+	for n := 1; n < pocketCount; n++ {
+		if n <= halfSize {
+			low.Numbers = append(low.Numbers, n)
+
+			continue
+		}
+
+		high.Numbers = append(high.Numbers, n)
+	}
+
+	return []selection{low, high}
+}
+
+func dozenSelections() []selection {
+	selections := []selection{{Bet: "1st"}, {Bet: "2nd"}, {Bet: "3rd"}}
+
+	for n := 1; n < pocketCount; n++ {
+		i := (n - 1) / dozenSize
+		selections[i].Numbers = append(selections[i].Numbers, n)
+	}
+
+	return selections
+}
+
+func columnSelections() []selection {
+	selections := []selection{{Bet: "1st"}, {Bet: "2nd"}, {Bet: "3rd"}}
+
+	for n := 1; n < pocketCount; n++ {
+		i := (n - 1) % rowSize
+		selections[i].Numbers = append(selections[i].Numbers, n)
+	}
+
+	return selections
+}
+
+func straightSelections(wheel string) []selection {
+	selections := make([]selection, table.Pockets(wheel))
+
+	for n := range selections {
+		selections[n] = newSelection(n)
+	}
+
+	return selections
+}
+
+// splitSelections returns the given splits that include a zero followed by every split between the numbers 1 to 36.
+func splitSelections(zeros ...selection) []selection {
+	selections := zeros
+
+	for n := 1; n < pocketCount; n++ {
+		if n%rowSize != 0 {
+			selections = append(selections, newSelection(n, n+1))
+		}
+
+		if n+rowSize < pocketCount {
+			selections = append(selections, newSelection(n, n+rowSize))
+		}
+	}
+
+	return selections
+}
+
+// streetSelections returns the given trios that include a zero followed by every row of the numbers 1 to 36.
+func streetSelections(zeros ...selection) []selection {
+	selections := zeros
+
+	for row := 0; row < rowCount; row++ {
+		first := row*rowSize + 1
+		selections = append(selections, newSelection(first, first+1, first+2))
+	}
+
+	return selections
+}
+
+func cornerSelections() []selection {
+	var selections []selection
+
+	for n := 1; n+rowSize+1 < pocketCount; n++ {
+		if n%rowSize == 0 {
+			continue
+		}
+
+		selections = append(selections, newSelection(n, n+1, n+rowSize, n+rowSize+1))
+	}
+
+	return selections
+}
+
+func sixLineSelections() []selection {
+	var selections []selection
+
+	for row := 0; row < rowCount-1; row++ {
+		first := row*rowSize + 1
+		selections = append(selections, newSelection(first, first+1, first+2, first+3, first+4, first+5))
+	}
+
+	return selections
+}
+
+// findSelection returns the catalogue selection of the wheel for the given Bet type and bet, accepting numbers in any
+// order.
+func findSelection(wheel, betType, bet string) (selection, bool) {
+	selections, ok := catalogues[wheel][betType]
+	if !ok {
+		return selection{}, false
+	}
+
+	canonical := canonicalBet(bet)
+	for _, s := range selections {
+		if s.Bet == canonical {
+			return s, true
+		}
+	}
+
+	return selection{}, false
+}
+
+// canonicalBet orders the numbers of an inside bet selection, leaving any other bet untouched.
+func canonicalBet(bet string) string {
+	parts := strings.Split(strings.TrimSpace(bet), selectionSeparator)
+	numbers := make([]int, len(parts))
+
+	for i, part := range parts {
+		n, ok := parsePocket(strings.TrimSpace(part))
+		if !ok {
+			return bet
+		}
+
+		numbers[i] = n
+	}
+
+	return newSelection(numbers...).Bet
+}
+
+// parsePocket returns the number for the name of a pocket, where "00" is the double zero of an American wheel.
+func parsePocket(pocket string) (int, bool) {
+	if pocket == round.Pocket(round.DoubleZero) {
+		return round.DoubleZero, true
+	}
+
+	n, err := strconv.Atoi(pocket)
+	if err != nil {
+		return 0, false
+	}
+
+	return n, true
+}
+
+// layoutOrder ranks a number by its position on the layout, placing 00 between 0 and 1.
+func layoutOrder(number int) int {
+	if number == round.DoubleZero {
+		return 1
+	}
+
+	return number * 2
+}
+
+// Color returns the color of the pocket for the given number, 37 being the 00 pocket of an American wheel.
+func Color(number int) string {
+	if isZero(number) {
+		return ColorGreen
+	}
+
+	if number >= 1 && number <= 10 || number >= 19 && number <= 28 {
+		if number%2 == 0 {
+			return ColorBlack
+		}
+
+		return ColorRed
+	}
+
+	if number%2 == 0 {
+		return ColorRed
+	}
+
+	return ColorBlack
+}
@@ -0,0 +1,292 @@
+package bet
+
+import (
+	"testing"
+
+	"github.com/clarke94/roulette-service/internal/pkg/round"
+	"github.com/clarke94/roulette-service/internal/pkg/table"
+	"github.com/google/go-cmp/cmp"
+)
+
+func Test_newCatalogue(t *testing.T) {
+	tests := []struct {
+		name    string
+		wheel   string
+		betType string
+		want    int
+	}{
+		{
+			name:    "expect 2 red/black selections",
+			wheel:   table.WheelEuropean,
+			betType: TypeRedBlack,
+			want:    2,
+		},
+		{
+			name:    "expect 2 odd/even selections",
+			wheel:   table.WheelEuropean,
+			betType: TypeOddEven,
+			want:    2,
+		},
+		{
+			name:    "expect 2 high/low selections",
+			wheel:   table.WheelEuropean,
+			betType: TypeHighLow,
+			want:    2,
+		},
+		{
+			name:    "expect 3 dozen selections",
+			wheel:   table.WheelEuropean,
+			betType: TypeDozen,
+			want:    3,
+		},
+		{
+			name:    "expect 3 column selections",
+			wheel:   table.WheelEuropean,
+			betType: TypeColumn,
+			want:    3,
+		},
+		{
+			name:    "expect 37 straight selections",
+			wheel:   table.WheelEuropean,
+			betType: TypeStraight,
+			want:    37,
+		},
+		{
+			name:    "expect 60 split selections",
+			wheel:   table.WheelEuropean,
+			betType: TypeSplit,
+			want:    60,
+		},
+		{
+			name:    "expect 14 street selections",
+			wheel:   table.WheelEuropean,
+			betType: TypeStreet,
+			want:    14,
+		},
+		{
+			name:    "expect 22 corner selections",
+			wheel:   table.WheelEuropean,
+			betType: TypeCorner,
+			want:    22,
+		},
+		{
+			name:    "expect 11 six-line selections",
+			wheel:   table.WheelEuropean,
+			betType: TypeSixLine,
+			want:    11,
+		},
+		{
+			name:    "expect 1 basket selection",
+			wheel:   table.WheelEuropean,
+			betType: TypeBasket,
+			want:    1,
+		},
+		{
+			name:    "expect 38 straight selections on American wheel",
+			wheel:   table.WheelAmerican,
+			betType: TypeStraight,
+			want:    38,
+		},
+		{
+			name:    "expect 62 split selections on American wheel",
+			wheel:   table.WheelAmerican,
+			betType: TypeSplit,
+			want:    62,
+		},
+		{
+			name:    "expect 15 street selections on American wheel",
+			wheel:   table.WheelAmerican,
+			betType: TypeStreet,
+			want:    15,
+		},
+		{
+			name:    "expect no basket selection on American wheel",
+			wheel:   table.WheelAmerican,
+			betType: TypeBasket,
+			want:    0,
+		},
+		{
+			name:    "expect 1 five-number selection on American wheel",
+			wheel:   table.WheelAmerican,
+			betType: TypeFiveNumber,
+			want:    1,
+		},
+		{
+			name:    "expect no five-number selection on European wheel",
+			wheel:   table.WheelEuropean,
+			betType: TypeFiveNumber,
+			want:    0,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := len(newCatalogue(tt.wheel)[tt.betType])
+
+			if !cmp.Equal(got, tt.want) {
+				t.Error(cmp.Diff(got, tt.want))
+			}
+		})
+	}
+}
+
+func Test_findSelection(t *testing.T) {
+	tests := []struct {
+		name    string
+		wheel   string
+		betType string
+		bet     string
+		want    selection
+		wantOk  bool
+	}{
+		{
+			name:    "expect selection given vertical split",
+			wheel:   table.WheelEuropean,
+			betType: TypeSplit,
+			bet:     "20-17",
+			want:    selection{Bet: "17-20", Numbers: []int{17, 20}},
+			wantOk:  true,
+		},
+		{
+			name:    "expect no selection given split across rows",
+			wheel:   table.WheelEuropean,
+			betType: TypeSplit,
+			bet:     "3-4",
+			wantOk:  false,
+		},
+		{
+			name:    "expect selection given six-line",
+			wheel:   table.WheelEuropean,
+			betType: TypeSixLine,
+			bet:     "31-32-33-34-35-36",
+			want:    selection{Bet: "31-32-33-34-35-36", Numbers: []int{31, 32, 33, 34, 35, 36}},
+			wantOk:  true,
+		},
+		{
+			name:    "expect no selection given six-line off the layout",
+			wheel:   table.WheelEuropean,
+			betType: TypeSixLine,
+			bet:     "34-35-36-37-38-39",
+			wantOk:  false,
+		},
+		{
+			name:    "expect selection given basket",
+			wheel:   table.WheelEuropean,
+			betType: TypeBasket,
+			bet:     "3-2-1-0",
+			want:    selection{Bet: "0-1-2-3", Numbers: []int{0, 1, 2, 3}},
+			wantOk:  true,
+		},
+		{
+			name:    "expect selection given 3rd column",
+			wheel:   table.WheelEuropean,
+			betType: TypeColumn,
+			bet:     "3rd",
+			want: selection{
+				Bet:     "3rd",
+				Numbers: []int{3, 6, 9, 12, 15, 18, 21, 24, 27, 30, 33, 36},
+			},
+			wantOk: true,
+		},
+		{
+			name:    "expect selection given 2nd dozen",
+			wheel:   table.WheelEuropean,
+			betType: TypeDozen,
+			bet:     "2nd",
+			want: selection{
+				Bet:     "2nd",
+				Numbers: []int{13, 14, 15, 16, 17, 18, 19, 20, 21, 22, 23, 24},
+			},
+			wantOk: true,
+		},
+		{
+			name:    "expect no selection given unknown odd/even",
+			wheel:   table.WheelEuropean,
+			betType: TypeOddEven,
+			bet:     "zero",
+			wantOk:  false,
+		},
+		{
+			name:    "expect selection given split of both zeros on American wheel",
+			wheel:   table.WheelAmerican,
+			betType: TypeSplit,
+			bet:     "00-0",
+			want:    selection{Bet: "0-00", Numbers: []int{0, round.DoubleZero}},
+			wantOk:  true,
+		},
+		{
+			name:    "expect no selection given double zero on European wheel",
+			wheel:   table.WheelEuropean,
+			betType: TypeStraight,
+			bet:     "00",
+			wantOk:  false,
+		},
+		{
+			name:    "expect no selection given unknown type",
+			wheel:   table.WheelEuropean,
+			betType: "foo",
+			bet:     "1",
+			wantOk:  false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := findSelection(tt.wheel, tt.betType, tt.bet)
+
+			if !cmp.Equal(ok, tt.wantOk) {
+				t.Fatal(cmp.Diff(ok, tt.wantOk))
+			}
+
+			if !cmp.Equal(got, tt.want) {
+				t.Error(cmp.Diff(got, tt.want))
+			}
+		})
+	}
+}
+
+func TestColor(t *testing.T) {
+	tests := []struct {
+		name   string
+		number int
+		want   string
+	}{
+		{
+			name:   "expect green given 0",
+			number: 0,
+			want:   ColorGreen,
+		},
+		{
+			name:   "expect green given 00",
+			number: round.DoubleZero,
+			want:   ColorGreen,
+		},
+		{
+			name:   "expect black given 2",
+			number: 2,
+			want:   ColorBlack,
+		},
+		{
+			name:   "expect red given 3",
+			number: 3,
+			want:   ColorRed,
+		},
+		{
+			name:   "expect black given 13",
+			number: 13,
+			want:   ColorBlack,
+		},
+		{
+			name:   "expect red given 14",
+			number: 14,
+			want:   ColorRed,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := Color(tt.number)
+
+			if !cmp.Equal(got, tt.want) {
+				t.Error(cmp.Diff(got, tt.want))
+			}
+		})
+	}
+}
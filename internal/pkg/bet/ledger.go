@@ -0,0 +1,46 @@
+package bet
+
+import (
+	"github.com/clarke94/roulette-service/internal/pkg/ledger"
+)
+
+// reserved returns the ledger entry of the stake of the Bet being held against the available balance of its player.
+func reserved(b Bet) ledger.Entry {
+	return entry(b, ledger.TypeStakeReserved, ledger.AccountReserved, ledger.AccountAvailable, b.Amount)
+}
+
+// refunded returns the ledger entry of the stake of the Bet being returned to the available balance of its player.
+func refunded(b Bet) ledger.Entry {
+	return entry(b, ledger.TypeStakeRefunded, ledger.AccountAvailable, ledger.AccountReserved, b.Amount)
+}
+
+// settlement returns the ledger entries that settle the stake of the Bet given the return paid back to its player.
+// A return of at least the stake gives back the stake and pays the rest as winnings, a smaller return gives back that
+// much of the stake and the house takes the rest.
+func settlement(b Bet, payout int64) []ledger.Entry {
+	if payout >= b.Amount {
+		return []ledger.Entry{
+			entry(b, ledger.TypeStakeReturned, ledger.AccountAvailable, ledger.AccountReserved, b.Amount),
+			entry(b, ledger.TypeWinningsPaid, ledger.AccountAvailable, ledger.AccountHouse, payout-b.Amount),
+		}
+	}
+
+	return []ledger.Entry{
+		entry(b, ledger.TypeStakeReturned, ledger.AccountAvailable, ledger.AccountReserved, payout),
+		entry(b, ledger.TypeStakeLost, ledger.AccountHouse, ledger.AccountReserved, b.Amount-payout),
+	}
+}
+
+func entry(b Bet, entryType, debit, credit string, amount int64) ledger.Entry {
+	return ledger.Entry{
+		Type:          entryType,
+		BetID:         b.ID,
+		RoundID:       b.RoundID,
+		TableID:       b.TableID,
+		PlayerID:      b.PlayerID,
+		Currency:      b.Currency,
+		DebitAccount:  debit,
+		CreditAccount: credit,
+		Amount:        amount,
+	}
+}
@@ -0,0 +1,100 @@
+package bet
+
+import (
+	"testing"
+
+	"github.com/clarke94/roulette-service/internal/pkg/ledger"
+	"github.com/google/go-cmp/cmp"
+)
+
+func Test_settlement(t *testing.T) {
+	b := Bet{ID: "a", RoundID: "b", TableID: "c", PlayerID: "d", Currency: "GBP", Amount: 100}
+
+	tests := []struct {
+		name   string
+		payout int64
+		want   []ledger.Entry
+	}{
+		{
+			name:   "expect stake lost given no return",
+			payout: 0,
+			want: []ledger.Entry{
+				entry(b, ledger.TypeStakeReturned, ledger.AccountAvailable, ledger.AccountReserved, 0),
+				entry(b, ledger.TypeStakeLost, ledger.AccountHouse, ledger.AccountReserved, 100),
+			},
+		},
+		{
+			name:   "expect part of stake lost given return below the stake",
+			payout: 50,
+			want: []ledger.Entry{
+				entry(b, ledger.TypeStakeReturned, ledger.AccountAvailable, ledger.AccountReserved, 50),
+				entry(b, ledger.TypeStakeLost, ledger.AccountHouse, ledger.AccountReserved, 50),
+			},
+		},
+		{
+			name:   "expect stake returned and winnings paid given return above the stake",
+			payout: 3600,
+			want: []ledger.Entry{
+				entry(b, ledger.TypeStakeReturned, ledger.AccountAvailable, ledger.AccountReserved, 100),
+				entry(b, ledger.TypeWinningsPaid, ledger.AccountAvailable, ledger.AccountHouse, 3500),
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := settlement(b, tt.payout)
+
+			if !cmp.Equal(got, tt.want) {
+				t.Error(cmp.Diff(got, tt.want))
+			}
+		})
+	}
+}
+
+func Test_entry(t *testing.T) {
+	b := Bet{ID: "a", RoundID: "b", TableID: "c", PlayerID: "d", Currency: "GBP", Amount: 100}
+
+	tests := []struct {
+		name string
+		got  ledger.Entry
+		want ledger.Entry
+	}{
+		{
+			name: "expect stake moved from available to reserved given reserved",
+			got:  reserved(b),
+			want: ledger.Entry{
+				Type:          ledger.TypeStakeReserved,
+				BetID:         "a",
+				RoundID:       "b",
+				TableID:       "c",
+				PlayerID:      "d",
+				Currency:      "GBP",
+				DebitAccount:  ledger.AccountReserved,
+				CreditAccount: ledger.AccountAvailable,
+				Amount:        100,
+			},
+		},
+		{
+			name: "expect stake moved from reserved to available given refunded",
+			got:  refunded(b),
+			want: ledger.Entry{
+				Type:          ledger.TypeStakeRefunded,
+				BetID:         "a",
+				RoundID:       "b",
+				TableID:       "c",
+				PlayerID:      "d",
+				Currency:      "GBP",
+				DebitAccount:  ledger.AccountAvailable,
+				CreditAccount: ledger.AccountReserved,
+				Amount:        100,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if !cmp.Equal(tt.got, tt.want) {
+				t.Error(cmp.Diff(tt.got, tt.want))
+			}
+		})
+	}
+}
@@ -0,0 +1,87 @@
+package bet
+
+import (
+	"fmt"
+
+	"github.com/clarke94/roulette-service/internal/pkg/table"
+)
+
+// checkTable ensures the Bet is placed in the currency of the table and within its minimum and maximum bet.
+func checkTable(t table.Table, model Bet) error {
+	if model.Currency != t.Currency {
+		return invalid(ErrCurrency, "currency", "must be "+t.Currency)
+	}
+
+	if model.Amount < int64(t.MinimumBet) || model.Amount > int64(t.MaximumBet) {
+		return invalid(ErrLimit, "amount", fmt.Sprintf("must be between %d and %d", t.MinimumBet, t.MaximumBet))
+	}
+
+	return nil
+}
+
+// checkRound ensures the Bet keeps the round within the stake limit per player and the exposure limit per number of
+// the table, given every other bet already placed on the round.
+func checkRound(t table.Table, bets []Bet, model Bet) error {
+	if t.MaximumPlayerStake > 0 && playerStake(bets, model) > int64(t.MaximumPlayerStake) {
+		return ErrPlayerStake
+	}
+
+	if t.MaximumExposure > 0 && maximumExposure(t.Wheel, bets, model) > int64(t.MaximumExposure) {
+		return ErrExposure
+	}
+
+	return nil
+}
+
+// playerStake returns the total the player of the Bet has staked on the round including the Bet.
+func playerStake(bets []Bet, model Bet) int64 {
+	stake := model.Amount
+
+	for i := range bets {
+		if bets[i].ID != model.ID && bets[i].PlayerID == model.PlayerID {
+			stake += bets[i].Amount
+		}
+	}
+
+	return stake
+}
+
+// maximumExposure returns the largest payout owed on any single number of the wheel covered by the Bet, including the
+// Bet.
+func maximumExposure(wheel string, bets []Bet, model Bet) int64 {
+	s, ok := findSelection(wheel, model.Type, model.Bet)
+	if !ok {
+		return 0
+	}
+
+	exposure := make(map[int]int64, len(s.Numbers))
+	for _, n := range s.Numbers {
+		exposure[n] = model.Amount * TypeMultiplierMap[model.Type]
+	}
+
+	for i := range bets {
+		if bets[i].ID == model.ID {
+			continue
+		}
+
+		other, ok := findSelection(wheel, bets[i].Type, bets[i].Bet)
+		if !ok {
+			continue
+		}
+
+		for _, n := range other.Numbers {
+			if _, ok := exposure[n]; ok {
+				exposure[n] += bets[i].Amount * TypeMultiplierMap[bets[i].Type]
+			}
+		}
+	}
+
+	var highest int64
+	for _, e := range exposure {
+		if e > highest {
+			highest = e
+		}
+	}
+
+	return highest
+}
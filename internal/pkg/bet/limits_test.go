@@ -0,0 +1,100 @@
+package bet
+
+import (
+	"testing"
+
+	"github.com/clarke94/roulette-service/internal/pkg/table"
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+)
+
+func Test_checkTable(t *testing.T) {
+	tests := []struct {
+		name    string
+		model   Bet
+		wantErr error
+	}{
+		{
+			name:    "expect success given bet within the table limits",
+			model:   Bet{Amount: 10, Currency: "GBP"},
+			wantErr: nil,
+		},
+		{
+			name:    "expect fail given another currency",
+			model:   Bet{Amount: 10, Currency: "EUR"},
+			wantErr: ErrCurrency,
+		},
+		{
+			name:    "expect fail given amount below the minimum",
+			model:   Bet{Amount: 9, Currency: "GBP"},
+			wantErr: ErrLimit,
+		},
+		{
+			name:    "expect fail given amount above the maximum",
+			model:   Bet{Amount: 1001, Currency: "GBP"},
+			wantErr: ErrLimit,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := checkTable(gbpTable, tt.model)
+			if !cmp.Equal(err, tt.wantErr, cmpopts.EquateErrors()) {
+				t.Error(cmp.Diff(err, tt.wantErr, cmpopts.EquateErrors()))
+			}
+		})
+	}
+}
+
+func Test_checkRound(t *testing.T) {
+	bets := []Bet{
+		{ID: "a", PlayerID: "foo", Bet: "17", Type: TypeStraight, Amount: 100},
+		{ID: "b", PlayerID: "foo", Bet: "red", Type: TypeRedBlack, Amount: 300},
+		{ID: "c", PlayerID: "bar", Bet: "16-17-19-20", Type: TypeCorner, Amount: 200},
+	}
+
+	tests := []struct {
+		name    string
+		table   table.Table
+		model   Bet
+		wantErr error
+	}{
+		{
+			name:    "expect success given table without round limits",
+			table:   gbpTable,
+			model:   Bet{PlayerID: "foo", Bet: "17", Type: TypeStraight, Amount: 1000},
+			wantErr: nil,
+		},
+		{
+			name:    "expect success given bet within the round limits",
+			table:   limitedTable,
+			model:   Bet{PlayerID: "foo", Bet: "1", Type: TypeStraight, Amount: 100},
+			wantErr: nil,
+		},
+		{
+			name:    "expect fail given player stake above the limit",
+			table:   limitedTable,
+			model:   Bet{PlayerID: "foo", Bet: "1", Type: TypeStraight, Amount: 101},
+			wantErr: ErrPlayerStake,
+		},
+		{
+			name:    "expect success given update that replaces the player's own stake",
+			table:   limitedTable,
+			model:   Bet{ID: "b", PlayerID: "foo", Bet: "black", Type: TypeRedBlack, Amount: 400},
+			wantErr: nil,
+		},
+		{
+			name:    "expect fail given exposure on a shared number above the limit",
+			table:   limitedTable,
+			model:   Bet{PlayerID: "baz", Bet: "17", Type: TypeStraight, Amount: 200},
+			wantErr: ErrExposure,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := checkRound(tt.table, bets, tt.model)
+			if !cmp.Equal(err, tt.wantErr, cmpopts.EquateErrors()) {
+				t.Error(cmp.Diff(err, tt.wantErr, cmpopts.EquateErrors()))
+			}
+		})
+	}
+}
@@ -1,57 +1,150 @@
 package bet
 
+import (
+	"time"
+
+	"github.com/clarke94/roulette-service/internal/pkg/page"
+	"github.com/clarke94/roulette-service/internal/pkg/round"
+)
+
 // Bet is a domain model.
+// Imprisoned is set on an even-money Bet held over to the next round by the en prison rule.
+// Version counts the changes made to the Bet, an update only applying to the Version it was based on.
+// ClientSeed is the optional seed the player mixes into the spin of a provably fair round.
 type Bet struct {
-	ID       string
-	TableID  string
-	Bet      string
-	Type     string
-	Amount   int64
-	Currency string
+	ID         string
+	TableID    string
+	RoundID    string
+	PlayerID   string
+	Bet        string
+	Type       string
+	Amount     int64
+	Currency   string
+	Imprisoned bool
+	ClientSeed string
+	Version    int64
+}
+
+// Filter narrows down and orders the bets returned from a list, Order sorting them by when they were placed.
+// MinAmount and MaxAmount bound the stake of each bet, a zero value for either leaving that end of the range open.
+type Filter struct {
+	page.Page
+	RoundID   string
+	PlayerID  string
+	Type      string
+	Currency  string
+	MinAmount int64
+	MaxAmount int64
+	From      time.Time
+	To        time.Time
+	Order     string
 }
 
 // Result is the round result from a game.
+// Imprisoned is the ID of every even-money bet held over to the next round by the en prison rule.
+// ServerSeed is revealed with the Result so a ProvablyFair spin can be recomputed from its seeds.
 type Result struct {
-	Number  int
-	Color   string
-	Winners []Winner
+	RoundID        string
+	Number         int
+	Pocket         string
+	Color          string
+	Winners        []Winner
+	Imprisoned     []string
+	ProvablyFair   bool
+	ServerSeed     string
+	ServerSeedHash string
+	ClientSeed     string
+	Nonce          int64
 }
 
-// Winner is a winning bet from a round.
+// Winner is a bet from a round that returned any of its stake, Outcome being why it was returned.
+// All amounts are in the smallest currency unit.
 type Winner struct {
-	BetID    string
-	Amount   int64
-	Currency string
+	BetID      string
+	Stake      int64
+	Multiplier int64
+	Winnings   int64
+	Return     int64
+	Currency   string
+	Outcome    string
 }
 
+// Outcome is the reason a Winner returned any of its stake.
+const (
+	// OutcomeWin pays the stake back along with the winnings of the Bet type.
+	OutcomeWin = "win"
+	// OutcomeLaPartage returns half the stake of an even-money bet when zero is spun.
+	OutcomeLaPartage = "la-partage"
+	// OutcomeEnPrison returns the stake of an imprisoned bet that wins the following round.
+	OutcomeEnPrison = "en-prison"
+)
+
+// Color is the color of a pocket on the wheel.
 const (
-	colorRed   = "red"
-	colorBlack = "black"
-	colorGreen = "green"
+	ColorRed   = "red"
+	ColorBlack = "black"
+	ColorGreen = "green"
 )
 
 // Type is the supported Bet type.
 var (
-	TypeRedBlack = "red/black"
-	TypeStraight = "straight"
+	TypeRedBlack   = "red/black"
+	TypeOddEven    = "odd/even"
+	TypeHighLow    = "high/low"
+	TypeDozen      = "dozen"
+	TypeColumn     = "column"
+	TypeStraight   = "straight"
+	TypeSplit      = "split"
+	TypeStreet     = "street"
+	TypeCorner     = "corner"
+	TypeSixLine    = "six-line"
+	TypeBasket     = "basket"
+	TypeFiveNumber = "five-number"
 )
 
 // TypeMultiplierMap is the Bet type that is available and the associated multiplier for that bet.
 var TypeMultiplierMap = map[string]int64{
 	// Outside bets
 	TypeRedBlack: 1,
+	TypeOddEven:  1,
+	TypeHighLow:  1,
+	TypeDozen:    2,
+	TypeColumn:   2,
 	// Inside bets
-	TypeStraight: 35,
+	TypeStraight:   35,
+	TypeSplit:      17,
+	TypeStreet:     11,
+	TypeCorner:     8,
+	TypeSixLine:    5,
+	TypeBasket:     8,
+	TypeFiveNumber: 6,
 }
 
 func betToWinner(b Bet) Winner {
+	multiplier := TypeMultiplierMap[b.Type]
+	winnings := b.Amount * multiplier
+
 	return Winner{
-		BetID:    b.ID,
-		Amount:   b.Amount,
-		Currency: b.Currency,
+		BetID:      b.ID,
+		Stake:      b.Amount,
+		Multiplier: multiplier,
+		Winnings:   winnings,
+		Return:     b.Amount + winnings,
+		Currency:   b.Currency,
+		Outcome:    OutcomeWin,
 	}
 }
 
+func betIDs(b []Bet) []string {
+	ids := make([]string, len(b))
+
+	for i := range b {
+		ids[i] = b[i].ID
+	}
+
+	return ids
+}
+
 func betListToWinner(b []Bet) []Winner {
 	winners := make([]Winner, len(b))
 
@@ -61,3 +154,26 @@ func betListToWinner(b []Bet) []Winner {
 
 	return winners
 }
+
+func winnerListToRound(w []Winner) []round.Winner {
+	winners := make([]round.Winner, len(w))
+
+	for i := range w {
+		winners[i] = round.Winner(w[i])
+	}
+
+	return winners
+}
+
+// totals returns the total staked on a round and the total paid back to the winners.
+func totals(bets []Bet, winners []Winner) (staked, paid int64) {
+	for i := range bets {
+		staked += bets[i].Amount
+	}
+
+	for i := range winners {
+		paid += winners[i].Return
+	}
+
+	return staked, paid
+}
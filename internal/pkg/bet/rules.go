@@ -0,0 +1,67 @@
+package bet
+
+import (
+	"github.com/clarke94/roulette-service/internal/pkg/round"
+	"github.com/clarke94/roulette-service/internal/pkg/table"
+)
+
+// settleBet returns what a Bet returns given the number spun under the rules of the table, reporting false when the
+// Bet loses its stake.
+// An imprisoned Bet only ever returns its stake, and loses it should zero be spun again.
+// A Bet that is not on the wheel of the table cannot be settled, rather than losing a stake it could never win.
+func settleBet(t table.Table, b Bet, number int) (Winner, bool, error) {
+	s, ok := findSelection(t.Wheel, b.Type, b.Bet)
+	if !ok {
+		return Winner{}, false, ErrSettle
+	}
+
+	switch {
+	case b.Imprisoned && s.covers(number):
+		return refund(b, b.Amount, OutcomeEnPrison), true, nil
+	case b.Imprisoned:
+		return Winner{}, false, nil
+	case s.covers(number):
+		return betToWinner(b), true, nil
+	case t.Rules == table.RulesLaPartage && isEvenMoney(b) && isZero(number):
+		return refund(b, b.Amount/2, OutcomeLaPartage), true, nil
+	}
+
+	return Winner{}, false, nil
+}
+
+// imprison splits the bets into those settled on the number spun and the even-money bets held over to the next round
+// under the en prison rule.
+func imprison(t table.Table, bets []Bet, number int) (settled, held []Bet) {
+	for i := range bets {
+		if t.Rules == table.RulesEnPrison && isZero(number) && isEvenMoney(bets[i]) && !bets[i].Imprisoned {
+			held = append(held, bets[i])
+
+			continue
+		}
+
+		settled = append(settled, bets[i])
+	}
+
+	return settled, held
+}
+
+// refund returns the given amount of the stake of a Bet without any winnings.
+func refund(b Bet, amount int64, outcome string) Winner {
+	return Winner{
+		BetID:    b.ID,
+		Stake:    b.Amount,
+		Return:   amount,
+		Currency: b.Currency,
+		Outcome:  outcome,
+	}
+}
+
+// isEvenMoney reports whether the Bet pays out at 1:1.
+func isEvenMoney(b Bet) bool {
+	return TypeMultiplierMap[b.Type] == 1
+}
+
+// isZero reports whether the number is the 0 or 00 pocket.
+func isZero(number int) bool {
+	return number == 0 || number == round.DoubleZero
+}
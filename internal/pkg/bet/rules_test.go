@@ -0,0 +1,170 @@
+package bet
+
+import (
+	"testing"
+
+	"github.com/clarke94/roulette-service/internal/pkg/round"
+	"github.com/clarke94/roulette-service/internal/pkg/table"
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+)
+
+func Test_settleBet(t *testing.T) {
+	red := Bet{ID: "a", Bet: "red", Type: TypeRedBlack, Amount: 101, Currency: "GBP"}
+
+	tests := []struct {
+		name    string
+		rules   string
+		wheel   string
+		model   Bet
+		number  int
+		want    Winner
+		wantOk  bool
+		wantErr error
+	}{
+		{
+			name:    "expect ErrSettle given bet not on the wheel",
+			rules:   table.RulesStandard,
+			wheel:   table.WheelEuropean,
+			model:   Bet{ID: "a", Bet: "00", Type: TypeStraight, Amount: 101, Currency: "GBP"},
+			number:  0,
+			wantOk:  false,
+			wantErr: ErrSettle,
+		},
+		{
+			name:   "expect loss given zero under standard rules",
+			rules:  table.RulesStandard,
+			wheel:  table.WheelEuropean,
+			model:  red,
+			number: 0,
+			wantOk: false,
+		},
+		{
+			name:   "expect half the stake rounded down given zero under la partage",
+			rules:  table.RulesLaPartage,
+			wheel:  table.WheelEuropean,
+			model:  red,
+			number: 0,
+			want:   Winner{BetID: "a", Stake: 101, Return: 50, Currency: "GBP", Outcome: OutcomeLaPartage},
+			wantOk: true,
+		},
+		{
+			name:   "expect half the stake given 00 under la partage",
+			rules:  table.RulesLaPartage,
+			wheel:  table.WheelAmerican,
+			model:  red,
+			number: round.DoubleZero,
+			want:   Winner{BetID: "a", Stake: 101, Return: 50, Currency: "GBP", Outcome: OutcomeLaPartage},
+			wantOk: true,
+		},
+		{
+			name:   "expect loss given inside bet on zero under la partage",
+			rules:  table.RulesLaPartage,
+			wheel:  table.WheelEuropean,
+			model:  Bet{ID: "a", Bet: "17", Type: TypeStraight, Amount: 100, Currency: "GBP"},
+			number: 0,
+			wantOk: false,
+		},
+		{
+			name:   "expect win given even-money bet covers the number under la partage",
+			rules:  table.RulesLaPartage,
+			wheel:  table.WheelEuropean,
+			model:  red,
+			number: 1,
+			want: Winner{
+				BetID:      "a",
+				Stake:      101,
+				Multiplier: 1,
+				Winnings:   101,
+				Return:     202,
+				Currency:   "GBP",
+				Outcome:    OutcomeWin,
+			},
+			wantOk: true,
+		},
+		{
+			name:   "expect stake only given imprisoned bet covers the number",
+			rules:  table.RulesEnPrison,
+			wheel:  table.WheelEuropean,
+			model:  Bet{ID: "a", Bet: "red", Type: TypeRedBlack, Amount: 101, Currency: "GBP", Imprisoned: true},
+			number: 1,
+			want:   Winner{BetID: "a", Stake: 101, Return: 101, Currency: "GBP", Outcome: OutcomeEnPrison},
+			wantOk: true,
+		},
+		{
+			name:   "expect loss given imprisoned bet and zero again",
+			rules:  table.RulesEnPrison,
+			wheel:  table.WheelEuropean,
+			model:  Bet{ID: "a", Bet: "red", Type: TypeRedBlack, Amount: 101, Currency: "GBP", Imprisoned: true},
+			number: 0,
+			wantOk: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok, err := settleBet(table.Table{Wheel: tt.wheel, Rules: tt.rules}, tt.model, tt.number)
+			if !cmp.Equal(err, tt.wantErr, cmpopts.EquateErrors()) {
+				t.Fatal(cmp.Diff(err, tt.wantErr, cmpopts.EquateErrors()))
+			}
+
+			if !cmp.Equal(ok, tt.wantOk) {
+				t.Fatal(cmp.Diff(ok, tt.wantOk))
+			}
+
+			if !cmp.Equal(got, tt.want) {
+				t.Error(cmp.Diff(got, tt.want))
+			}
+		})
+	}
+}
+
+func Test_imprison(t *testing.T) {
+	bets := []Bet{
+		{ID: "a", Bet: "red", Type: TypeRedBlack},
+		{ID: "b", Bet: "17", Type: TypeStraight},
+		{ID: "c", Bet: "odd", Type: TypeOddEven, Imprisoned: true},
+	}
+
+	tests := []struct {
+		name        string
+		rules       string
+		number      int
+		wantSettled []string
+		wantHeld    []string
+	}{
+		{
+			name:        "expect even-money bet held given zero under en prison",
+			rules:       table.RulesEnPrison,
+			number:      0,
+			wantSettled: []string{"b", "c"},
+			wantHeld:    []string{"a"},
+		},
+		{
+			name:        "expect every bet settled given another number under en prison",
+			rules:       table.RulesEnPrison,
+			number:      17,
+			wantSettled: []string{"a", "b", "c"},
+			wantHeld:    []string{},
+		},
+		{
+			name:        "expect every bet settled given zero under standard rules",
+			rules:       table.RulesStandard,
+			number:      0,
+			wantSettled: []string{"a", "b", "c"},
+			wantHeld:    []string{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			settled, held := imprison(table.Table{Wheel: table.WheelEuropean, Rules: tt.rules}, bets, tt.number)
+
+			if !cmp.Equal(betIDs(settled), tt.wantSettled) {
+				t.Error(cmp.Diff(betIDs(settled), tt.wantSettled))
+			}
+
+			if !cmp.Equal(betIDs(held), tt.wantHeld) {
+				t.Error(cmp.Diff(betIDs(held), tt.wantHeld))
+			}
+		})
+	}
+}
@@ -0,0 +1,87 @@
+package bet
+
+import (
+	"strings"
+
+	"github.com/clarke94/roulette-service/internal/pkg/apperror"
+	"github.com/clarke94/roulette-service/internal/pkg/table"
+)
+
+// invalid wraps the error with the field of the Bet at fault.
+func invalid(err error, field, message string) error {
+	return apperror.WithFields(err, apperror.Field{
+		Field:   field,
+		Message: message,
+	})
+}
+
+// types is every supported Bet type in the order they are described to players.
+var types = []string{
+	TypeRedBlack,
+	TypeOddEven,
+	TypeHighLow,
+	TypeDozen,
+	TypeColumn,
+	TypeStraight,
+	TypeSplit,
+	TypeStreet,
+	TypeCorner,
+	TypeSixLine,
+	TypeBasket,
+	TypeFiveNumber,
+}
+
+// selectionHints describes the selections that are valid for each Bet type.
+var selectionHints = map[string]string{
+	TypeRedBlack: `"red" or "black"`,
+	TypeOddEven:  `"odd" or "even"`,
+	TypeHighLow:  `"1-18" or "19-36"`,
+	TypeDozen:    `"1st", "2nd" or "3rd"`,
+	TypeColumn:   `"1st", "2nd" or "3rd"`,
+	TypeStraight: `a single number from 0 to 36, e.g. "17"`,
+	TypeSplit:    `two numbers next to each other on the layout, e.g. "17-20"`,
+	TypeStreet:   `a row of three numbers or a trio with zero, e.g. "16-17-18" or "0-1-2"`,
+	TypeCorner:   `four numbers that meet at a corner, e.g. "17-18-20-21"`,
+	TypeSixLine:  `two neighbouring rows, e.g. "16-17-18-19-20-21"`,
+	TypeBasket:   `"0-1-2-3"`,
+}
+
+// americanHints replaces the selection hints for the Bet types that include the double zero of an American wheel.
+var americanHints = map[string]string{
+	TypeStraight:   `a single number from 0 to 36 or 00, e.g. "17" or "00"`,
+	TypeSplit:      `two numbers next to each other on the layout, e.g. "17-20" or "0-00"`,
+	TypeStreet:     `a row of three numbers or a trio with zero, e.g. "16-17-18" or "0-00-2"`,
+	TypeFiveNumber: `"0-00-1-2-3"`,
+}
+
+// checkFilter rejects a Filter on a Bet type that is not supported.
+func checkFilter(filter Filter) error {
+	if _, ok := TypeMultiplierMap[filter.Type]; filter.Type != "" && !ok {
+		return invalid(ErrType, "type", "must be one of "+strings.Join(types, ", "))
+	}
+
+	return nil
+}
+
+// typeMessage describes the Bet types that are supported on the wheel.
+func typeMessage(wheel string) string {
+	var supported []string
+
+	for _, t := range types {
+		if _, ok := catalogues[wheel][t]; ok {
+			supported = append(supported, t)
+		}
+	}
+
+	return "must be one of " + strings.Join(supported, ", ")
+}
+
+// selectionMessage describes the selections that are valid for the Bet type on the wheel.
+func selectionMessage(wheel, betType string) string {
+	hint := selectionHints[betType]
+	if h, ok := americanHints[betType]; ok && wheel == table.WheelAmerican {
+		hint = h
+	}
+
+	return "must be " + hint + " for a " + betType + " bet"
+}
@@ -0,0 +1,131 @@
+package bet
+
+import (
+	"testing"
+
+	"github.com/clarke94/roulette-service/internal/pkg/apperror"
+	"github.com/clarke94/roulette-service/internal/pkg/table"
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestController_validate(t *testing.T) {
+	tests := []struct {
+		name       string
+		wheel      string
+		model      Bet
+		want       Bet
+		wantFields []apperror.Field
+	}{
+		{
+			name:  "expect canonical bet given corner in any order",
+			wheel: table.WheelEuropean,
+			model: Bet{Bet: "21-17-20-18", Type: TypeCorner},
+			want:  Bet{Bet: "17-18-20-21", Type: TypeCorner},
+		},
+		{
+			name:  "expect type field given unknown type",
+			wheel: table.WheelEuropean,
+			model: Bet{Bet: "17", Type: "foo"},
+			want:  Bet{},
+			wantFields: []apperror.Field{
+				{
+					Field:   "type",
+					Message: "must be one of red/black, odd/even, high/low, dozen, column, straight, split, street, corner, six-line, basket",
+				},
+			},
+		},
+		{
+			name:  "expect bet field given color that does not exist",
+			wheel: table.WheelEuropean,
+			model: Bet{Bet: "purple", Type: TypeRedBlack},
+			want:  Bet{},
+			wantFields: []apperror.Field{
+				{
+					Field:   "bet",
+					Message: `must be "red" or "black" for a red/black bet`,
+				},
+			},
+		},
+		{
+			name:  "expect bet field given split of numbers that are not adjacent",
+			wheel: table.WheelEuropean,
+			model: Bet{Bet: "17-21", Type: TypeSplit},
+			want:  Bet{},
+			wantFields: []apperror.Field{
+				{
+					Field:   "bet",
+					Message: `must be two numbers next to each other on the layout, e.g. "17-20" for a split bet`,
+				},
+			},
+		},
+		{
+			name:  "expect canonical bet given five-number on American wheel",
+			wheel: table.WheelAmerican,
+			model: Bet{Bet: "3-2-1-00-0", Type: TypeFiveNumber},
+			want:  Bet{Bet: "0-00-1-2-3", Type: TypeFiveNumber},
+		},
+		{
+			name:  "expect type field given five-number on European wheel",
+			wheel: table.WheelEuropean,
+			model: Bet{Bet: "0-00-1-2-3", Type: TypeFiveNumber},
+			want:  Bet{},
+			wantFields: []apperror.Field{
+				{
+					Field:   "type",
+					Message: "must be one of red/black, odd/even, high/low, dozen, column, straight, split, street, corner, six-line, basket",
+				},
+			},
+		},
+		{
+			name:  "expect type field given basket on American wheel",
+			wheel: table.WheelAmerican,
+			model: Bet{Bet: "0-1-2-3", Type: TypeBasket},
+			want:  Bet{},
+			wantFields: []apperror.Field{
+				{
+					Field:   "type",
+					Message: "must be one of red/black, odd/even, high/low, dozen, column, straight, split, street, corner, six-line, five-number",
+				},
+			},
+		},
+		{
+			name:  "expect bet field given double zero on European wheel",
+			wheel: table.WheelEuropean,
+			model: Bet{Bet: "00", Type: TypeStraight},
+			want:  Bet{},
+			wantFields: []apperror.Field{
+				{
+					Field:   "bet",
+					Message: `must be a single number from 0 to 36, e.g. "17" for a straight bet`,
+				},
+			},
+		},
+		{
+			name:  "expect bet field given split across zeros on American wheel",
+			wheel: table.WheelAmerican,
+			model: Bet{Bet: "0-3", Type: TypeSplit},
+			want:  Bet{},
+			wantFields: []apperror.Field{
+				{
+					Field:   "bet",
+					Message: `must be two numbers next to each other on the layout, e.g. "17-20" or "0-00" for a split bet`,
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Controller{}.validate(table.Table{Wheel: tt.wheel}, tt.model)
+
+			fields := apperror.FieldsOf(err)
+
+			if !cmp.Equal(got, tt.want) {
+				t.Error(cmp.Diff(got, tt.want))
+			}
+
+			if !cmp.Equal(fields, tt.wantFields) {
+				t.Error(cmp.Diff(fields, tt.wantFields))
+			}
+		})
+	}
+}
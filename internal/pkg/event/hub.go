@@ -0,0 +1,174 @@
+// Package event provides an in-process hub that publishes the events of each table to its subscribers.
+package event
+
+import (
+	"sync"
+	"time"
+)
+
+const (
+	// historySize is the number of recent events kept per table to replay to a subscriber that reconnects.
+	historySize = 256
+	// historyTables is the number of tables history is kept for, the tables least recently published to are
+	// forgotten first.
+	historyTables = 1024
+	// bufferSize is the number of events a subscriber can fall behind by before it is disconnected.
+	bufferSize = 64
+)
+
+// Type is the kind of Event published for a table.
+const (
+	// TypeBetPlaced is published with the Bet once a bet is placed.
+	TypeBetPlaced = "bet.placed"
+	// TypeBetUpdated is published with the Bet once a bet is updated.
+	TypeBetUpdated = "bet.updated"
+	// TypeBetCancelled is published with the Bet once a bet is cancelled by its player.
+	TypeBetCancelled = "bet.cancelled"
+	// TypeBettingClosed is published with the Round once it stops accepting bets.
+	TypeBettingClosed = "round.closed"
+	// TypeRoundSpun is published with the Round once its result is known.
+	TypeRoundSpun = "round.spun"
+	// TypeRoundSettled is published with the Result once the winners of a round are paid out.
+	TypeRoundSettled = "round.settled"
+)
+
+// Event is something that happened at a table.
+// ID increases with every Event published to the Hub, so a subscriber can resume from the last Event it received.
+type Event struct {
+	ID      int64
+	Type    string
+	TableID string
+	Data    interface{}
+	At      time.Time
+}
+
+// Hub fans the events of each table out to the subscribers of the table.
+// Publishing never blocks, a subscriber that falls too far behind is disconnected and can resume from its last Event.
+type Hub struct {
+	mu          *sync.Mutex
+	next        int64
+	history     map[string][]Event
+	subscribers map[string]map[chan Event]struct{}
+}
+
+// NewHub initializes a new Hub.
+func NewHub() *Hub {
+	return &Hub{
+		mu:          &sync.Mutex{},
+		history:     make(map[string][]Event),
+		subscribers: make(map[string]map[chan Event]struct{}),
+	}
+}
+
+// Publish sends the Event to every subscriber of its table.
+func (h *Hub) Publish(e Event) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.next++
+	e.ID = h.next
+	e.At = time.Now().UTC()
+
+	if _, ok := h.history[e.TableID]; !ok && len(h.history) >= historyTables {
+		h.forget()
+	}
+
+	history := append(h.history[e.TableID], e)
+	if len(history) > historySize {
+		history = history[len(history)-historySize:]
+	}
+
+	h.history[e.TableID] = history
+
+	for events := range h.subscribers[e.TableID] {
+		select {
+		case events <- e:
+		default:
+			h.unsubscribe(e.TableID, events)
+		}
+	}
+}
+
+// Subscribe returns the events of the table as they are published, starting with any recent events after the given
+// ID, and a func to stop receiving them. The channel is closed once the subscription ends.
+func (h *Hub) Subscribe(tableID string, after int64) (<-chan Event, func()) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	events := make(chan Event, historySize+bufferSize)
+
+	if after > 0 {
+		for _, e := range h.history[tableID] {
+			if e.ID > after {
+				events <- e
+			}
+		}
+	}
+
+	if h.subscribers[tableID] == nil {
+		h.subscribers[tableID] = make(map[chan Event]struct{})
+	}
+
+	h.subscribers[tableID][events] = struct{}{}
+
+	return events, func() {
+		h.mu.Lock()
+		defer h.mu.Unlock()
+
+		h.unsubscribe(tableID, events)
+	}
+}
+
+// Remove forgets the history of the table and ends the subscriptions to it, once the table is deleted.
+func (h *Hub) Remove(tableID string) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	delete(h.history, tableID)
+
+	for events := range h.subscribers[tableID] {
+		h.unsubscribe(tableID, events)
+	}
+}
+
+// Close ends every subscription, so the streams of the subscribers finish as the server shuts down.
+func (h *Hub) Close() {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	for tableID := range h.subscribers {
+		for events := range h.subscribers[tableID] {
+			h.unsubscribe(tableID, events)
+		}
+	}
+}
+
+// forget drops the history of the table least recently published to, the caller holding the lock.
+func (h *Hub) forget() {
+	var (
+		oldest string
+		latest int64
+	)
+
+	for tableID, history := range h.history {
+		if id := history[len(history)-1].ID; oldest == "" || id < latest {
+			oldest, latest = tableID, id
+		}
+	}
+
+	delete(h.history, oldest)
+}
+
+// unsubscribe removes the subscriber and closes its channel, the caller holding the lock.
+func (h *Hub) unsubscribe(tableID string, events chan Event) {
+	if _, ok := h.subscribers[tableID][events]; !ok {
+		return
+	}
+
+	delete(h.subscribers[tableID], events)
+	close(events)
+
+	if len(h.subscribers[tableID]) == 0 {
+		delete(h.subscribers, tableID)
+	}
+}
@@ -0,0 +1,168 @@
+package event
+
+import (
+	"strconv"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestHub_Publish(t *testing.T) {
+	h := NewHub()
+
+	events, unsubscribe := h.Subscribe("a", 0)
+	defer unsubscribe()
+
+	other, unsubscribeOther := h.Subscribe("b", 0)
+	defer unsubscribeOther()
+
+	h.Publish(Event{Type: TypeBetPlaced, TableID: "a", Data: "foo"})
+	h.Publish(Event{Type: TypeBettingClosed, TableID: "a"})
+
+	got := []string{(<-events).Type, (<-events).Type}
+	want := []string{TypeBetPlaced, TypeBettingClosed}
+
+	if !cmp.Equal(got, want) {
+		t.Error(cmp.Diff(got, want))
+	}
+
+	if !cmp.Equal(len(other), 0) {
+		t.Error("expect no events given subscriber of another table")
+	}
+}
+
+func TestHub_Subscribe(t *testing.T) {
+	tests := []struct {
+		name    string
+		after   int64
+		wantIDs []int64
+	}{
+		{
+			name:    "expect only new events given no last event",
+			after:   0,
+			wantIDs: []int64{},
+		},
+		{
+			name:    "expect events after the last event replayed given reconnect",
+			after:   1,
+			wantIDs: []int64{2, 3},
+		},
+		{
+			name:    "expect nothing replayed given last event is the latest",
+			after:   3,
+			wantIDs: []int64{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := NewHub()
+
+			for i := 0; i < 3; i++ {
+				h.Publish(Event{Type: TypeBetPlaced, TableID: "a"})
+			}
+
+			events, unsubscribe := h.Subscribe("a", tt.after)
+			defer unsubscribe()
+
+			got := []int64{}
+			for len(events) > 0 {
+				got = append(got, (<-events).ID)
+			}
+
+			if !cmp.Equal(got, tt.wantIDs) {
+				t.Error(cmp.Diff(got, tt.wantIDs))
+			}
+		})
+	}
+}
+
+func TestHub_unsubscribe(t *testing.T) {
+	h := NewHub()
+
+	events, unsubscribe := h.Subscribe("a", 0)
+	unsubscribe()
+	unsubscribe()
+
+	if _, ok := <-events; ok {
+		t.Error("expect channel closed given unsubscribed")
+	}
+
+	slow, unsubscribeSlow := h.Subscribe("a", 0)
+	defer unsubscribeSlow()
+
+	for i := 0; i < historySize+bufferSize+1; i++ {
+		h.Publish(Event{Type: TypeBetPlaced, TableID: "a"})
+	}
+
+	count := 0
+	for range slow {
+		count++
+	}
+
+	if !cmp.Equal(count, historySize+bufferSize) {
+		t.Error(cmp.Diff(count, historySize+bufferSize))
+	}
+}
+
+func TestHub_Remove(t *testing.T) {
+	h := NewHub()
+
+	h.Publish(Event{Type: TypeBetPlaced, TableID: "a"})
+	h.Publish(Event{Type: TypeBetPlaced, TableID: "b"})
+
+	events, unsubscribe := h.Subscribe("a", 0)
+	defer unsubscribe()
+
+	h.Remove("a")
+
+	if _, ok := <-events; ok {
+		t.Error("expect channel closed given table removed")
+	}
+
+	replayed, unsubscribeReplayed := h.Subscribe("a", 0)
+	defer unsubscribeReplayed()
+
+	h.Publish(Event{Type: TypeBetPlaced, TableID: "b"})
+
+	if !cmp.Equal(len(replayed), 0) {
+		t.Error("expect nothing replayed given table removed")
+	}
+
+	if !cmp.Equal(len(h.history), 1) {
+		t.Error(cmp.Diff(len(h.history), 1))
+	}
+}
+
+func TestHub_Close(t *testing.T) {
+	h := NewHub()
+
+	a, unsubscribeA := h.Subscribe("a", 0)
+	defer unsubscribeA()
+
+	b, unsubscribeB := h.Subscribe("b", 0)
+	defer unsubscribeB()
+
+	h.Close()
+
+	for _, events := range []<-chan Event{a, b} {
+		if _, ok := <-events; ok {
+			t.Error("expect channel closed given hub closed")
+		}
+	}
+}
+
+func TestHub_forget(t *testing.T) {
+	h := NewHub()
+
+	for i := 0; i < historyTables+1; i++ {
+		h.Publish(Event{Type: TypeBetPlaced, TableID: strconv.Itoa(i)})
+	}
+
+	if !cmp.Equal(len(h.history), historyTables) {
+		t.Error(cmp.Diff(len(h.history), historyTables))
+	}
+
+	if _, ok := h.history["0"]; ok {
+		t.Error("expect history forgotten given table least recently published to")
+	}
+}
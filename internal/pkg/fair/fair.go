@@ -0,0 +1,60 @@
+// Package fair derives provably fair spins from a server seed committed to before a round and a client seed.
+//
+// The outcome of a spin is read from HMAC-SHA256 keyed with the server seed over the message
+// "clientSeed:nonce:cursor", taking each 4 byte big-endian chunk of the digest in turn and discarding any chunk that
+// would bias the result before reducing it modulo the number of pockets. Should every chunk be discarded the cursor
+// is incremented and a new digest drawn.
+package fair
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+)
+
+const (
+	// seedSize is the number of random bytes in a server seed.
+	seedSize = 32
+	// chunkSize is the number of bytes of the digest read for each candidate outcome.
+	chunkSize = 4
+	// chunkRange is the number of values a chunk can hold.
+	chunkRange = 1 << 32
+)
+
+// NewSeed returns a new random server seed encoded as hex.
+func NewSeed() (string, error) {
+	seed := make([]byte, seedSize)
+	if _, err := rand.Read(seed); err != nil {
+		return "", err
+	}
+
+	return hex.EncodeToString(seed), nil
+}
+
+// Hash returns the SHA-256 hash of the seed encoded as hex, published before the round as a commitment to the seed.
+func Hash(seed string) string {
+	sum := sha256.Sum256([]byte(seed))
+
+	return hex.EncodeToString(sum[:])
+}
+
+// Outcome returns the pocket in [0, pockets) derived from the server seed, client seed and nonce.
+func Outcome(serverSeed, clientSeed string, nonce int64, pockets int) int {
+	limit := uint64(chunkRange - chunkRange%pockets)
+
+	for cursor := 0; ; cursor++ {
+		mac := hmac.New(sha256.New, []byte(serverSeed))
+		_, _ = fmt.Fprintf(mac, "%s:%d:%d", clientSeed, nonce, cursor)
+		digest := mac.Sum(nil)
+
+		for i := 0; i+chunkSize <= len(digest); i += chunkSize {
+			value := uint64(binary.BigEndian.Uint32(digest[i : i+chunkSize]))
+			if value < limit {
+				return int(value % uint64(pockets))
+			}
+		}
+	}
+}
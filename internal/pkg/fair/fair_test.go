@@ -0,0 +1,97 @@
+package fair
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestNewSeed(t *testing.T) {
+	first, err := NewSeed()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	second, err := NewSeed()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !cmp.Equal(len(first), seedSize*2) {
+		t.Error(cmp.Diff(len(first), seedSize*2))
+	}
+
+	if cmp.Equal(first, second) {
+		t.Error("expect a different seed each time")
+	}
+}
+
+func TestHash(t *testing.T) {
+	want := "b3eacd33433b31b5252351032c9b3e7a2e7aa7738d5decdf0dd6c62680853c06"
+
+	got := Hash("server")
+	if !cmp.Equal(got, want) {
+		t.Error(cmp.Diff(got, want))
+	}
+}
+
+func TestOutcome(t *testing.T) {
+	tests := []struct {
+		name       string
+		serverSeed string
+		clientSeed string
+		nonce      int64
+		pockets    int
+		want       int
+	}{
+		{
+			name:       "expect outcome given a European wheel",
+			serverSeed: "server",
+			clientSeed: "client",
+			nonce:      0,
+			pockets:    37,
+			want:       19,
+		},
+		{
+			name:       "expect different outcome given another nonce",
+			serverSeed: "server",
+			clientSeed: "client",
+			nonce:      1,
+			pockets:    37,
+			want:       6,
+		},
+		{
+			name:       "expect outcome given an American wheel",
+			serverSeed: "server",
+			clientSeed: "client",
+			nonce:      0,
+			pockets:    38,
+			want:       11,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := Outcome(tt.serverSeed, tt.clientSeed, tt.nonce, tt.pockets)
+			if !cmp.Equal(got, tt.want) {
+				t.Error(cmp.Diff(got, tt.want))
+			}
+		})
+	}
+}
+
+func TestOutcome_range(t *testing.T) {
+	seen := make(map[int]bool)
+
+	for nonce := int64(0); nonce < 2000; nonce++ {
+		got := Outcome("server", "client", nonce, 38)
+		if got < 0 || got >= 38 {
+			t.Fatalf("expect outcome in [0, 38), got %d", got)
+		}
+
+		seen[got] = true
+	}
+
+	if !cmp.Equal(len(seen), 38) {
+		t.Error(cmp.Diff(len(seen), 38))
+	}
+}
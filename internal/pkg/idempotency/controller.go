@@ -0,0 +1,147 @@
+package idempotency
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/clarke94/roulette-service/internal/pkg/apperror"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	// DefaultTTL is how long the response to a request is replayed for retries made with the same key.
+	DefaultTTL = 24 * time.Hour
+	// DefaultLease is how long a request holds its key while in progress before a retry can take it over, should the
+	// request never finish.
+	DefaultLease = time.Minute
+)
+
+var (
+	ErrBegin      = apperror.New(apperror.Internal, "unable to check idempotency key")
+	ErrFinish     = apperror.New(apperror.Internal, "unable to save idempotent response")
+	ErrRelease    = apperror.New(apperror.Internal, "unable to release idempotency key")
+	ErrNotFound   = apperror.New(apperror.NotFound, "idempotency key not found")
+	ErrMismatch   = apperror.New(apperror.Conflict, "idempotency key was used with a different request")
+	ErrInProgress = apperror.New(apperror.Conflict, "a request with the idempotency key is in progress")
+	ErrLeaseLost  = apperror.New(apperror.Conflict, "idempotency key was taken over once its lease ran out")
+)
+
+// StorageProvider provides an interface to the Storage layer.
+type StorageProvider interface {
+	Get(ctx context.Context, id string) (Key, error)
+	Create(ctx context.Context, model Key) error
+	Complete(ctx context.Context, model Key) error
+	Delete(ctx context.Context, id, claim string) error
+	DeleteExpired(ctx context.Context, id string, now time.Time) error
+}
+
+// Controller provides a domain controller.
+type Controller struct {
+	Logger  *logrus.Logger
+	Storage StorageProvider
+	TTL     time.Duration
+	Lease   time.Duration
+	Now     func() time.Time
+}
+
+// New initializes a new Controller that replays responses for the given TTL and holds the key of a request in
+// progress for the given lease, using DefaultTTL and DefaultLease for either when it is not set.
+func New(logger *logrus.Logger, storage StorageProvider, ttl, lease time.Duration) Controller {
+	if ttl <= 0 {
+		ttl = DefaultTTL
+	}
+
+	if lease <= 0 {
+		lease = DefaultLease
+	}
+
+	return Controller{
+		Logger:  logger,
+		Storage: storage,
+		TTL:     ttl,
+		Lease:   lease,
+		Now:     time.Now,
+	}
+}
+
+// Begin claims the key for a request with the given fingerprint. When the key has already been used for the same
+// request the recorded Key is returned and is Done once there is a response to replay. A key used for a different
+// request, or by a request that has not finished, is a conflict. A key that has expired is claimed afresh, which
+// includes the key of a request whose lease ran out without it finishing.
+func (c Controller) Begin(ctx context.Context, id, fingerprint string) (Key, error) {
+	now := c.Now().UTC()
+
+	existing, err := c.Storage.Get(ctx, id)
+
+	switch {
+	case errors.Is(err, ErrNotFound):
+	case err != nil:
+		return Key{}, c.fail(err, ErrBegin)
+	case !existing.ExpiresAt.After(now):
+		if err := c.Storage.DeleteExpired(ctx, id, now); err != nil {
+			return Key{}, c.fail(err, ErrBegin)
+		}
+	case existing.Fingerprint != fingerprint:
+		return Key{}, ErrMismatch
+	case !existing.Done():
+		return Key{}, ErrInProgress
+	default:
+		return existing, nil
+	}
+
+	model := Key{
+		ID:          id,
+		Claim:       uuid.New().String(),
+		Fingerprint: fingerprint,
+		ExpiresAt:   now.Add(c.Lease),
+	}
+
+	err = c.Storage.Create(ctx, model)
+	if errors.Is(err, ErrInProgress) {
+		return Key{}, ErrInProgress
+	}
+
+	if err != nil {
+		return Key{}, c.fail(err, ErrBegin)
+	}
+
+	return model, nil
+}
+
+// Finish records the response to the request that claimed the key so it is replayed to any retry within the TTL.
+// A request whose lease ran out and whose key was taken over by a retry records nothing, returning ErrLeaseLost.
+func (c Controller) Finish(ctx context.Context, model Key) error {
+	model.ExpiresAt = c.Now().UTC().Add(c.TTL)
+
+	err := c.Storage.Complete(ctx, model)
+	if errors.Is(err, ErrLeaseLost) {
+		return ErrLeaseLost
+	}
+
+	return c.fail(err, ErrFinish)
+}
+
+// Release gives up the key of a request that failed so the request can be retried.
+// A request whose lease ran out and whose key was taken over by a retry releases nothing, returning ErrLeaseLost.
+func (c Controller) Release(ctx context.Context, model Key) error {
+	err := c.Storage.Delete(ctx, model.ID, model.Claim)
+	if errors.Is(err, ErrLeaseLost) {
+		return ErrLeaseLost
+	}
+
+	return c.fail(err, ErrRelease)
+}
+
+func (c Controller) fail(err, external error) error {
+	if err == nil {
+		return nil
+	}
+
+	c.Logger.WithFields(logrus.Fields{
+		"error": err.Error(),
+	}).Error(external.Error())
+
+	return external
+}
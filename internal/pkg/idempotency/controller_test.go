@@ -0,0 +1,307 @@
+package idempotency
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/sirupsen/logrus"
+)
+
+var now = time.Date(2021, 6, 21, 21, 0, 0, 0, time.UTC)
+
+func TestNew(t *testing.T) {
+	tests := []struct {
+		name  string
+		ttl   time.Duration
+		lease time.Duration
+		want  Controller
+	}{
+		{
+			name:  "expect Controller to init given TTL and lease",
+			ttl:   time.Hour,
+			lease: time.Minute,
+			want: Controller{
+				Storage: &mockStorage{},
+				TTL:     time.Hour,
+				Lease:   time.Minute,
+			},
+		},
+		{
+			name:  "expect defaults given no TTL or lease",
+			ttl:   0,
+			lease: 0,
+			want: Controller{
+				Storage: &mockStorage{},
+				TTL:     DefaultTTL,
+				Lease:   DefaultLease,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := New(logrus.New(), &mockStorage{}, tt.ttl, tt.lease)
+
+			opts := []cmp.Option{
+				cmpopts.IgnoreFields(Controller{}, "Logger", "Now"),
+				cmp.AllowUnexported(mockStorage{}),
+			}
+			if !cmp.Equal(got, tt.want, opts...) {
+				t.Error(cmp.Diff(got, tt.want, opts...))
+			}
+		})
+	}
+}
+
+func TestController_Begin(t *testing.T) {
+	done := Key{
+		ID:          "foo",
+		Fingerprint: "bar",
+		Status:      201,
+		ContentType: "application/json",
+		Body:        []byte(`{"id":"baz"}`),
+		ExpiresAt:   now.Add(time.Minute),
+	}
+
+	tests := []struct {
+		name        string
+		Storage     *mockStorage
+		fingerprint string
+		want        Key
+		wantErr     error
+		wantCreated bool
+		wantDeleted bool
+	}{
+		{
+			name:        "expect key claimed given new key",
+			Storage:     &mockStorage{GivenGetError: ErrNotFound},
+			fingerprint: "bar",
+			want:        Key{ID: "foo", Fingerprint: "bar", ExpiresAt: now.Add(time.Minute)},
+			wantErr:     nil,
+			wantCreated: true,
+		},
+		{
+			name:        "expect response replayed given same request",
+			Storage:     &mockStorage{GivenKey: done},
+			fingerprint: "bar",
+			want:        done,
+			wantErr:     nil,
+		},
+		{
+			name:        "expect ErrMismatch given different request",
+			Storage:     &mockStorage{GivenKey: done},
+			fingerprint: "qux",
+			want:        Key{},
+			wantErr:     ErrMismatch,
+		},
+		{
+			name: "expect ErrInProgress given first request has not finished",
+			Storage: &mockStorage{GivenKey: Key{
+				ID:          "foo",
+				Fingerprint: "bar",
+				ExpiresAt:   now.Add(time.Minute),
+			}},
+			fingerprint: "bar",
+			want:        Key{},
+			wantErr:     ErrInProgress,
+		},
+		{
+			name: "expect key claimed afresh given expired key",
+			Storage: &mockStorage{GivenKey: Key{
+				ID:          "foo",
+				Fingerprint: "qux",
+				Status:      201,
+				ExpiresAt:   now,
+			}},
+			fingerprint: "bar",
+			want:        Key{ID: "foo", Fingerprint: "bar", ExpiresAt: now.Add(time.Minute)},
+			wantErr:     nil,
+			wantCreated: true,
+			wantDeleted: true,
+		},
+		{
+			name: "expect key taken over given lease of unfinished request ran out",
+			Storage: &mockStorage{GivenKey: Key{
+				ID:          "foo",
+				Fingerprint: "bar",
+				ExpiresAt:   now,
+			}},
+			fingerprint: "bar",
+			want:        Key{ID: "foo", Fingerprint: "bar", ExpiresAt: now.Add(time.Minute)},
+			wantErr:     nil,
+			wantCreated: true,
+			wantDeleted: true,
+		},
+		{
+			name:        "expect ErrInProgress given key claimed concurrently",
+			Storage:     &mockStorage{GivenGetError: ErrNotFound, GivenError: ErrInProgress},
+			fingerprint: "bar",
+			want:        Key{},
+			wantErr:     ErrInProgress,
+			wantCreated: true,
+		},
+		{
+			name:        "expect ErrBegin given get error",
+			Storage:     &mockStorage{GivenGetError: errors.New("foo")},
+			fingerprint: "bar",
+			want:        Key{},
+			wantErr:     ErrBegin,
+		},
+		{
+			name:        "expect ErrBegin given create error",
+			Storage:     &mockStorage{GivenGetError: ErrNotFound, GivenError: errors.New("foo")},
+			fingerprint: "bar",
+			want:        Key{},
+			wantErr:     ErrBegin,
+			wantCreated: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := New(logrus.New(), tt.Storage, time.Hour, time.Minute)
+			c.Now = func() time.Time { return now }
+
+			got, err := c.Begin(context.Background(), "foo", tt.fingerprint)
+			if !cmp.Equal(err, tt.wantErr, cmpopts.EquateErrors()) {
+				t.Error(cmp.Diff(err, tt.wantErr, cmpopts.EquateErrors()))
+			}
+
+			if !cmp.Equal(got, tt.want, cmpopts.IgnoreFields(Key{}, "Claim")) {
+				t.Error(cmp.Diff(got, tt.want, cmpopts.IgnoreFields(Key{}, "Claim")))
+			}
+
+			if err == nil && tt.wantCreated && (got.Claim == "" || got.Claim != tt.Storage.claim) {
+				t.Errorf("expect claim %q recorded, got %q", got.Claim, tt.Storage.claim)
+			}
+
+			if !cmp.Equal(tt.Storage.created, tt.wantCreated) {
+				t.Error(cmp.Diff(tt.Storage.created, tt.wantCreated))
+			}
+
+			if !cmp.Equal(tt.Storage.deleted, tt.wantDeleted) {
+				t.Error(cmp.Diff(tt.Storage.deleted, tt.wantDeleted))
+			}
+		})
+	}
+}
+
+func TestController_Finish(t *testing.T) {
+	tests := []struct {
+		name    string
+		Storage *mockStorage
+		wantErr error
+	}{
+		{
+			name:    "expect success given response recorded",
+			Storage: &mockStorage{},
+			wantErr: nil,
+		},
+		{
+			name:    "expect fail given storage error",
+			Storage: &mockStorage{GivenError: errors.New("foo")},
+			wantErr: ErrFinish,
+		},
+		{
+			name:    "expect ErrLeaseLost given key taken over by another request",
+			Storage: &mockStorage{GivenError: ErrLeaseLost},
+			wantErr: ErrLeaseLost,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := New(logrus.New(), tt.Storage, time.Hour, time.Minute)
+			c.Now = func() time.Time { return now }
+
+			err := c.Finish(context.Background(), Key{ID: "foo", Status: 201, ExpiresAt: now.Add(time.Minute)})
+			if !cmp.Equal(err, tt.wantErr, cmpopts.EquateErrors()) {
+				t.Error(cmp.Diff(err, tt.wantErr, cmpopts.EquateErrors()))
+			}
+
+			if !cmp.Equal(tt.Storage.completed.ExpiresAt, now.Add(time.Hour)) {
+				t.Error(cmp.Diff(tt.Storage.completed.ExpiresAt, now.Add(time.Hour)))
+			}
+		})
+	}
+}
+
+func TestController_Release(t *testing.T) {
+	tests := []struct {
+		name    string
+		Storage *mockStorage
+		wantErr error
+	}{
+		{
+			name:    "expect success given key released",
+			Storage: &mockStorage{},
+			wantErr: nil,
+		},
+		{
+			name:    "expect fail given storage error",
+			Storage: &mockStorage{GivenDeleteError: errors.New("foo")},
+			wantErr: ErrRelease,
+		},
+		{
+			name:    "expect ErrLeaseLost given key taken over by another request",
+			Storage: &mockStorage{GivenDeleteError: ErrLeaseLost},
+			wantErr: ErrLeaseLost,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := New(logrus.New(), tt.Storage, time.Hour, time.Minute)
+
+			err := c.Release(context.Background(), Key{ID: "foo", Claim: "bar"})
+			if !cmp.Equal(err, tt.wantErr, cmpopts.EquateErrors()) {
+				t.Error(cmp.Diff(err, tt.wantErr, cmpopts.EquateErrors()))
+			}
+
+			if !cmp.Equal(tt.Storage.claim, "bar") {
+				t.Error(cmp.Diff(tt.Storage.claim, "bar"))
+			}
+		})
+	}
+}
+
+type mockStorage struct {
+	GivenKey         Key
+	GivenGetError    error
+	GivenDeleteError error
+	GivenError       error
+	created          bool
+	deleted          bool
+	completed        Key
+	claim            string
+}
+
+func (m *mockStorage) Get(_ context.Context, _ string) (Key, error) {
+	return m.GivenKey, m.GivenGetError
+}
+
+func (m *mockStorage) Create(_ context.Context, model Key) error {
+	m.created = true
+	m.claim = model.Claim
+
+	return m.GivenError
+}
+
+func (m *mockStorage) Complete(_ context.Context, model Key) error {
+	m.completed = model
+
+	return m.GivenError
+}
+
+func (m *mockStorage) Delete(_ context.Context, _, claim string) error {
+	m.deleted = true
+	m.claim = claim
+
+	return m.GivenDeleteError
+}
+
+func (m *mockStorage) DeleteExpired(_ context.Context, _ string, _ time.Time) error {
+	m.deleted = true
+
+	return m.GivenDeleteError
+}
@@ -0,0 +1,26 @@
+package idempotency
+
+import (
+	"time"
+)
+
+// Key is a domain model.
+// A Key records the response to the first request made with it so a retry of the request gets the same response.
+// Headers holds the response headers replayed along with the body.
+// Claim identifies the request holding the Key, so a request whose lease ran out can neither record its response over
+// nor release a Key another request has since taken over.
+type Key struct {
+	ID          string
+	Claim       string
+	Fingerprint string
+	Status      int
+	ContentType string
+	Headers     map[string]string
+	Body        []byte
+	ExpiresAt   time.Time
+}
+
+// Done reports whether the response to the first request made with the Key has been recorded.
+func (k Key) Done() bool {
+	return k.Status != 0
+}
@@ -0,0 +1,98 @@
+package ledger
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/clarke94/roulette-service/internal/pkg/apperror"
+	"github.com/clarke94/roulette-service/internal/pkg/page"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+)
+
+var (
+	ErrRecord = apperror.New(apperror.Internal, "unable to record ledger entry")
+	ErrList   = apperror.New(apperror.Internal, "unable to fetch ledger entries")
+
+	errEntry = errors.New("entry does not balance")
+)
+
+// StorageProvider provides an interface to the Storage layer.
+type StorageProvider interface {
+	Create(ctx context.Context, entries []Entry) error
+	List(ctx context.Context, filter Filter) ([]Entry, string, error)
+}
+
+// Controller provides a domain controller.
+type Controller struct {
+	Logger  *logrus.Logger
+	Storage StorageProvider
+}
+
+// New initializes a new Controller.
+func New(logger *logrus.Logger, storage StorageProvider) Controller {
+	return Controller{
+		Logger:  logger,
+		Storage: storage,
+	}
+}
+
+// Record appends the entries to the ledger, skipping any that move nothing.
+// Entries recorded with a context holding a transaction are only kept if the transaction commits.
+func (c Controller) Record(ctx context.Context, entries ...Entry) error {
+	now := time.Now().UTC()
+	recorded := make([]Entry, 0, len(entries))
+
+	for _, e := range entries {
+		if e.Amount == 0 {
+			continue
+		}
+
+		if e.Amount < 0 || e.DebitAccount == e.CreditAccount {
+			c.Logger.WithFields(logrus.Fields{
+				"error": errEntry.Error(),
+				"type":  e.Type,
+				"betId": e.BetID,
+			}).Error(ErrRecord.Error())
+
+			return ErrRecord
+		}
+
+		e.ID = uuid.New().String()
+		e.CreatedAt = now
+		recorded = append(recorded, e)
+	}
+
+	if len(recorded) == 0 {
+		return nil
+	}
+
+	if err := c.Storage.Create(ctx, recorded); err != nil {
+		c.Logger.WithFields(logrus.Fields{
+			"error": err.Error(),
+		}).Error(ErrRecord.Error())
+
+		return ErrRecord
+	}
+
+	return nil
+}
+
+// List returns a page of ledger entries, oldest first, and the cursor of the next page.
+func (c Controller) List(ctx context.Context, filter Filter) ([]Entry, string, error) {
+	entries, next, err := c.Storage.List(ctx, filter)
+	if errors.Is(err, page.ErrCursor) {
+		return []Entry{}, "", page.ErrCursor
+	}
+
+	if err != nil {
+		c.Logger.WithFields(logrus.Fields{
+			"error": err.Error(),
+		}).Error(ErrList.Error())
+
+		return []Entry{}, "", ErrList
+	}
+
+	return entries, next, nil
+}
@@ -0,0 +1,200 @@
+package ledger
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/clarke94/roulette-service/internal/pkg/page"
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/sirupsen/logrus"
+)
+
+func TestNew(t *testing.T) {
+	tests := []struct {
+		name string
+		want Controller
+	}{
+		{
+			name: "expect Controller to init",
+			want: Controller{
+				Storage: &mockStorage{},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := New(logrus.New(), &mockStorage{})
+			if !cmp.Equal(got, tt.want, cmpopts.IgnoreFields(Controller{}, "Logger"), cmp.AllowUnexported(mockStorage{})) {
+				t.Error(cmp.Diff(got, tt.want, cmpopts.IgnoreFields(Controller{}, "Logger"), cmp.AllowUnexported(mockStorage{})))
+			}
+		})
+	}
+}
+
+func TestController_Record(t *testing.T) {
+	stake := Entry{
+		Type:          TypeStakeReserved,
+		BetID:         "a",
+		RoundID:       "b",
+		TableID:       "c",
+		PlayerID:      "d",
+		Currency:      "GBP",
+		DebitAccount:  AccountReserved,
+		CreditAccount: AccountAvailable,
+		Amount:        100,
+	}
+	winnings := Entry{
+		Type:          TypeWinningsPaid,
+		BetID:         "a",
+		RoundID:       "b",
+		TableID:       "c",
+		PlayerID:      "d",
+		Currency:      "GBP",
+		DebitAccount:  AccountAvailable,
+		CreditAccount: AccountHouse,
+	}
+
+	tests := []struct {
+		name    string
+		Storage *mockStorage
+		entries []Entry
+		want    []Entry
+		wantErr error
+	}{
+		{
+			name:    "expect entries recorded given balanced entries",
+			Storage: &mockStorage{},
+			entries: []Entry{stake, stake},
+			want:    []Entry{stake, stake},
+			wantErr: nil,
+		},
+		{
+			name:    "expect zero amount skipped",
+			Storage: &mockStorage{},
+			entries: []Entry{stake, winnings},
+			want:    []Entry{stake},
+			wantErr: nil,
+		},
+		{
+			name:    "expect nothing stored given only zero amounts",
+			Storage: &mockStorage{},
+			entries: []Entry{winnings},
+			want:    nil,
+			wantErr: nil,
+		},
+		{
+			name:    "expect fail given negative amount",
+			Storage: &mockStorage{},
+			entries: []Entry{{Type: TypeStakeLost, DebitAccount: AccountHouse, CreditAccount: AccountReserved, Amount: -1}},
+			want:    nil,
+			wantErr: ErrRecord,
+		},
+		{
+			name:    "expect fail given same debit and credit account",
+			Storage: &mockStorage{},
+			entries: []Entry{{Type: TypeStakeLost, DebitAccount: AccountHouse, CreditAccount: AccountHouse, Amount: 1}},
+			want:    nil,
+			wantErr: ErrRecord,
+		},
+		{
+			name:    "expect fail given storage error",
+			Storage: &mockStorage{GivenError: errors.New("foo")},
+			entries: []Entry{stake},
+			want:    []Entry{stake},
+			wantErr: ErrRecord,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := New(logrus.New(), tt.Storage)
+
+			err := c.Record(context.Background(), tt.entries...)
+			if !cmp.Equal(err, tt.wantErr, cmpopts.EquateErrors()) {
+				t.Error(cmp.Diff(err, tt.wantErr, cmpopts.EquateErrors()))
+			}
+
+			for _, e := range tt.Storage.created {
+				if e.ID == "" || e.CreatedAt.IsZero() {
+					t.Error("expected entry to be given an ID and time")
+				}
+			}
+
+			if !cmp.Equal(tt.Storage.created, tt.want, cmpopts.IgnoreFields(Entry{}, "ID", "CreatedAt")) {
+				t.Error(cmp.Diff(tt.Storage.created, tt.want, cmpopts.IgnoreFields(Entry{}, "ID", "CreatedAt")))
+			}
+		})
+	}
+}
+
+func TestController_List(t *testing.T) {
+	tests := []struct {
+		name     string
+		Storage  *mockStorage
+		want     []Entry
+		wantNext string
+		wantErr  error
+	}{
+		{
+			name: "expect entries given storage success",
+			Storage: &mockStorage{
+				GivenList: []Entry{{ID: "foo", Amount: 100}},
+				GivenNext: "bar",
+			},
+			want:     []Entry{{ID: "foo", Amount: 100}},
+			wantNext: "bar",
+			wantErr:  nil,
+		},
+		{
+			name:     "expect fail given invalid cursor",
+			Storage:  &mockStorage{GivenError: page.ErrCursor},
+			want:     []Entry{},
+			wantNext: "",
+			wantErr:  page.ErrCursor,
+		},
+		{
+			name:     "expect fail given storage error",
+			Storage:  &mockStorage{GivenError: errors.New("foo")},
+			want:     []Entry{},
+			wantNext: "",
+			wantErr:  ErrList,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := New(logrus.New(), tt.Storage)
+
+			got, next, err := c.List(context.Background(), Filter{})
+			if !cmp.Equal(err, tt.wantErr, cmpopts.EquateErrors()) {
+				t.Error(cmp.Diff(err, tt.wantErr, cmpopts.EquateErrors()))
+			}
+
+			if !cmp.Equal(got, tt.want) {
+				t.Error(cmp.Diff(got, tt.want))
+			}
+
+			if !cmp.Equal(next, tt.wantNext) {
+				t.Error(cmp.Diff(next, tt.wantNext))
+			}
+		})
+	}
+}
+
+type mockStorage struct {
+	GivenList  []Entry
+	GivenNext  string
+	GivenError error
+
+	created []Entry
+}
+
+func (m *mockStorage) Create(_ context.Context, entries []Entry) error {
+	m.created = append(m.created, entries...)
+
+	return m.GivenError
+}
+
+func (m *mockStorage) List(_ context.Context, _ Filter) ([]Entry, string, error) {
+	return m.GivenList, m.GivenNext, m.GivenError
+}
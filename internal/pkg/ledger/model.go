@@ -0,0 +1,68 @@
+package ledger
+
+import (
+	"time"
+
+	"github.com/clarke94/roulette-service/internal/pkg/page"
+)
+
+// Entry is a domain model.
+// An Entry is an immutable double-entry record of Amount moving out of the CreditAccount and into the DebitAccount,
+// so every Entry balances and the accounts of the ledger always sum to zero. All amounts are in the smallest currency
+// unit.
+type Entry struct {
+	ID            string
+	Type          string
+	BetID         string
+	RoundID       string
+	TableID       string
+	PlayerID      string
+	Currency      string
+	DebitAccount  string
+	CreditAccount string
+	Amount        int64
+	CreatedAt     time.Time
+}
+
+// Filter narrows down the entries returned from the ledger.
+type Filter struct {
+	page.Page
+	PlayerID string
+	TableID  string
+	RoundID  string
+	BetID    string
+	Type     string
+	Currency string
+	From     time.Time
+	To       time.Time
+}
+
+// Type is the money movement an Entry records.
+const (
+	// TypeStakeReserved holds the stake of a bet placed against the available balance of the player.
+	TypeStakeReserved = "stake-reserved"
+	// TypeStakeRefunded returns the stake of a bet that is changed or cancelled to the available balance.
+	TypeStakeRefunded = "stake-refunded"
+	// TypeStakeLost takes the stake, or the part of it that is not returned, of a settled bet to the house.
+	TypeStakeLost = "stake-lost"
+	// TypeStakeReturned returns the stake, or the part of it that is returned, of a settled bet to the player.
+	TypeStakeReturned = "stake-returned"
+	// TypeWinningsPaid pays the winnings of a settled bet from the house to the player.
+	TypeWinningsPaid = "winnings-paid"
+	// TypeOpeningBalance funds the available balance of a new wallet from outside the ledger.
+	TypeOpeningBalance = "opening-balance"
+	// TypeDeposit adds funds from outside the ledger to the available balance of a wallet.
+	TypeDeposit = "deposit"
+)
+
+// Account is an account money moves between.
+const (
+	// AccountAvailable is the balance of a player that is free to bet with.
+	AccountAvailable = "player-available"
+	// AccountReserved is the balance of a player held against their open bets.
+	AccountReserved = "player-reserved"
+	// AccountHouse is the house that takes lost stakes and pays out winnings.
+	AccountHouse = "house"
+	// AccountExternal is where funds deposited into a wallet come from outside the ledger.
+	AccountExternal = "external"
+)
@@ -2,16 +2,16 @@ package openapi
 
 import (
 	"embed"
-	"errors"
 
+	"github.com/clarke94/roulette-service/internal/pkg/apperror"
 	"github.com/sirupsen/logrus"
 )
 
 var (
 	// ErrDocumentation is the external error for unable to read html file.
-	ErrDocumentation = errors.New("unable to render documentation")
+	ErrDocumentation = apperror.New(apperror.Internal, "unable to render documentation")
 	// ErrSpecification is the external error for unable to read swagger spec file.
-	ErrSpecification = errors.New("unable to render specification")
+	ErrSpecification = apperror.New(apperror.Internal, "unable to render specification")
 )
 
 //go:embed docs/*
@@ -0,0 +1,76 @@
+// Package page provides cursor based pagination for list endpoints.
+package page
+
+import (
+	"encoding/base64"
+	"strings"
+	"time"
+
+	"github.com/clarke94/roulette-service/internal/pkg/apperror"
+)
+
+const (
+	// DefaultLimit is the page size used when no limit is requested.
+	DefaultLimit = 50
+	// MaxLimit is the largest page size that can be requested.
+	MaxLimit = 100
+
+	cursorSeparator = "|"
+)
+
+// Order is the direction a list is sorted in by its sort key.
+const (
+	// OrderAsc sorts a list oldest first.
+	OrderAsc = "asc"
+	// OrderDesc sorts a list newest first.
+	OrderDesc = "desc"
+)
+
+// ErrCursor is returned when a cursor cannot be decoded.
+var ErrCursor = apperror.New(apperror.Validation, "invalid cursor")
+
+// Page is a request for a single page of a list.
+type Page struct {
+	Limit  int
+	Cursor string
+}
+
+// Size returns the limit of the page bounded by DefaultLimit and MaxLimit.
+func (p Page) Size() int {
+	if p.Limit <= 0 {
+		return DefaultLimit
+	}
+
+	if p.Limit > MaxLimit {
+		return MaxLimit
+	}
+
+	return p.Limit
+}
+
+// Encode returns an opaque cursor that points at the record with the given sort key and ID.
+func Encode(key time.Time, id string) string {
+	raw := key.UTC().Format(time.RFC3339Nano) + cursorSeparator + id
+
+	return base64.RawURLEncoding.EncodeToString([]byte(raw))
+}
+
+// Decode returns the sort key and ID that the given cursor points at.
+func Decode(cursor string) (time.Time, string, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return time.Time{}, "", ErrCursor
+	}
+
+	parts := strings.SplitN(string(raw), cursorSeparator, 2)
+	if len(parts) != 2 || parts[1] == "" {
+		return time.Time{}, "", ErrCursor
+	}
+
+	key, err := time.Parse(time.RFC3339Nano, parts[0])
+	if err != nil {
+		return time.Time{}, "", ErrCursor
+	}
+
+	return key, parts[1], nil
+}
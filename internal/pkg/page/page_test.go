@@ -0,0 +1,89 @@
+package page
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+)
+
+func TestPage_Size(t *testing.T) {
+	tests := []struct {
+		name string
+		page Page
+		want int
+	}{
+		{
+			name: "expect default given no limit",
+			page: Page{},
+			want: DefaultLimit,
+		},
+		{
+			name: "expect limit given limit in range",
+			page: Page{Limit: 10},
+			want: 10,
+		},
+		{
+			name: "expect max given limit too large",
+			page: Page{Limit: 1000},
+			want: MaxLimit,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := tt.page.Size()
+
+			if !cmp.Equal(got, tt.want) {
+				t.Error(cmp.Diff(got, tt.want))
+			}
+		})
+	}
+}
+
+func TestDecode(t *testing.T) {
+	key := time.Date(2021, 6, 21, 21, 4, 0, 123, time.UTC)
+
+	tests := []struct {
+		name    string
+		cursor  string
+		wantKey time.Time
+		wantID  string
+		wantErr error
+	}{
+		{
+			name:    "expect key and id given encoded cursor",
+			cursor:  Encode(key, "8117bb87-148c-4fb1-8971-a2d4373b3f19"),
+			wantKey: key,
+			wantID:  "8117bb87-148c-4fb1-8971-a2d4373b3f19",
+			wantErr: nil,
+		},
+		{
+			name:    "expect fail given invalid base64",
+			cursor:  "%%%",
+			wantErr: ErrCursor,
+		},
+		{
+			name:    "expect fail given cursor without id",
+			cursor:  Encode(key, ""),
+			wantErr: ErrCursor,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gotKey, gotID, err := Decode(tt.cursor)
+
+			if !cmp.Equal(err, tt.wantErr, cmpopts.EquateErrors()) {
+				t.Fatal(cmp.Diff(err, tt.wantErr, cmpopts.EquateErrors()))
+			}
+
+			if !gotKey.Equal(tt.wantKey) {
+				t.Error(gotKey, tt.wantKey)
+			}
+
+			if !cmp.Equal(gotID, tt.wantID) {
+				t.Error(cmp.Diff(gotID, tt.wantID))
+			}
+		})
+	}
+}
@@ -0,0 +1,120 @@
+// Package rng provides the sources of random numbers used to spin a roulette wheel.
+package rng
+
+import (
+	"crypto/rand"
+	"math/big"
+	mathrand "math/rand"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/clarke94/roulette-service/internal/pkg/apperror"
+)
+
+var (
+	ErrSource   = apperror.New(apperror.Internal, "random number source is not supported")
+	ErrOutcomes = apperror.New(apperror.Internal, "scripted outcomes are not valid")
+	ErrOutcome  = apperror.New(apperror.Internal, "scripted outcome is out of range")
+)
+
+// Source is the name of a supported source of random numbers.
+const (
+	// SourceCrypto draws from the cryptographically secure generator of the operating system.
+	SourceCrypto = "crypto"
+	// SourceSeeded draws a repeatable sequence from a seed.
+	SourceSeeded = "seeded"
+	// SourceScripted returns a fixed list of outcomes in order.
+	SourceScripted = "scripted"
+)
+
+// Crypto is a source of random numbers backed by crypto/rand, used in production.
+type Crypto struct{}
+
+// NewCrypto initializes a Crypto source.
+func NewCrypto() Crypto {
+	return Crypto{}
+}
+
+// Intn returns a uniformly random number in [0, n).
+func (c Crypto) Intn(n int) (int, error) {
+	number, err := rand.Int(rand.Reader, big.NewInt(int64(n)))
+	if err != nil {
+		return 0, err
+	}
+
+	return int(number.Int64()), nil
+}
+
+// Seeded is a deterministic source of random numbers that repeats the same sequence for the same seed.
+type Seeded struct {
+	mu   *sync.Mutex
+	rand *mathrand.Rand
+}
+
+// NewSeeded initializes a Seeded source from the given seed.
+func NewSeeded(seed int64) Seeded {
+	return Seeded{
+		mu:   &sync.Mutex{},
+		rand: mathrand.New(mathrand.NewSource(seed)), //nolint:gosec // Deterministic by design outside production.
+	}
+}
+
+// Intn returns the next number in [0, n) from the seeded sequence.
+func (s Seeded) Intn(n int) (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.rand.Intn(n), nil
+}
+
+// Scripted returns the given outcomes in order, starting again from the first once every outcome is returned.
+type Scripted struct {
+	mu       *sync.Mutex
+	next     *int
+	outcomes []int
+}
+
+// NewScripted initializes a Scripted source with the outcomes to return.
+func NewScripted(outcomes ...int) Scripted {
+	return Scripted{
+		mu:       &sync.Mutex{},
+		next:     new(int),
+		outcomes: outcomes,
+	}
+}
+
+// Intn returns the next scripted outcome, failing when it is not in [0, n).
+func (s Scripted) Intn(n int) (int, error) {
+	if len(s.outcomes) == 0 {
+		return 0, ErrOutcomes
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	outcome := s.outcomes[*s.next%len(s.outcomes)]
+	*s.next++
+
+	if outcome < 0 || outcome >= n {
+		return 0, ErrOutcome
+	}
+
+	return outcome, nil
+}
+
+// ParseOutcomes parses a comma separated list of outcomes, e.g. "17,0,32".
+func ParseOutcomes(value string) ([]int, error) {
+	var outcomes []int
+
+	for _, part := range strings.Split(value, ",") {
+		outcome, err := strconv.Atoi(strings.TrimSpace(part))
+		if err != nil || outcome < 0 {
+			return nil, ErrOutcomes
+		}
+
+		outcomes = append(outcomes, outcome)
+	}
+
+	return outcomes, nil
+}
@@ -0,0 +1,140 @@
+package rng
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+)
+
+func TestCrypto_Intn(t *testing.T) {
+	c := NewCrypto()
+
+	seen := make(map[int]bool)
+	for i := 0; i < 10000; i++ {
+		got, err := c.Intn(37)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		seen[got] = true
+	}
+
+	if !cmp.Equal(len(seen), 37) {
+		t.Error(cmp.Diff(len(seen), 37))
+	}
+}
+
+func TestSeeded_Intn(t *testing.T) {
+	draw := func(s Seeded) []int {
+		numbers := make([]int, 10)
+		for i := range numbers {
+			numbers[i], _ = s.Intn(37)
+		}
+
+		return numbers
+	}
+
+	first := draw(NewSeeded(42))
+	second := draw(NewSeeded(42))
+
+	if !cmp.Equal(first, second) {
+		t.Error(cmp.Diff(first, second))
+	}
+
+	if cmp.Equal(first, draw(NewSeeded(7))) {
+		t.Error("expect a different sequence given a different seed")
+	}
+}
+
+func TestScripted_Intn(t *testing.T) {
+	tests := []struct {
+		name     string
+		outcomes []int
+		n        int
+		want     []int
+		wantErr  error
+	}{
+		{
+			name:     "expect outcomes in order, starting again once returned",
+			outcomes: []int{17, 0, 32},
+			n:        37,
+			want:     []int{17, 0, 32, 17},
+			wantErr:  nil,
+		},
+		{
+			name:     "expect fail given outcome out of range",
+			outcomes: []int{37},
+			n:        37,
+			want:     []int{0},
+			wantErr:  ErrOutcome,
+		},
+		{
+			name:     "expect fail given no outcomes",
+			outcomes: nil,
+			n:        37,
+			want:     []int{0},
+			wantErr:  ErrOutcomes,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := NewScripted(tt.outcomes...)
+
+			got := make([]int, len(tt.want))
+
+			var err error
+			for i := range got {
+				got[i], err = s.Intn(tt.n)
+			}
+
+			if !cmp.Equal(err, tt.wantErr, cmpopts.EquateErrors()) {
+				t.Error(cmp.Diff(err, tt.wantErr, cmpopts.EquateErrors()))
+			}
+
+			if !cmp.Equal(got, tt.want) {
+				t.Error(cmp.Diff(got, tt.want))
+			}
+		})
+	}
+}
+
+func TestParseOutcomes(t *testing.T) {
+	tests := []struct {
+		name    string
+		value   string
+		want    []int
+		wantErr error
+	}{
+		{
+			name:    "expect outcomes given comma separated numbers",
+			value:   "17, 0,37",
+			want:    []int{17, 0, 37},
+			wantErr: nil,
+		},
+		{
+			name:    "expect fail given value that is not a number",
+			value:   "17,foo",
+			want:    nil,
+			wantErr: ErrOutcomes,
+		},
+		{
+			name:    "expect fail given negative number",
+			value:   "-1",
+			want:    nil,
+			wantErr: ErrOutcomes,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseOutcomes(tt.value)
+			if !cmp.Equal(err, tt.wantErr, cmpopts.EquateErrors()) {
+				t.Error(cmp.Diff(err, tt.wantErr, cmpopts.EquateErrors()))
+			}
+
+			if !cmp.Equal(got, tt.want) {
+				t.Error(cmp.Diff(got, tt.want))
+			}
+		})
+	}
+}
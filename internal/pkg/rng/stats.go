@@ -0,0 +1,169 @@
+package rng
+
+import (
+	"math"
+)
+
+const (
+	// gammaIterations is the most terms summed when evaluating the incomplete gamma function.
+	gammaIterations = 500
+	// gammaEpsilon is the relative accuracy the incomplete gamma function is evaluated to.
+	gammaEpsilon = 1e-15
+	// gammaTiny guards the continued fraction of the incomplete gamma function against dividing by zero.
+	gammaTiny = 1e-300
+)
+
+// Test is the outcome of a statistical test of a sequence of random numbers.
+// P is the probability of a statistic at least as extreme given the numbers are uniformly random.
+type Test struct {
+	Name      string
+	Statistic float64
+	P         float64
+}
+
+// Passed reports whether the Test fails to reject uniform randomness at the given significance level.
+func (t Test) Passed(alpha float64) bool {
+	return t.P >= alpha
+}
+
+// ChiSquare tests the observed count of each category against the proportion of draws expected to land in it.
+func ChiSquare(name string, observed []int64, proportions []float64) Test {
+	var total int64
+	for i := range observed {
+		total += observed[i]
+	}
+
+	statistic := 0.0
+
+	for i := range observed {
+		expected := float64(total) * proportions[i]
+		diff := float64(observed[i]) - expected
+		statistic += diff * diff / expected
+	}
+
+	return Test{
+		Name:      name,
+		Statistic: statistic,
+		P:         gammaQ(float64(len(observed)-1)/2, statistic/2),
+	}
+}
+
+// Runs counts the runs of each category in a sequence of draws for the Wald-Wolfowitz runs test, where a run is an
+// unbroken streak of draws either in or out of the category.
+type Runs struct {
+	previous int
+	total    int64
+	counts   []int64
+	changes  []int64
+}
+
+// NewRuns initializes Runs for the given number of categories.
+func NewRuns(categories int) *Runs {
+	return &Runs{
+		counts:  make([]int64, categories),
+		changes: make([]int64, categories),
+	}
+}
+
+// Add records the category of the next draw.
+func (r *Runs) Add(category int) {
+	if r.total > 0 && category != r.previous {
+		r.changes[r.previous]++
+		r.changes[category]++
+	}
+
+	r.counts[category]++
+	r.total++
+	r.previous = category
+}
+
+// Test returns the runs test of draws in and out of the category, rejecting a sequence with either too few runs, as
+// when draws cluster together, or too many, as when draws alternate.
+func (r *Runs) Test(name string, category int) Test {
+	in := float64(r.counts[category])
+	out := float64(r.total) - in
+	n := float64(r.total)
+
+	if in == 0 || out == 0 {
+		return Test{Name: name}
+	}
+
+	runs := float64(r.changes[category] + 1)
+	mean := 2*in*out/n + 1
+	variance := 2 * in * out * (2*in*out - n) / (n * n * (n - 1))
+	z := (runs - mean) / math.Sqrt(variance)
+
+	return Test{
+		Name:      name,
+		Statistic: z,
+		P:         math.Erfc(math.Abs(z) / math.Sqrt2),
+	}
+}
+
+// gammaQ returns the regularized upper incomplete gamma function Q(a, x), the upper tail of the chi-square
+// distribution with 2a degrees of freedom at 2x.
+func gammaQ(a, x float64) float64 {
+	if x <= 0 {
+		return 1
+	}
+
+	lgamma, _ := math.Lgamma(a)
+	prefix := math.Exp(-x + a*math.Log(x) - lgamma)
+
+	if x < a+1 {
+		return 1 - prefix*gammaSeries(a, x)
+	}
+
+	return prefix * gammaFraction(a, x)
+}
+
+// gammaSeries evaluates the series expansion of the lower incomplete gamma function, converging quickly when x < a+1.
+func gammaSeries(a, x float64) float64 {
+	term := 1 / a
+	sum := term
+
+	for n := 1; n < gammaIterations; n++ {
+		term *= x / (a + float64(n))
+		sum += term
+
+		if math.Abs(term) < math.Abs(sum)*gammaEpsilon {
+			break
+		}
+	}
+
+	return sum
+}
+
+// gammaFraction evaluates the continued fraction of the upper incomplete gamma function by the modified Lentz
+// method, converging quickly when x >= a+1.
+func gammaFraction(a, x float64) float64 {
+	b := x + 1 - a
+	c := 1 / gammaTiny
+	d := 1 / b
+	h := d
+
+	for n := 1; n < gammaIterations; n++ {
+		an := -float64(n) * (float64(n) - a)
+		b += 2
+
+		d = an*d + b
+		if math.Abs(d) < gammaTiny {
+			d = gammaTiny
+		}
+
+		c = b + an/c
+		if math.Abs(c) < gammaTiny {
+			c = gammaTiny
+		}
+
+		d = 1 / d
+		delta := d * c
+		h *= delta
+
+		if math.Abs(delta-1) < gammaEpsilon {
+			break
+		}
+	}
+
+	return h
+}
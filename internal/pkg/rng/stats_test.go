@@ -0,0 +1,132 @@
+package rng
+
+import (
+	"math"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+)
+
+func TestChiSquare(t *testing.T) {
+	tests := []struct {
+		name        string
+		observed    []int64
+		proportions []float64
+		want        Test
+	}{
+		{
+			name:        "expect p of 1 given observed counts that match expected",
+			observed:    []int64{25, 25, 25, 25},
+			proportions: []float64{0.25, 0.25, 0.25, 0.25},
+			want:        Test{Name: "foo", Statistic: 0, P: 1},
+		},
+		{
+			name:        "expect p of 0.05 given the critical value for one degree of freedom",
+			observed:    []int64{5098, 4902},
+			proportions: []float64{0.5, 0.5},
+			want:        Test{Name: "foo", Statistic: 3.8416, P: 0.05},
+		},
+		{
+			name:        "expect p of 1 given observed counts that match uneven proportions",
+			observed:    []int64{10, 20, 30},
+			proportions: []float64{1.0 / 6, 2.0 / 6, 3.0 / 6},
+			want:        Test{Name: "foo", Statistic: 0, P: 1},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := ChiSquare("foo", tt.observed, tt.proportions)
+
+			approx := cmpopts.EquateApprox(0.001, 1e-9)
+			if !cmp.Equal(got, tt.want, approx) {
+				t.Error(cmp.Diff(got, tt.want, approx))
+			}
+		})
+	}
+}
+
+func Test_gammaQ(t *testing.T) {
+	tests := []struct {
+		name    string
+		degrees float64
+		value   float64
+		want    float64
+	}{
+		{
+			name:    "expect 0.05 given critical value of 1 degree of freedom",
+			degrees: 1,
+			value:   3.841,
+			want:    0.05,
+		},
+		{
+			name:    "expect 0.01 given critical value of 36 degrees of freedom",
+			degrees: 36,
+			value:   58.619,
+			want:    0.01,
+		},
+		{
+			name:    "expect 0.5 given median of 37 degrees of freedom",
+			degrees: 37,
+			value:   36.335,
+			want:    0.5,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := gammaQ(tt.degrees/2, tt.value/2)
+			if math.Abs(got-tt.want) > 0.0005 {
+				t.Error(cmp.Diff(got, tt.want))
+			}
+		})
+	}
+}
+
+func TestRuns_Test(t *testing.T) {
+	tests := []struct {
+		name     string
+		draws    func(i int) int
+		category int
+		wantPass bool
+	}{
+		{
+			name: "expect pass given seeded draws",
+			draws: func() func(i int) int {
+				s := NewSeeded(42)
+
+				return func(_ int) int {
+					n, _ := s.Intn(2)
+
+					return n
+				}
+			}(),
+			category: 0,
+			wantPass: true,
+		},
+		{
+			name:     "expect fail given alternating draws",
+			draws:    func(i int) int { return i % 2 },
+			category: 0,
+			wantPass: false,
+		},
+		{
+			name:     "expect fail given clustered draws",
+			draws:    func(i int) int { return i / 5000 },
+			category: 0,
+			wantPass: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := NewRuns(2)
+			for i := 0; i < 10000; i++ {
+				r.Add(tt.draws(i))
+			}
+
+			got := r.Test("foo", tt.category)
+			if !cmp.Equal(got.Passed(0.01), tt.wantPass) {
+				t.Error(got)
+			}
+		})
+	}
+}
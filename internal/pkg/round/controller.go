@@ -0,0 +1,251 @@
+package round
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/clarke94/roulette-service/internal/pkg/apperror"
+	"github.com/clarke94/roulette-service/internal/pkg/fair"
+	"github.com/clarke94/roulette-service/internal/pkg/page"
+	"github.com/clarke94/roulette-service/internal/pkg/table"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+)
+
+var (
+	ErrCreate   = apperror.New(apperror.Internal, "unable to open round")
+	ErrCurrent  = apperror.New(apperror.Internal, "unable to fetch current round")
+	ErrList     = apperror.New(apperror.Internal, "unable to fetch all rounds")
+	ErrGet      = apperror.New(apperror.Internal, "unable to fetch round")
+	ErrUpdate   = apperror.New(apperror.Internal, "unable to update round")
+	ErrState    = apperror.New(apperror.Conflict, "round is not in the required state")
+	ErrNotFound = apperror.New(apperror.NotFound, "round not found")
+	ErrNotFair  = apperror.New(apperror.Conflict, "round was not spun provably fair")
+	ErrInPlay   = apperror.New(apperror.Conflict, "table already has a round in play")
+)
+
+// StorageProvider provides an interface to the Storage layer.
+type StorageProvider interface {
+	Create(ctx context.Context, model Round) (string, error)
+	Current(ctx context.Context, tableID string) (Round, error)
+	List(ctx context.Context, tableID string, filter Filter) ([]Round, string, error)
+	Get(ctx context.Context, tableID, id string) (Round, error)
+	Update(ctx context.Context, model Round) (string, error)
+}
+
+// TableProvider provides an interface to the tables rounds are played at.
+type TableProvider interface {
+	Get(ctx context.Context, id string) (table.Table, error)
+}
+
+// Controller provides a domain controller.
+type Controller struct {
+	Logger  *logrus.Logger
+	Storage StorageProvider
+	Tables  TableProvider
+}
+
+// New initializes a new Controller.
+func New(logger *logrus.Logger, storage StorageProvider, tables TableProvider) Controller {
+	return Controller{
+		Logger:  logger,
+		Storage: storage,
+		Tables:  tables,
+	}
+}
+
+// Open starts a new round for the given table that accepts bets, committing to the hash of a new server seed.
+// The round is provably fair when its table is as the round opens, so the commitment is published with the round
+// before any bet is placed on it.
+func (c Controller) Open(ctx context.Context, tableID string) (Round, error) {
+	t, err := c.Tables.Get(ctx, tableID)
+	if err != nil {
+		c.Logger.WithFields(logrus.Fields{
+			"error": err.Error(),
+		}).Error(ErrCreate.Error())
+
+		return Round{}, ErrCreate
+	}
+
+	seed, err := fair.NewSeed()
+	if err != nil {
+		c.Logger.WithFields(logrus.Fields{
+			"error": err.Error(),
+		}).Error(ErrCreate.Error())
+
+		return Round{}, ErrCreate
+	}
+
+	model := Round{
+		ID:             uuid.New().String(),
+		TableID:        tableID,
+		State:          StateOpen,
+		ServerSeed:     seed,
+		ServerSeedHash: fair.Hash(seed),
+		ProvablyFair:   t.ProvablyFair,
+		CreatedAt:      time.Now().UTC(),
+	}
+
+	_, err = c.Storage.Create(ctx, model)
+	if errors.Is(err, ErrInPlay) {
+		return Round{}, ErrInPlay
+	}
+
+	if err != nil {
+		c.Logger.WithFields(logrus.Fields{
+			"error": err.Error(),
+		}).Error(ErrCreate.Error())
+
+		return Round{}, ErrCreate
+	}
+
+	return model, nil
+}
+
+// Current returns the round in play for the given table, opening one if the table has none.
+// When a concurrent caller opens the round first, the round it opened is returned instead.
+func (c Controller) Current(ctx context.Context, tableID string) (Round, error) {
+	model, err := c.Storage.Current(ctx, tableID)
+	if errors.Is(err, ErrNotFound) {
+		model, err = c.Open(ctx, tableID)
+		if !errors.Is(err, ErrInPlay) {
+			return model, err
+		}
+
+		model, err = c.Storage.Current(ctx, tableID)
+	}
+
+	if err != nil {
+		c.Logger.WithFields(logrus.Fields{
+			"error": err.Error(),
+		}).Error(ErrCurrent.Error())
+
+		return Round{}, ErrCurrent
+	}
+
+	return model, nil
+}
+
+// Find returns the round in play for the given table without opening one, failing with ErrNotFound when the table has
+// none.
+func (c Controller) Find(ctx context.Context, tableID string) (Round, error) {
+	model, err := c.Storage.Current(ctx, tableID)
+	if errors.Is(err, ErrNotFound) {
+		return Round{}, ErrNotFound
+	}
+
+	if err != nil {
+		c.Logger.WithFields(logrus.Fields{
+			"error": err.Error(),
+		}).Error(ErrCurrent.Error())
+
+		return Round{}, ErrCurrent
+	}
+
+	return model, nil
+}
+
+// List returns a page of rounds for the given table, newest first, and the cursor of the next page.
+func (c Controller) List(ctx context.Context, tableID string, filter Filter) ([]Round, string, error) {
+	rounds, next, err := c.Storage.List(ctx, tableID, filter)
+	if errors.Is(err, page.ErrCursor) {
+		return []Round{}, "", page.ErrCursor
+	}
+
+	if err != nil {
+		c.Logger.WithFields(logrus.Fields{
+			"error": err.Error(),
+		}).Error(ErrList.Error())
+
+		return []Round{}, "", ErrList
+	}
+
+	return rounds, next, nil
+}
+
+// Get returns a single round for the given table.
+func (c Controller) Get(ctx context.Context, tableID, id string) (Round, error) {
+	model, err := c.Storage.Get(ctx, tableID, id)
+	if errors.Is(err, ErrNotFound) {
+		return Round{}, ErrNotFound
+	}
+
+	if err != nil {
+		c.Logger.WithFields(logrus.Fields{
+			"error": err.Error(),
+		}).Error(ErrGet.Error())
+
+		return Round{}, ErrGet
+	}
+
+	return model, nil
+}
+
+// Verify recomputes the outcome of a settled provably fair round from its seeds.
+func (c Controller) Verify(ctx context.Context, tableID, id string) (Verification, error) {
+	model, err := c.Get(ctx, tableID, id)
+	if err != nil {
+		return Verification{}, err
+	}
+
+	if !model.ProvablyFair {
+		return Verification{}, ErrNotFair
+	}
+
+	if model.State != StateSettled {
+		return Verification{}, ErrState
+	}
+
+	number := fair.Outcome(model.ServerSeed, model.ClientSeed, model.Nonce, table.Pockets(model.Wheel))
+
+	return Verification{
+		RoundID:        model.ID,
+		ServerSeed:     model.ServerSeed,
+		ServerSeedHash: model.ServerSeedHash,
+		ClientSeed:     model.ClientSeed,
+		Nonce:          model.Nonce,
+		Wheel:          model.Wheel,
+		Number:         number,
+		HashMatches:    fair.Hash(model.ServerSeed) == model.ServerSeedHash,
+		Matches:        number == model.Number,
+	}, nil
+}
+
+// Close stops an open round from accepting any more bets.
+func (c Controller) Close(ctx context.Context, model Round) (Round, error) {
+	return c.transition(ctx, model, StateOpen, StateClosed)
+}
+
+// Spin records the result of a closed round.
+func (c Controller) Spin(ctx context.Context, model Round, number int, color string) (Round, error) {
+	model.Number = number
+	model.Color = color
+	model.SpunAt = time.Now().UTC()
+
+	return c.transition(ctx, model, StateClosed, StateSpun)
+}
+
+// Settle records the winners and totals of a spun round and marks it as paid out.
+func (c Controller) Settle(ctx context.Context, model Round) (Round, error) {
+	return c.transition(ctx, model, StateSpun, StateSettled)
+}
+
+func (c Controller) transition(ctx context.Context, model Round, from, to string) (Round, error) {
+	if model.State != from {
+		return Round{}, ErrState
+	}
+
+	model.State = to
+
+	_, err := c.Storage.Update(ctx, model)
+	if err != nil {
+		c.Logger.WithFields(logrus.Fields{
+			"error": err.Error(),
+		}).Error(ErrUpdate.Error())
+
+		return Round{}, ErrUpdate
+	}
+
+	return model, nil
+}
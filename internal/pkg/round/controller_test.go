@@ -0,0 +1,662 @@
+package round
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/clarke94/roulette-service/internal/pkg/fair"
+	"github.com/clarke94/roulette-service/internal/pkg/page"
+	"github.com/clarke94/roulette-service/internal/pkg/table"
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+)
+
+func TestNew(t *testing.T) {
+	tests := []struct {
+		name string
+		want Controller
+	}{
+		{
+			name: "expect Controller to init",
+			want: Controller{
+				Storage: mockStorage{},
+				Tables:  mockTables{},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := New(logrus.New(), mockStorage{}, mockTables{})
+			if !cmp.Equal(got, tt.want, cmpopts.IgnoreFields(Controller{}, "Logger")) {
+				t.Error(cmp.Diff(got, tt.want, cmpopts.IgnoreFields(Controller{}, "Logger")))
+			}
+		})
+	}
+}
+
+func TestController_Open(t *testing.T) {
+	tests := []struct {
+		name    string
+		Storage StorageProvider
+		Tables  TableProvider
+		tableID string
+		want    Round
+		wantErr error
+	}{
+		{
+			name:    "expect open round given valid table",
+			Storage: mockStorage{},
+			Tables:  mockTables{},
+			tableID: "8117bb87-148c-4fb1-8971-a2d4373b3f19",
+			want: Round{
+				TableID: "8117bb87-148c-4fb1-8971-a2d4373b3f19",
+				State:   StateOpen,
+			},
+			wantErr: nil,
+		},
+		{
+			name:    "expect provably fair round given provably fair table",
+			Storage: mockStorage{},
+			Tables:  mockTables{GivenTable: table.Table{ProvablyFair: true}},
+			tableID: "8117bb87-148c-4fb1-8971-a2d4373b3f19",
+			want: Round{
+				TableID:      "8117bb87-148c-4fb1-8971-a2d4373b3f19",
+				State:        StateOpen,
+				ProvablyFair: true,
+			},
+			wantErr: nil,
+		},
+		{
+			name:    "expect fail given table error",
+			Storage: mockStorage{},
+			Tables:  mockTables{GivenError: table.ErrNotFound},
+			tableID: "8117bb87-148c-4fb1-8971-a2d4373b3f19",
+			want:    Round{},
+			wantErr: ErrCreate,
+		},
+		{
+			name: "expect fail given storage error",
+			Storage: mockStorage{
+				GivenError: errors.New("foo"),
+			},
+			Tables:  mockTables{},
+			tableID: "8117bb87-148c-4fb1-8971-a2d4373b3f19",
+			want:    Round{},
+			wantErr: ErrCreate,
+		},
+		{
+			name: "expect fail given round in play",
+			Storage: mockStorage{
+				GivenError: ErrInPlay,
+			},
+			Tables:  mockTables{},
+			tableID: "8117bb87-148c-4fb1-8971-a2d4373b3f19",
+			want:    Round{},
+			wantErr: ErrInPlay,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := New(logrus.New(), tt.Storage, tt.Tables)
+
+			got, err := c.Open(context.Background(), tt.tableID)
+			if !cmp.Equal(err, tt.wantErr, cmpopts.EquateErrors()) {
+				t.Error(cmp.Diff(err, tt.wantErr, cmpopts.EquateErrors()))
+			}
+
+			if err == nil && !cmp.Equal(got.ServerSeedHash, fair.Hash(got.ServerSeed)) {
+				t.Error("expect server seed hash to commit to the server seed")
+			}
+
+			ignore := cmpopts.IgnoreFields(Round{}, "ID", "ServerSeed", "ServerSeedHash", "CreatedAt")
+			if !cmp.Equal(got, tt.want, ignore) {
+				t.Error(cmp.Diff(got, tt.want, ignore))
+			}
+		})
+	}
+}
+
+func TestController_Current(t *testing.T) {
+	tests := []struct {
+		name    string
+		Storage StorageProvider
+		tableID string
+		want    Round
+		wantErr error
+	}{
+		{
+			name: "expect current round given round in play",
+			Storage: mockStorage{
+				GivenRound: Round{
+					ID:      "8117bb87-148c-4fb1-8971-a2d4373b3f19",
+					TableID: "8117bb87-148c-4fb1-8971-a2d4373b3f19",
+					State:   StateClosed,
+				},
+			},
+			tableID: "8117bb87-148c-4fb1-8971-a2d4373b3f19",
+			want: Round{
+				ID:      "8117bb87-148c-4fb1-8971-a2d4373b3f19",
+				TableID: "8117bb87-148c-4fb1-8971-a2d4373b3f19",
+				State:   StateClosed,
+			},
+			wantErr: nil,
+		},
+		{
+			name: "expect new open round given table without a round",
+			Storage: mockStorage{
+				GivenCurrentError: ErrNotFound,
+			},
+			tableID: "8117bb87-148c-4fb1-8971-a2d4373b3f19",
+			want: Round{
+				TableID: "8117bb87-148c-4fb1-8971-a2d4373b3f19",
+				State:   StateOpen,
+			},
+			wantErr: nil,
+		},
+		{
+			name: "expect round opened concurrently given round in play when opening",
+			Storage: &mockOpened{
+				mockStorage: mockStorage{
+					GivenRound: Round{
+						ID:      "8117bb87-148c-4fb1-8971-a2d4373b3f19",
+						TableID: "8117bb87-148c-4fb1-8971-a2d4373b3f19",
+						State:   StateOpen,
+					},
+					GivenError: ErrInPlay,
+				},
+			},
+			tableID: "8117bb87-148c-4fb1-8971-a2d4373b3f19",
+			want: Round{
+				ID:      "8117bb87-148c-4fb1-8971-a2d4373b3f19",
+				TableID: "8117bb87-148c-4fb1-8971-a2d4373b3f19",
+				State:   StateOpen,
+			},
+			wantErr: nil,
+		},
+		{
+			name: "expect fail given storage error",
+			Storage: mockStorage{
+				GivenCurrentError: errors.New("foo"),
+			},
+			tableID: "8117bb87-148c-4fb1-8971-a2d4373b3f19",
+			want:    Round{},
+			wantErr: ErrCurrent,
+		},
+		{
+			name: "expect fail given open error",
+			Storage: mockStorage{
+				GivenCurrentError: ErrNotFound,
+				GivenError:        errors.New("foo"),
+			},
+			tableID: "8117bb87-148c-4fb1-8971-a2d4373b3f19",
+			want:    Round{},
+			wantErr: ErrCreate,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := New(logrus.New(), tt.Storage, mockTables{})
+
+			got, err := c.Current(context.Background(), tt.tableID)
+			if !cmp.Equal(err, tt.wantErr, cmpopts.EquateErrors()) {
+				t.Error(cmp.Diff(err, tt.wantErr, cmpopts.EquateErrors()))
+			}
+
+			ignore := cmpopts.IgnoreFields(Round{}, "ID", "ServerSeed", "ServerSeedHash", "CreatedAt")
+			if !cmp.Equal(got, tt.want, ignore) {
+				t.Error(cmp.Diff(got, tt.want, ignore))
+			}
+		})
+	}
+}
+
+func TestController_Find(t *testing.T) {
+	tests := []struct {
+		name    string
+		Storage StorageProvider
+		tableID string
+		want    Round
+		wantErr error
+	}{
+		{
+			name: "expect current round given round in play",
+			Storage: mockStorage{
+				GivenRound: Round{
+					ID:      "8117bb87-148c-4fb1-8971-a2d4373b3f19",
+					TableID: "8117bb87-148c-4fb1-8971-a2d4373b3f19",
+					State:   StateOpen,
+				},
+			},
+			tableID: "8117bb87-148c-4fb1-8971-a2d4373b3f19",
+			want: Round{
+				ID:      "8117bb87-148c-4fb1-8971-a2d4373b3f19",
+				TableID: "8117bb87-148c-4fb1-8971-a2d4373b3f19",
+				State:   StateOpen,
+			},
+			wantErr: nil,
+		},
+		{
+			name: "expect ErrNotFound and no round opened given table without a round",
+			Storage: mockStorage{
+				GivenCurrentError: ErrNotFound,
+				GivenError:        errors.New("foo"),
+			},
+			tableID: "8117bb87-148c-4fb1-8971-a2d4373b3f19",
+			want:    Round{},
+			wantErr: ErrNotFound,
+		},
+		{
+			name: "expect fail given storage error",
+			Storage: mockStorage{
+				GivenCurrentError: errors.New("foo"),
+			},
+			tableID: "8117bb87-148c-4fb1-8971-a2d4373b3f19",
+			want:    Round{},
+			wantErr: ErrCurrent,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := New(logrus.New(), tt.Storage, mockTables{})
+
+			got, err := c.Find(context.Background(), tt.tableID)
+			if !cmp.Equal(err, tt.wantErr, cmpopts.EquateErrors()) {
+				t.Error(cmp.Diff(err, tt.wantErr, cmpopts.EquateErrors()))
+			}
+
+			if !cmp.Equal(got, tt.want) {
+				t.Error(cmp.Diff(got, tt.want))
+			}
+		})
+	}
+}
+
+func TestController_List(t *testing.T) {
+	tests := []struct {
+		name     string
+		Storage  StorageProvider
+		want     []Round
+		wantNext string
+		wantErr  error
+	}{
+		{
+			name: "expect rounds and next cursor given rounds found",
+			Storage: mockStorage{
+				GivenList: []Round{
+					{ID: "8117bb87-148c-4fb1-8971-a2d4373b3f19", State: StateSettled, Number: 17, Color: "black"},
+				},
+				GivenNext: "foo",
+			},
+			want: []Round{
+				{ID: "8117bb87-148c-4fb1-8971-a2d4373b3f19", State: StateSettled, Number: 17, Color: "black"},
+			},
+			wantNext: "foo",
+			wantErr:  nil,
+		},
+		{
+			name: "expect fail given storage error",
+			Storage: mockStorage{
+				GivenError: errors.New("foo"),
+			},
+			want:     []Round{},
+			wantNext: "",
+			wantErr:  ErrList,
+		},
+		{
+			name: "expect fail given invalid cursor",
+			Storage: mockStorage{
+				GivenError: page.ErrCursor,
+			},
+			want:     []Round{},
+			wantNext: "",
+			wantErr:  page.ErrCursor,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := New(logrus.New(), tt.Storage, mockTables{})
+
+			got, next, err := c.List(context.Background(), uuid.New().String(), Filter{})
+			if !cmp.Equal(err, tt.wantErr, cmpopts.EquateErrors()) {
+				t.Error(cmp.Diff(err, tt.wantErr, cmpopts.EquateErrors()))
+			}
+
+			if !cmp.Equal(got, tt.want) {
+				t.Error(cmp.Diff(got, tt.want))
+			}
+
+			if !cmp.Equal(next, tt.wantNext) {
+				t.Error(cmp.Diff(next, tt.wantNext))
+			}
+		})
+	}
+}
+
+func TestController_Get(t *testing.T) {
+	tests := []struct {
+		name    string
+		Storage StorageProvider
+		want    Round
+		wantErr error
+	}{
+		{
+			name: "expect round given round found",
+			Storage: mockStorage{
+				GivenRound: Round{ID: "8117bb87-148c-4fb1-8971-a2d4373b3f19", State: StateSettled},
+			},
+			want:    Round{ID: "8117bb87-148c-4fb1-8971-a2d4373b3f19", State: StateSettled},
+			wantErr: nil,
+		},
+		{
+			name: "expect not found given round doesnt exist",
+			Storage: mockStorage{
+				GivenError: ErrNotFound,
+			},
+			want:    Round{},
+			wantErr: ErrNotFound,
+		},
+		{
+			name: "expect fail given storage error",
+			Storage: mockStorage{
+				GivenError: errors.New("foo"),
+			},
+			want:    Round{},
+			wantErr: ErrGet,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := New(logrus.New(), tt.Storage, mockTables{})
+
+			got, err := c.Get(context.Background(), uuid.New().String(), uuid.New().String())
+			if !cmp.Equal(err, tt.wantErr, cmpopts.EquateErrors()) {
+				t.Error(cmp.Diff(err, tt.wantErr, cmpopts.EquateErrors()))
+			}
+
+			if !cmp.Equal(got, tt.want) {
+				t.Error(cmp.Diff(got, tt.want))
+			}
+		})
+	}
+}
+
+func TestController_Verify(t *testing.T) {
+	spun := Round{
+		ID:             "8117bb87-148c-4fb1-8971-a2d4373b3f19",
+		State:          StateSettled,
+		Number:         fair.Outcome("server", "client", 0, 37),
+		ServerSeed:     "server",
+		ServerSeedHash: fair.Hash("server"),
+		ClientSeed:     "client",
+		ProvablyFair:   true,
+		Wheel:          table.WheelEuropean,
+	}
+
+	tampered := spun
+	tampered.Number = (spun.Number + 1) % 37
+	tampered.ServerSeedHash = fair.Hash("other")
+
+	open := spun
+	open.State = StateOpen
+
+	tests := []struct {
+		name    string
+		Storage StorageProvider
+		want    Verification
+		wantErr error
+	}{
+		{
+			name:    "expect outcome to match given settled provably fair round",
+			Storage: mockStorage{GivenRound: spun},
+			want: Verification{
+				RoundID:        spun.ID,
+				ServerSeed:     "server",
+				ServerSeedHash: fair.Hash("server"),
+				ClientSeed:     "client",
+				Wheel:          table.WheelEuropean,
+				Number:         spun.Number,
+				HashMatches:    true,
+				Matches:        true,
+			},
+			wantErr: nil,
+		},
+		{
+			name:    "expect mismatch given number or commitment that differs from the seeds",
+			Storage: mockStorage{GivenRound: tampered},
+			want: Verification{
+				RoundID:        spun.ID,
+				ServerSeed:     "server",
+				ServerSeedHash: fair.Hash("other"),
+				ClientSeed:     "client",
+				Wheel:          table.WheelEuropean,
+				Number:         spun.Number,
+				HashMatches:    false,
+				Matches:        false,
+			},
+			wantErr: nil,
+		},
+		{
+			name:    "expect conflict given round not spun provably fair",
+			Storage: mockStorage{GivenRound: Round{ID: spun.ID, State: StateSettled}},
+			want:    Verification{},
+			wantErr: ErrNotFair,
+		},
+		{
+			name:    "expect conflict given round not yet settled",
+			Storage: mockStorage{GivenRound: open},
+			want:    Verification{},
+			wantErr: ErrState,
+		},
+		{
+			name:    "expect not found given round doesnt exist",
+			Storage: mockStorage{GivenError: ErrNotFound},
+			want:    Verification{},
+			wantErr: ErrNotFound,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := New(logrus.New(), tt.Storage, mockTables{})
+
+			got, err := c.Verify(context.Background(), uuid.New().String(), uuid.New().String())
+			if !cmp.Equal(err, tt.wantErr, cmpopts.EquateErrors()) {
+				t.Error(cmp.Diff(err, tt.wantErr, cmpopts.EquateErrors()))
+			}
+
+			if !cmp.Equal(got, tt.want) {
+				t.Error(cmp.Diff(got, tt.want))
+			}
+		})
+	}
+}
+
+func TestController_Close(t *testing.T) {
+	tests := []struct {
+		name    string
+		Storage StorageProvider
+		model   Round
+		want    Round
+		wantErr error
+	}{
+		{
+			name:    "expect closed round given open round",
+			Storage: mockStorage{},
+			model:   Round{ID: "8117bb87-148c-4fb1-8971-a2d4373b3f19", State: StateOpen},
+			want:    Round{ID: "8117bb87-148c-4fb1-8971-a2d4373b3f19", State: StateClosed},
+			wantErr: nil,
+		},
+		{
+			name:    "expect fail given round already closed",
+			Storage: mockStorage{},
+			model:   Round{ID: "8117bb87-148c-4fb1-8971-a2d4373b3f19", State: StateClosed},
+			want:    Round{},
+			wantErr: ErrState,
+		},
+		{
+			name: "expect fail given storage error",
+			Storage: mockStorage{
+				GivenError: errors.New("foo"),
+			},
+			model:   Round{ID: "8117bb87-148c-4fb1-8971-a2d4373b3f19", State: StateOpen},
+			want:    Round{},
+			wantErr: ErrUpdate,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := New(logrus.New(), tt.Storage, mockTables{})
+
+			got, err := c.Close(context.Background(), tt.model)
+			if !cmp.Equal(err, tt.wantErr, cmpopts.EquateErrors()) {
+				t.Error(cmp.Diff(err, tt.wantErr, cmpopts.EquateErrors()))
+			}
+
+			if !cmp.Equal(got, tt.want) {
+				t.Error(cmp.Diff(got, tt.want))
+			}
+		})
+	}
+}
+
+func TestController_Spin(t *testing.T) {
+	tests := []struct {
+		name    string
+		model   Round
+		number  int
+		color   string
+		want    Round
+		wantErr error
+	}{
+		{
+			name:    "expect spun round given closed round",
+			model:   Round{ID: "8117bb87-148c-4fb1-8971-a2d4373b3f19", State: StateClosed},
+			number:  17,
+			color:   "black",
+			want:    Round{ID: "8117bb87-148c-4fb1-8971-a2d4373b3f19", State: StateSpun, Number: 17, Color: "black"},
+			wantErr: nil,
+		},
+		{
+			name:    "expect fail given open round",
+			model:   Round{ID: "8117bb87-148c-4fb1-8971-a2d4373b3f19", State: StateOpen},
+			number:  17,
+			color:   "black",
+			want:    Round{},
+			wantErr: ErrState,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := New(logrus.New(), mockStorage{}, mockTables{})
+
+			got, err := c.Spin(context.Background(), tt.model, tt.number, tt.color)
+			if !cmp.Equal(err, tt.wantErr, cmpopts.EquateErrors()) {
+				t.Error(cmp.Diff(err, tt.wantErr, cmpopts.EquateErrors()))
+			}
+
+			if !cmp.Equal(got, tt.want, cmpopts.IgnoreFields(Round{}, "SpunAt")) {
+				t.Error(cmp.Diff(got, tt.want, cmpopts.IgnoreFields(Round{}, "SpunAt")))
+			}
+
+			if tt.wantErr == nil && got.SpunAt.IsZero() {
+				t.Error("expected spun at to be set")
+			}
+		})
+	}
+}
+
+func TestController_Settle(t *testing.T) {
+	tests := []struct {
+		name    string
+		model   Round
+		want    Round
+		wantErr error
+	}{
+		{
+			name:    "expect settled round given spun round",
+			model:   Round{ID: "8117bb87-148c-4fb1-8971-a2d4373b3f19", State: StateSpun, Number: 17},
+			want:    Round{ID: "8117bb87-148c-4fb1-8971-a2d4373b3f19", State: StateSettled, Number: 17},
+			wantErr: nil,
+		},
+		{
+			name:    "expect fail given closed round",
+			model:   Round{ID: uuid.New().String(), State: StateClosed},
+			want:    Round{},
+			wantErr: ErrState,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := New(logrus.New(), mockStorage{}, mockTables{})
+
+			got, err := c.Settle(context.Background(), tt.model)
+			if !cmp.Equal(err, tt.wantErr, cmpopts.EquateErrors()) {
+				t.Error(cmp.Diff(err, tt.wantErr, cmpopts.EquateErrors()))
+			}
+
+			if !cmp.Equal(got, tt.want) {
+				t.Error(cmp.Diff(got, tt.want))
+			}
+		})
+	}
+}
+
+type mockStorage struct {
+	GivenRound        Round
+	GivenList         []Round
+	GivenNext         string
+	GivenID           string
+	GivenError        error
+	GivenCurrentError error
+}
+
+func (m mockStorage) Create(_ context.Context, _ Round) (string, error) {
+	return m.GivenID, m.GivenError
+}
+
+func (m mockStorage) Current(_ context.Context, _ string) (Round, error) {
+	return m.GivenRound, m.GivenCurrentError
+}
+
+func (m mockStorage) List(_ context.Context, _ string, _ Filter) ([]Round, string, error) {
+	return m.GivenList, m.GivenNext, m.GivenError
+}
+
+func (m mockStorage) Get(_ context.Context, _, _ string) (Round, error) {
+	return m.GivenRound, m.GivenError
+}
+
+func (m mockStorage) Update(_ context.Context, _ Round) (string, error) {
+	return m.GivenID, m.GivenError
+}
+
+// mockOpened finds no round in play until it has tried to open one, as when a concurrent caller opens it first.
+type mockOpened struct {
+	mockStorage
+	opened bool
+}
+
+func (m *mockOpened) Create(ctx context.Context, model Round) (string, error) {
+	m.opened = true
+
+	return m.mockStorage.Create(ctx, model)
+}
+
+func (m *mockOpened) Current(ctx context.Context, tableID string) (Round, error) {
+	if !m.opened {
+		return Round{}, ErrNotFound
+	}
+
+	return m.mockStorage.Current(ctx, tableID)
+}
+
+type mockTables struct {
+	GivenTable table.Table
+	GivenError error
+}
+
+func (m mockTables) Get(_ context.Context, _ string) (table.Table, error) {
+	return m.GivenTable, m.GivenError
+}
@@ -0,0 +1,90 @@
+package round
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/clarke94/roulette-service/internal/pkg/page"
+)
+
+// Round is a domain model.
+// All amounts are in the smallest currency unit.
+// ServerSeedHash commits to the ServerSeed when the Round opens, the ServerSeed itself is only revealed once the Round
+// is settled. A ProvablyFair Round derives its Number from the ServerSeed, ClientSeed and Nonce spun on the Wheel.
+// The ClientSeed is given by the players betting on the Round and the Nonce is always 0, as no ServerSeed is used for
+// more than one Round.
+type Round struct {
+	ID             string
+	TableID        string
+	State          string
+	Number         int
+	Color          string
+	TotalStaked    int64
+	TotalPaid      int64
+	Winners        []Winner
+	ServerSeed     string
+	ServerSeedHash string
+	ClientSeed     string
+	Nonce          int64
+	ProvablyFair   bool
+	Wheel          string
+	SpunAt         time.Time
+	CreatedAt      time.Time
+}
+
+// Winner is a bet from a settled Round that returned any of its stake.
+type Winner struct {
+	BetID      string
+	Stake      int64
+	Multiplier int64
+	Winnings   int64
+	Return     int64
+	Currency   string
+	Outcome    string
+}
+
+// Verification is the outcome of a provably fair Round recomputed from its seeds.
+// HashMatches reports whether the revealed server seed hashes to the commitment published when the Round opened and
+// Matches reports whether the recomputed Number is the one spun.
+type Verification struct {
+	RoundID        string
+	ServerSeed     string
+	ServerSeedHash string
+	ClientSeed     string
+	Nonce          int64
+	Wheel          string
+	Number         int
+	HashMatches    bool
+	Matches        bool
+}
+
+// Filter narrows down the rounds returned for a table.
+type Filter struct {
+	page.Page
+	From time.Time
+	To   time.Time
+}
+
+// State is the lifecycle state of a Round.
+const (
+	// StateOpen accepts bets.
+	StateOpen = "open"
+	// StateClosed no longer accepts bets and is waiting to be spun.
+	StateClosed = "closed"
+	// StateSpun has a result and is waiting for its bets to be settled.
+	StateSpun = "spun"
+	// StateSettled has paid out all winning bets.
+	StateSettled = "settled"
+)
+
+// DoubleZero is the Number recorded for the 00 pocket of an American wheel.
+const DoubleZero = 37
+
+// Pocket returns the name of the pocket for the given number as it is printed on the wheel.
+func Pocket(number int) string {
+	if number == DoubleZero {
+		return "00"
+	}
+
+	return strconv.Itoa(number)
+}
@@ -0,0 +1,219 @@
+// Package scheduler plays the rounds of every table with a betting window on a timer.
+package scheduler
+
+import (
+	"context"
+	"time"
+
+	"github.com/clarke94/roulette-service/internal/pkg/apperror"
+	"github.com/clarke94/roulette-service/internal/pkg/bet"
+	"github.com/clarke94/roulette-service/internal/pkg/page"
+	"github.com/clarke94/roulette-service/internal/pkg/round"
+	"github.com/clarke94/roulette-service/internal/pkg/table"
+	"github.com/sirupsen/logrus"
+)
+
+// DefaultPoll is how often the Scheduler checks whether any table is due to close betting or spin.
+const DefaultPoll = time.Second
+
+var (
+	ErrList    = apperror.New(apperror.Internal, "unable to fetch scheduled tables")
+	ErrAdvance = apperror.New(apperror.Internal, "unable to advance scheduled round")
+)
+
+// TableProvider provides an interface to the table domain.
+type TableProvider interface {
+	List(ctx context.Context, filter table.Filter) ([]table.Table, string, error)
+}
+
+// RoundProvider provides an interface to the round domain.
+type RoundProvider interface {
+	Current(ctx context.Context, tableID string) (round.Round, error)
+}
+
+// GameProvider provides an interface to close betting on and play the round of a table.
+type GameProvider interface {
+	Close(ctx context.Context, model round.Round) (round.Round, error)
+	Play(ctx context.Context, tableID string) (bet.Result, error)
+}
+
+// Scheduler closes betting, spins, settles and opens the next round of every table with a betting window.
+// The time betting closed and the last spin of each table are kept in memory, so a restart begins the no more bets
+// countdown of a closed round again and does not hold the next spin back by the spin interval. Each is kept only
+// until the step it holds back is taken, and is forgotten once its table is no longer scheduled.
+type Scheduler struct {
+	Logger   *logrus.Logger
+	Tables   TableProvider
+	Rounds   RoundProvider
+	Games    GameProvider
+	Poll     time.Duration
+	Now      func() time.Time
+	closedAt map[string]time.Time
+	spunAt   map[string]time.Time
+}
+
+// New initializes a new Scheduler.
+func New(logger *logrus.Logger, tables TableProvider, rounds RoundProvider, games GameProvider) *Scheduler {
+	return &Scheduler{
+		Logger:   logger,
+		Tables:   tables,
+		Rounds:   rounds,
+		Games:    games,
+		Poll:     DefaultPoll,
+		Now:      time.Now,
+		closedAt: make(map[string]time.Time),
+		spunAt:   make(map[string]time.Time),
+	}
+}
+
+// Run advances the scheduled tables every poll until the context is done.
+// A step already in progress is not cancelled with the context, so shutting down never leaves a round half played.
+func (s *Scheduler) Run(ctx context.Context) {
+	ticker := time.NewTicker(s.Poll)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			s.Tick(context.Background())
+		}
+	}
+}
+
+// Tick advances every table with a betting window whose round is due to close betting or spin.
+func (s *Scheduler) Tick(ctx context.Context) {
+	tables, err := s.tables(ctx)
+	if err != nil {
+		s.Logger.WithFields(logrus.Fields{
+			"error": err.Error(),
+		}).Error(ErrList.Error())
+
+		return
+	}
+
+	s.forget(tables)
+
+	for i := range tables {
+		if tables[i].BettingWindow <= 0 {
+			continue
+		}
+
+		if err := s.advance(ctx, tables[i]); err != nil {
+			s.Logger.WithFields(logrus.Fields{
+				"table": tables[i].ID,
+				"error": err.Error(),
+			}).Error(ErrAdvance.Error())
+		}
+	}
+}
+
+// tables returns every table, reading them a page at a time.
+func (s *Scheduler) tables(ctx context.Context) ([]table.Table, error) {
+	var tables []table.Table
+
+	filter := table.Filter{Page: page.Page{Limit: page.MaxLimit}}
+
+	for {
+		p, next, err := s.Tables.List(ctx, filter)
+		if err != nil {
+			return nil, err
+		}
+
+		tables = append(tables, p...)
+
+		if next == "" {
+			return tables, nil
+		}
+
+		filter.Cursor = next
+	}
+}
+
+// advance closes betting on the current round of the table once its betting window is over, then plays the round
+// once the no more bets countdown is over.
+func (s *Scheduler) advance(ctx context.Context, t table.Table) error {
+	r, err := s.Rounds.Current(ctx, t.ID)
+	if err != nil {
+		return err
+	}
+
+	now := s.Now()
+
+	if r.State == round.StateOpen {
+		if now.Before(s.closesAt(t, r)) {
+			return nil
+		}
+
+		if _, err := s.Games.Close(ctx, r); err != nil {
+			return err
+		}
+
+		s.closedAt[t.ID] = now
+		delete(s.spunAt, t.ID)
+
+		return nil
+	}
+
+	closedAt, ok := s.closedAt[t.ID]
+	if !ok {
+		closedAt = now
+		s.closedAt[t.ID] = now
+	}
+
+	if now.Before(closedAt.Add(seconds(t.NoMoreBets))) {
+		return nil
+	}
+
+	if _, err := s.Games.Play(ctx, t.ID); err != nil {
+		return err
+	}
+
+	delete(s.closedAt, t.ID)
+	s.spunAt[t.ID] = now
+
+	return nil
+}
+
+// forget drops the times kept for the tables that are no longer scheduled, as they were deleted or their betting
+// window was removed.
+func (s *Scheduler) forget(tables []table.Table) {
+	scheduled := make(map[string]bool, len(tables))
+	for i := range tables {
+		if tables[i].BettingWindow > 0 {
+			scheduled[tables[i].ID] = true
+		}
+	}
+
+	for id := range s.closedAt {
+		if !scheduled[id] {
+			delete(s.closedAt, id)
+		}
+	}
+
+	for id := range s.spunAt {
+		if !scheduled[id] {
+			delete(s.spunAt, id)
+		}
+	}
+}
+
+// closesAt returns when betting closes on the round, once its betting window is over and late enough that the
+// following spin is at least the spin interval after the last.
+func (s *Scheduler) closesAt(t table.Table, r round.Round) time.Time {
+	closesAt := r.CreatedAt.Add(seconds(t.BettingWindow))
+
+	if spunAt, ok := s.spunAt[t.ID]; ok {
+		next := spunAt.Add(seconds(t.SpinInterval) - seconds(t.NoMoreBets))
+		if next.After(closesAt) {
+			return next
+		}
+	}
+
+	return closesAt
+}
+
+func seconds(n int) time.Duration {
+	return time.Duration(n) * time.Second
+}
@@ -0,0 +1,280 @@
+package scheduler
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/clarke94/roulette-service/internal/pkg/bet"
+	"github.com/clarke94/roulette-service/internal/pkg/round"
+	"github.com/clarke94/roulette-service/internal/pkg/table"
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/sirupsen/logrus"
+)
+
+var now = time.Date(2021, 6, 21, 21, 0, 0, 0, time.UTC)
+
+var liveTable = table.Table{
+	ID:            "8117bb87-148c-4fb1-8971-a2d4373b3f19",
+	BettingWindow: 30,
+	NoMoreBets:    5,
+	SpinInterval:  60,
+}
+
+func TestNew(t *testing.T) {
+	got := New(logrus.New(), mockTables{}, mockRounds{}, &mockGames{})
+
+	want := &Scheduler{
+		Tables:   mockTables{},
+		Rounds:   mockRounds{},
+		Games:    &mockGames{},
+		Poll:     DefaultPoll,
+		closedAt: map[string]time.Time{},
+		spunAt:   map[string]time.Time{},
+	}
+
+	opts := []cmp.Option{
+		cmpopts.IgnoreFields(Scheduler{}, "Logger", "Now"),
+		cmp.AllowUnexported(Scheduler{}, mockGames{}),
+	}
+	if !cmp.Equal(got, want, opts...) {
+		t.Error(cmp.Diff(got, want, opts...))
+	}
+}
+
+func TestScheduler_Tick(t *testing.T) {
+	tests := []struct {
+		name         string
+		tables       mockTables
+		round        round.Round
+		closedAt     map[string]time.Time
+		spunAt       map[string]time.Time
+		wantClosed   int
+		wantPlayed   int
+		wantClosedAt map[string]time.Time
+		wantSpunAt   map[string]time.Time
+	}{
+		{
+			name:         "expect betting open given betting window not over",
+			tables:       mockTables{GivenTables: []table.Table{liveTable}},
+			round:        round.Round{State: round.StateOpen, CreatedAt: now.Add(-29 * time.Second)},
+			wantClosedAt: map[string]time.Time{},
+		},
+		{
+			name:         "expect betting closed given betting window over",
+			tables:       mockTables{GivenTables: []table.Table{liveTable}},
+			round:        round.Round{State: round.StateOpen, CreatedAt: now.Add(-30 * time.Second)},
+			wantClosed:   1,
+			wantClosedAt: map[string]time.Time{liveTable.ID: now},
+		},
+		{
+			name:         "expect betting open given betting window over but spin interval since last spin not over",
+			tables:       mockTables{GivenTables: []table.Table{liveTable}},
+			round:        round.Round{State: round.StateOpen, CreatedAt: now.Add(-40 * time.Second)},
+			spunAt:       map[string]time.Time{liveTable.ID: now.Add(-50 * time.Second)},
+			wantClosedAt: map[string]time.Time{},
+			wantSpunAt:   map[string]time.Time{liveTable.ID: now.Add(-50 * time.Second)},
+		},
+		{
+			name:         "expect betting closed given spin interval since last spin less the countdown over",
+			tables:       mockTables{GivenTables: []table.Table{liveTable}},
+			round:        round.Round{State: round.StateOpen, CreatedAt: now.Add(-55 * time.Second)},
+			spunAt:       map[string]time.Time{liveTable.ID: now.Add(-55 * time.Second)},
+			wantClosed:   1,
+			wantClosedAt: map[string]time.Time{liveTable.ID: now},
+			wantSpunAt:   map[string]time.Time{},
+		},
+		{
+			name:         "expect no spin given no more bets countdown not over",
+			tables:       mockTables{GivenTables: []table.Table{liveTable}},
+			round:        round.Round{State: round.StateClosed},
+			closedAt:     map[string]time.Time{liveTable.ID: now.Add(-4 * time.Second)},
+			wantClosedAt: map[string]time.Time{liveTable.ID: now.Add(-4 * time.Second)},
+		},
+		{
+			name:         "expect round played given no more bets countdown over",
+			tables:       mockTables{GivenTables: []table.Table{liveTable}},
+			round:        round.Round{State: round.StateClosed},
+			closedAt:     map[string]time.Time{liveTable.ID: now.Add(-5 * time.Second)},
+			wantPlayed:   1,
+			wantClosedAt: map[string]time.Time{},
+			wantSpunAt:   map[string]time.Time{liveTable.ID: now},
+		},
+		{
+			name:         "expect countdown started again given closed round from before a restart",
+			tables:       mockTables{GivenTables: []table.Table{liveTable}},
+			round:        round.Round{State: round.StateClosed},
+			wantClosedAt: map[string]time.Time{liveTable.ID: now},
+		},
+		{
+			name:         "expect round played given spun round from before a restart",
+			tables:       mockTables{GivenTables: []table.Table{{ID: liveTable.ID, BettingWindow: 30}}},
+			round:        round.Round{State: round.StateSpun},
+			wantPlayed:   1,
+			wantClosedAt: map[string]time.Time{},
+			wantSpunAt:   map[string]time.Time{liveTable.ID: now},
+		},
+		{
+			name: "expect every table advanced given tables over many pages",
+			tables: mockTables{GivenTables: []table.Table{
+				{ID: "foo", BettingWindow: 30},
+				{ID: "bar", BettingWindow: 30},
+				{ID: "baz", BettingWindow: 30},
+			}},
+			round:        round.Round{State: round.StateSpun},
+			wantPlayed:   3,
+			wantClosedAt: map[string]time.Time{},
+			wantSpunAt:   map[string]time.Time{"foo": now, "bar": now, "baz": now},
+		},
+		{
+			name:         "expect nothing given table without betting window",
+			tables:       mockTables{GivenTables: []table.Table{{ID: liveTable.ID}}},
+			round:        round.Round{State: round.StateOpen, CreatedAt: now.Add(-time.Hour)},
+			wantClosedAt: map[string]time.Time{},
+		},
+		{
+			name:         "expect times forgotten given betting window removed from table",
+			tables:       mockTables{GivenTables: []table.Table{{ID: liveTable.ID}}},
+			round:        round.Round{State: round.StateClosed},
+			closedAt:     map[string]time.Time{liveTable.ID: now.Add(-4 * time.Second)},
+			spunAt:       map[string]time.Time{liveTable.ID: now.Add(-time.Minute)},
+			wantClosedAt: map[string]time.Time{},
+			wantSpunAt:   map[string]time.Time{},
+		},
+		{
+			name:         "expect times forgotten given table deleted",
+			tables:       mockTables{GivenTables: []table.Table{liveTable}},
+			round:        round.Round{State: round.StateOpen, CreatedAt: now},
+			closedAt:     map[string]time.Time{"foo": now.Add(-4 * time.Second)},
+			spunAt:       map[string]time.Time{"foo": now.Add(-time.Minute)},
+			wantClosedAt: map[string]time.Time{},
+			wantSpunAt:   map[string]time.Time{},
+		},
+		{
+			name:         "expect nothing given table error",
+			tables:       mockTables{GivenError: errors.New("foo")},
+			closedAt:     map[string]time.Time{"foo": now.Add(-4 * time.Second)},
+			wantClosedAt: map[string]time.Time{"foo": now.Add(-4 * time.Second)},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rounds := mockRounds{GivenRound: tt.round}
+			games := &mockGames{}
+
+			s := New(logrus.New(), tt.tables, rounds, games)
+			s.Now = func() time.Time { return now }
+
+			for id, at := range tt.closedAt {
+				s.closedAt[id] = at
+			}
+
+			for id, at := range tt.spunAt {
+				s.spunAt[id] = at
+			}
+
+			s.Tick(context.Background())
+
+			if !cmp.Equal(games.closed, tt.wantClosed) {
+				t.Error(cmp.Diff(games.closed, tt.wantClosed))
+			}
+
+			if !cmp.Equal(games.played, tt.wantPlayed) {
+				t.Error(cmp.Diff(games.played, tt.wantPlayed))
+			}
+
+			if !cmp.Equal(s.closedAt, tt.wantClosedAt) {
+				t.Error(cmp.Diff(s.closedAt, tt.wantClosedAt))
+			}
+
+			if !cmp.Equal(s.spunAt, tt.wantSpunAt, cmpopts.EquateEmpty()) {
+				t.Error(cmp.Diff(s.spunAt, tt.wantSpunAt, cmpopts.EquateEmpty()))
+			}
+		})
+	}
+}
+
+func TestScheduler_Run(t *testing.T) {
+	games := &mockGames{}
+
+	s := New(logrus.New(), mockTables{GivenTables: []table.Table{{ID: liveTable.ID, BettingWindow: 1}}}, mockRounds{
+		GivenRound: round.Round{State: round.StateSpun},
+	}, games)
+	s.Poll = time.Millisecond
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+
+	go func() {
+		s.Run(ctx)
+		close(done)
+	}()
+
+	time.Sleep(20 * time.Millisecond)
+	cancel()
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("expect Run to return once the context is done")
+	}
+
+	if games.played == 0 {
+		t.Error("expect rounds played before the context is done")
+	}
+}
+
+type mockTables struct {
+	GivenTables []table.Table
+	GivenError  error
+}
+
+// List returns the given tables a page of one table at a time.
+func (m mockTables) List(_ context.Context, filter table.Filter) ([]table.Table, string, error) {
+	if m.GivenError != nil {
+		return []table.Table{}, "", m.GivenError
+	}
+
+	i, _ := strconv.Atoi(filter.Cursor)
+	if i >= len(m.GivenTables) {
+		return []table.Table{}, "", nil
+	}
+
+	next := ""
+	if i+1 < len(m.GivenTables) {
+		next = strconv.Itoa(i + 1)
+	}
+
+	return m.GivenTables[i : i+1], next, nil
+}
+
+type mockRounds struct {
+	GivenRound round.Round
+	GivenError error
+}
+
+func (m mockRounds) Current(_ context.Context, _ string) (round.Round, error) {
+	return m.GivenRound, m.GivenError
+}
+
+type mockGames struct {
+	GivenError error
+	closed     int
+	played     int
+}
+
+func (m *mockGames) Close(_ context.Context, model round.Round) (round.Round, error) {
+	m.closed++
+	model.State = round.StateClosed
+
+	return model, m.GivenError
+}
+
+func (m *mockGames) Play(_ context.Context, _ string) (bet.Result, error) {
+	m.played++
+
+	return bet.Result{}, m.GivenError
+}
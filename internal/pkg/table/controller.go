@@ -4,41 +4,64 @@ import (
 	"context"
 	"errors"
 
+	"github.com/clarke94/roulette-service/internal/pkg/apperror"
+	"github.com/clarke94/roulette-service/internal/pkg/page"
 	"github.com/google/uuid"
 	"github.com/sirupsen/logrus"
 )
 
 var (
-	ErrCreate = errors.New("unable to create table")
-	ErrList   = errors.New("unable to fetch all tables")
-	ErrUpdate = errors.New("unable to update table")
-	ErrDelete = errors.New("unable to delete table")
+	ErrCreate   = apperror.New(apperror.Internal, "unable to create table")
+	ErrList     = apperror.New(apperror.Internal, "unable to fetch all tables")
+	ErrUpdate   = apperror.New(apperror.Internal, "unable to update table")
+	ErrDelete   = apperror.New(apperror.Internal, "unable to delete table")
+	ErrGet      = apperror.New(apperror.Internal, "unable to fetch table")
+	ErrNotFound = apperror.New(apperror.NotFound, "table not found")
+	ErrVersion  = apperror.New(apperror.PreconditionFailed, "table has changed since it was fetched")
+	ErrInPlay   = apperror.New(apperror.Conflict, "table wheel, rules and currency cannot change while bets are in play")
 )
 
 // StorageProvider provides an interface to the Storage layer.
 type StorageProvider interface {
 	Create(ctx context.Context, model Table) (string, error)
-	List(ctx context.Context) ([]Table, error)
+	List(ctx context.Context, filter Filter) ([]Table, string, error)
+	Get(ctx context.Context, id string) (Table, error)
 	Update(ctx context.Context, model Table) (string, error)
 	Delete(ctx context.Context, id string) (string, error)
 }
 
+// BetProvider provides an interface to the bets in play on a table.
+type BetProvider interface {
+	InPlay(ctx context.Context, tableID string) (bool, error)
+}
+
+// TransactionProvider provides an interface to run work in a single database transaction holding the lock of a table.
+type TransactionProvider interface {
+	Transaction(ctx context.Context, fn func(ctx context.Context) error) error
+	Lock(ctx context.Context, key string) error
+}
+
 // Controller provides a domain controller.
 type Controller struct {
-	Logger  *logrus.Logger
-	Storage StorageProvider
+	Logger      *logrus.Logger
+	Storage     StorageProvider
+	Bets        BetProvider
+	Transaction TransactionProvider
 }
 
 // New initializes a new Controller.
-func New(logger *logrus.Logger, storage StorageProvider) Controller {
+func New(logger *logrus.Logger, storage StorageProvider, bets BetProvider, transaction TransactionProvider) Controller {
 	return Controller{
-		Logger:  logger,
-		Storage: storage,
+		Logger:      logger,
+		Storage:     storage,
+		Bets:        bets,
+		Transaction: transaction,
 	}
 }
 
 // Create validates the model and invokes the repository.
 func (c Controller) Create(ctx context.Context, model Table) (string, error) {
+	model = withDefaults(model)
 	model.ID = uuid.New().String()
 
 	id, err := c.Storage.Create(ctx, model)
@@ -53,23 +76,80 @@ func (c Controller) Create(ctx context.Context, model Table) (string, error) {
 	return id, nil
 }
 
-// List returns all tables from the storage layer.
-func (c Controller) List(ctx context.Context) ([]Table, error) {
-	tables, err := c.Storage.List(ctx)
+// List returns a page of tables from the storage layer and the cursor of the next page.
+func (c Controller) List(ctx context.Context, filter Filter) ([]Table, string, error) {
+	tables, next, err := c.Storage.List(ctx, filter)
+	if errors.Is(err, page.ErrCursor) {
+		return []Table{}, "", page.ErrCursor
+	}
+
 	if err != nil {
 		c.Logger.WithFields(logrus.Fields{
 			"error": err.Error(),
 		}).Error(ErrList.Error())
 
-		return []Table{}, ErrList
+		return []Table{}, "", ErrList
 	}
 
-	return tables, nil
+	return tables, next, nil
 }
 
-// Update validates the model and invokes the repository.
+// Get returns a single table from the storage layer.
+func (c Controller) Get(ctx context.Context, id string) (Table, error) {
+	model, err := c.Storage.Get(ctx, id)
+	if errors.Is(err, ErrNotFound) {
+		return Table{}, ErrNotFound
+	}
+
+	if err != nil {
+		c.Logger.WithFields(logrus.Fields{
+			"error": err.Error(),
+		}).Error(ErrGet.Error())
+
+		return Table{}, ErrGet
+	}
+
+	return model, nil
+}
+
+// Update validates the model and invokes the repository, rejecting it when the table is no longer at the
+// version the model is based on.
+// The table is locked against bets being placed while it changes, and its wheel, rules and currency are refused a
+// change while bets are in play, as the bets would no longer settle as they were placed.
 func (c Controller) Update(ctx context.Context, model Table) (string, error) {
-	id, err := c.Storage.Update(ctx, model)
+	model = withDefaults(model)
+
+	var id string
+
+	err := c.Transaction.Transaction(ctx, func(ctx context.Context) error {
+		if err := c.Transaction.Lock(ctx, model.ID); err != nil {
+			return err
+		}
+
+		current, err := c.Storage.Get(ctx, model.ID)
+		if err != nil {
+			return err
+		}
+
+		if changesGame(current, model) {
+			inPlay, err := c.Bets.InPlay(ctx, model.ID)
+			if err != nil {
+				return err
+			}
+
+			if inPlay {
+				return ErrInPlay
+			}
+		}
+
+		id, err = c.Storage.Update(ctx, model)
+
+		return err
+	})
+	if errors.Is(err, ErrNotFound) || errors.Is(err, ErrVersion) || errors.Is(err, ErrInPlay) {
+		return "", err
+	}
+
 	if err != nil {
 		c.Logger.WithFields(logrus.Fields{
 			"error": err.Error(),
@@ -84,6 +164,10 @@ func (c Controller) Update(ctx context.Context, model Table) (string, error) {
 // Delete deletes one from the repository.
 func (c Controller) Delete(ctx context.Context, id string) (string, error) {
 	deletedID, err := c.Storage.Delete(ctx, id)
+	if errors.Is(err, ErrNotFound) {
+		return "", ErrNotFound
+	}
+
 	if err != nil {
 		c.Logger.WithFields(logrus.Fields{
 			"error": err.Error(),
@@ -94,3 +178,8 @@ func (c Controller) Delete(ctx context.Context, id string) (string, error) {
 
 	return deletedID, nil
 }
+
+// changesGame reports whether the update changes how the bets placed on the table are settled.
+func changesGame(current, model Table) bool {
+	return current.Wheel != model.Wheel || current.Rules != model.Rules || current.Currency != model.Currency
+}
@@ -3,6 +3,7 @@ package table
 import (
 	"context"
 	"errors"
+	"github.com/clarke94/roulette-service/internal/pkg/page"
 	"github.com/google/go-cmp/cmp/cmpopts"
 	"github.com/google/uuid"
 	"github.com/sirupsen/logrus"
@@ -19,13 +20,15 @@ func TestNew(t *testing.T) {
 		{
 			name: "expect Controller to init",
 			want: Controller{
-				Storage: mockStorage{},
+				Storage:     mockStorage{},
+				Bets:        mockBets{},
+				Transaction: mockTransaction{},
 			},
 		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			got := New(logrus.New(), mockStorage{})
+			got := New(logrus.New(), mockStorage{}, mockBets{}, mockTransaction{})
 			if !cmp.Equal(got, tt.want, cmpopts.IgnoreUnexported(Controller{}), cmpopts.IgnoreFields(Controller{}, "Logger")) {
 				t.Error(cmp.Diff(got, tt.want, cmpopts.IgnoreUnexported(Controller{}), cmpopts.IgnoreFields(Controller{}, "Logger")))
 			}
@@ -70,7 +73,7 @@ func TestController_Create(t *testing.T) {
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			c := New(tt.Logger, tt.Storage)
+			c := New(tt.Logger, tt.Storage, mockBets{}, mockTransaction{})
 			_, err := c.Create(context.Background(), tt.model)
 
 			if !cmp.Equal(err, tt.wantErr, cmpopts.EquateErrors()) {
@@ -86,6 +89,7 @@ func TestController_List(t *testing.T) {
 		Logger     *logrus.Logger
 		Storage    StorageProvider
 		wantTables []Table
+		wantNext   string
 		wantErr    error
 	}{
 		{
@@ -109,6 +113,7 @@ func TestController_List(t *testing.T) {
 						Currency:   "GBP",
 					},
 				},
+				GivenNext: "bar",
 			},
 			wantTables: []Table{
 				{
@@ -118,7 +123,17 @@ func TestController_List(t *testing.T) {
 					Currency:   "GBP",
 				},
 			},
-			wantErr: nil,
+			wantNext: "bar",
+			wantErr:  nil,
+		},
+		{
+			name:   "expect fail given invalid cursor",
+			Logger: logrus.New(),
+			Storage: mockStorage{
+				GivenError: page.ErrCursor,
+			},
+			wantTables: []Table{},
+			wantErr:    page.ErrCursor,
 		},
 		{
 			name:   "expect fail given storage error",
@@ -133,8 +148,8 @@ func TestController_List(t *testing.T) {
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			c := New(tt.Logger, tt.Storage)
-			tables, err := c.List(context.Background())
+			c := New(tt.Logger, tt.Storage, mockBets{}, mockTransaction{})
+			tables, next, err := c.List(context.Background(), Filter{})
 
 			if !cmp.Equal(err, tt.wantErr, cmpopts.EquateErrors()) {
 				t.Error(cmp.Diff(err, tt.wantErr, cmpopts.EquateErrors()))
@@ -143,6 +158,74 @@ func TestController_List(t *testing.T) {
 			if !cmp.Equal(tables, tt.wantTables) {
 				t.Error(cmp.Diff(tables, tt.wantTables))
 			}
+
+			if !cmp.Equal(next, tt.wantNext) {
+				t.Error(cmp.Diff(next, tt.wantNext))
+			}
+		})
+	}
+}
+
+func TestController_Get(t *testing.T) {
+	tests := []struct {
+		name    string
+		Storage StorageProvider
+		want    Table
+		wantErr error
+	}{
+		{
+			name: "expect table given existing table",
+			Storage: mockStorage{
+				GivenTable: Table{
+					ID:                 "8117bb87-148c-4fb1-8971-a2d4373b3f19",
+					Name:               "foo",
+					MaximumBet:         1000,
+					MinimumBet:         10,
+					Currency:           "GBP",
+					MaximumPlayerStake: 5000,
+					MaximumExposure:    100000,
+				},
+			},
+			want: Table{
+				ID:                 "8117bb87-148c-4fb1-8971-a2d4373b3f19",
+				Name:               "foo",
+				MaximumBet:         1000,
+				MinimumBet:         10,
+				Currency:           "GBP",
+				MaximumPlayerStake: 5000,
+				MaximumExposure:    100000,
+			},
+			wantErr: nil,
+		},
+		{
+			name: "expect not found given missing table",
+			Storage: mockStorage{
+				GivenError: ErrNotFound,
+			},
+			want:    Table{},
+			wantErr: ErrNotFound,
+		},
+		{
+			name: "expect fail given storage error",
+			Storage: mockStorage{
+				GivenError: errors.New("foo"),
+			},
+			want:    Table{},
+			wantErr: ErrGet,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := New(logrus.New(), tt.Storage, mockBets{}, mockTransaction{})
+			got, err := c.Get(context.Background(), "8117bb87-148c-4fb1-8971-a2d4373b3f19")
+
+			if !cmp.Equal(err, tt.wantErr, cmpopts.EquateErrors()) {
+				t.Error(cmp.Diff(err, tt.wantErr, cmpopts.EquateErrors()))
+			}
+
+			if !cmp.Equal(got, tt.want) {
+				t.Error(cmp.Diff(got, tt.want))
+			}
 		})
 	}
 }
@@ -152,6 +235,7 @@ func TestController_Update(t *testing.T) {
 		name    string
 		Logger  *logrus.Logger
 		Storage StorageProvider
+		Bets    BetProvider
 		model   Table
 		wantErr error
 	}{
@@ -159,15 +243,119 @@ func TestController_Update(t *testing.T) {
 			name:    "expect success given valid table",
 			Logger:  logrus.New(),
 			Storage: mockStorage{},
+			Bets:    mockBets{},
+			model: Table{
+				ID:         uuid.New().String(),
+				Name:       "foo",
+				MaximumBet: 10,
+				MinimumBet: 10,
+				Currency:   "GBP",
+			},
+			wantErr: nil,
+		},
+		{
+			name:    "expect success given limits changed while bets in play",
+			Logger:  logrus.New(),
+			Storage: mockStorage{GivenTable: Table{Currency: "GBP", Wheel: WheelAmerican, Rules: RulesStandard}},
+			Bets:    mockBets{GivenInPlay: true},
+			model: Table{
+				ID:         uuid.New().String(),
+				Name:       "foo",
+				MaximumBet: 20,
+				MinimumBet: 10,
+				Currency:   "GBP",
+				Wheel:      WheelAmerican,
+			},
+			wantErr: nil,
+		},
+		{
+			name:    "expect ErrInPlay given wheel changed while bets in play",
+			Logger:  logrus.New(),
+			Storage: mockStorage{GivenTable: Table{Currency: "GBP", Wheel: WheelAmerican, Rules: RulesStandard}},
+			Bets:    mockBets{GivenInPlay: true},
+			model: Table{
+				ID:         uuid.New().String(),
+				Name:       "foo",
+				MaximumBet: 10,
+				MinimumBet: 10,
+				Currency:   "GBP",
+				Wheel:      WheelEuropean,
+			},
+			wantErr: ErrInPlay,
+		},
+		{
+			name:    "expect ErrInPlay given currency changed while bets in play",
+			Logger:  logrus.New(),
+			Storage: mockStorage{GivenTable: Table{Currency: "GBP", Wheel: WheelEuropean, Rules: RulesStandard}},
+			Bets:    mockBets{GivenInPlay: true},
+			model: Table{
+				ID:         uuid.New().String(),
+				Name:       "foo",
+				MaximumBet: 10,
+				MinimumBet: 10,
+				Currency:   "EUR",
+			},
+			wantErr: ErrInPlay,
+		},
+		{
+			name:    "expect success given rules changed with no bets in play",
+			Logger:  logrus.New(),
+			Storage: mockStorage{GivenTable: Table{Currency: "GBP", Wheel: WheelEuropean, Rules: RulesStandard}},
+			Bets:    mockBets{},
 			model: Table{
 				ID:         uuid.New().String(),
 				Name:       "foo",
 				MaximumBet: 10,
 				MinimumBet: 10,
 				Currency:   "GBP",
+				Rules:      RulesLaPartage,
 			},
 			wantErr: nil,
 		},
+		{
+			name:    "expect fail given bets in play cannot be checked",
+			Logger:  logrus.New(),
+			Storage: mockStorage{GivenTable: Table{Currency: "GBP", Wheel: WheelEuropean, Rules: RulesStandard}},
+			Bets:    mockBets{GivenError: errors.New("foo")},
+			model: Table{
+				ID:         uuid.New().String(),
+				Name:       "foo",
+				MaximumBet: 10,
+				MinimumBet: 10,
+				Currency:   "EUR",
+			},
+			wantErr: ErrUpdate,
+		},
+		{
+			name:    "expect ErrNotFound given table not found",
+			Logger:  logrus.New(),
+			Storage: mockStorage{GivenError: ErrNotFound},
+			Bets:    mockBets{},
+			model: Table{
+				ID:         uuid.New().String(),
+				Name:       "foo",
+				MaximumBet: 10,
+				MinimumBet: 10,
+				Currency:   "GBP",
+			},
+			wantErr: ErrNotFound,
+		},
+		{
+			name:   "expect fail given table has changed",
+			Logger: logrus.New(),
+			Storage: mockStorage{
+				GivenError: ErrVersion,
+			},
+			model: Table{
+				ID:         uuid.New().String(),
+				Name:       "foo",
+				MaximumBet: 10,
+				MinimumBet: 10,
+				Currency:   "GBP",
+				Version:    1,
+			},
+			wantErr: ErrVersion,
+		},
 		{
 			name:   "expect fail given storage error",
 			Logger: logrus.New(),
@@ -186,7 +374,7 @@ func TestController_Update(t *testing.T) {
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			c := New(tt.Logger, tt.Storage)
+			c := New(tt.Logger, tt.Storage, tt.Bets, mockTransaction{})
 			_, err := c.Update(context.Background(), tt.model)
 
 			if !cmp.Equal(err, tt.wantErr, cmpopts.EquateErrors()) {
@@ -223,7 +411,7 @@ func TestController_Delete(t *testing.T) {
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			c := New(tt.Logger, tt.Storage)
+			c := New(tt.Logger, tt.Storage, mockBets{}, mockTransaction{})
 			_, err := c.Delete(context.Background(), tt.id)
 
 			if !cmp.Equal(err, tt.wantErr, cmpopts.EquateErrors()) {
@@ -235,10 +423,16 @@ func TestController_Delete(t *testing.T) {
 
 type mockStorage struct {
 	GivenList  []Table
+	GivenNext  string
+	GivenTable Table
 	GivenID    string
 	GivenError error
 }
 
+func (m mockStorage) Get(_ context.Context, _ string) (Table, error) {
+	return m.GivenTable, m.GivenError
+}
+
 func (m mockStorage) Delete(_ context.Context, _ string) (string, error) {
 	return m.GivenID, m.GivenError
 }
@@ -247,10 +441,29 @@ func (m mockStorage) Update(_ context.Context, _ Table) (string, error) {
 	return m.GivenID, m.GivenError
 }
 
-func (m mockStorage) List(_ context.Context) ([]Table, error) {
-	return m.GivenList, m.GivenError
+func (m mockStorage) List(_ context.Context, _ Filter) ([]Table, string, error) {
+	return m.GivenList, m.GivenNext, m.GivenError
 }
 
 func (m mockStorage) Create(_ context.Context, _ Table) (string, error) {
 	return m.GivenID, m.GivenError
 }
+
+type mockBets struct {
+	GivenInPlay bool
+	GivenError  error
+}
+
+func (m mockBets) InPlay(_ context.Context, _ string) (bool, error) {
+	return m.GivenInPlay, m.GivenError
+}
+
+type mockTransaction struct{}
+
+func (m mockTransaction) Transaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	return fn(ctx)
+}
+
+func (m mockTransaction) Lock(_ context.Context, _ string) error {
+	return nil
+}
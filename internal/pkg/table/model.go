@@ -1,10 +1,85 @@
 package table
 
+import (
+	"time"
+
+	"github.com/clarke94/roulette-service/internal/pkg/page"
+)
+
 // Table is a domain model.
+// MaximumPlayerStake limits the total a single player can stake on a round and MaximumExposure limits the total
+// payout owed on any single number of a round, a zero value for either means the table has no limit.
+// Wheel is the variant of roulette wheel spun at the table and Rules is how even-money bets are settled when zero is
+// spun. ProvablyFair derives every spin from a server seed committed to before the round and a client seed.
+// A Table with a BettingWindow is played automatically, each round accepting bets for BettingWindow seconds and
+// spinning NoMoreBets seconds after betting closes, with at least SpinInterval seconds between spins.
+// Version counts the changes made to the Table, an update only applying to the Version it was based on.
 type Table struct {
-	ID         string
-	Name       string
-	MaximumBet int
-	MinimumBet int
-	Currency   string
+	ID                 string
+	Name               string
+	MaximumBet         int
+	MinimumBet         int
+	Currency           string
+	MaximumPlayerStake int
+	MaximumExposure    int
+	Wheel              string
+	Rules              string
+	ProvablyFair       bool
+	BettingWindow      int
+	NoMoreBets         int
+	SpinInterval       int
+	Version            int64
+}
+
+// Filter narrows down and orders the tables returned from a list, Order sorting them by when they were created.
+type Filter struct {
+	page.Page
+	Currency string
+	Wheel    string
+	Rules    string
+	From     time.Time
+	To       time.Time
+	Order    string
+}
+
+// Wheel is the supported variant of roulette wheel.
+const (
+	// WheelEuropean has a single zero and the numbers 1 to 36.
+	WheelEuropean = "european"
+	// WheelAmerican has a zero, a double zero and the numbers 1 to 36.
+	WheelAmerican = "american"
+)
+
+// wheelPockets is the number of pockets on each supported wheel.
+var wheelPockets = map[string]int{
+	WheelEuropean: 37,
+	WheelAmerican: 38,
+}
+
+// Pockets returns the number of pockets on the wheel, the 00 pocket of an American wheel being the last.
+func Pockets(wheel string) int {
+	return wheelPockets[wheel]
+}
+
+// Rules is the supported ruleset for settling even-money bets when zero is spun.
+const (
+	// RulesStandard loses every even-money bet.
+	RulesStandard = "standard"
+	// RulesLaPartage returns half the stake of every even-money bet.
+	RulesLaPartage = "la-partage"
+	// RulesEnPrison holds every even-money bet over to the next round, returning the stake if it then wins.
+	RulesEnPrison = "en-prison"
+)
+
+// withDefaults returns the Table with a European wheel and standard rules when none are given.
+func withDefaults(t Table) Table {
+	if t.Wheel == "" {
+		t.Wheel = WheelEuropean
+	}
+
+	if t.Rules == "" {
+		t.Rules = RulesStandard
+	}
+
+	return t
 }
@@ -0,0 +1,168 @@
+package wallet
+
+import (
+	"context"
+	"errors"
+
+	"github.com/clarke94/roulette-service/internal/pkg/apperror"
+	"github.com/clarke94/roulette-service/internal/pkg/ledger"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+)
+
+var (
+	ErrCreate            = apperror.New(apperror.Internal, "unable to create wallet")
+	ErrList              = apperror.New(apperror.Internal, "unable to fetch all wallets")
+	ErrDeposit           = apperror.New(apperror.Internal, "unable to deposit into wallet")
+	ErrReserve           = apperror.New(apperror.Internal, "unable to reserve funds")
+	ErrRelease           = apperror.New(apperror.Internal, "unable to release funds")
+	ErrSettle            = apperror.New(apperror.Internal, "unable to settle funds")
+	ErrNotFound          = apperror.New(apperror.NotFound, "wallet not found")
+	ErrExists            = apperror.New(apperror.Conflict, "player already has a wallet in this currency")
+	ErrInsufficientFunds = apperror.New(apperror.LimitExceeded, "insufficient funds")
+)
+
+// StorageProvider provides an interface to the Storage layer.
+type StorageProvider interface {
+	Create(ctx context.Context, model Wallet) (string, error)
+	List(ctx context.Context, playerID string) ([]Wallet, error)
+	Deposit(ctx context.Context, playerID, currency string, amount int64) error
+	Reserve(ctx context.Context, playerID, currency string, amount int64) error
+	Release(ctx context.Context, playerID, currency string, amount int64) error
+	Settle(ctx context.Context, playerID, currency string, stake, payout int64) error
+}
+
+// LedgerProvider provides an interface to record the funds moving into a wallet.
+type LedgerProvider interface {
+	Record(ctx context.Context, entries ...ledger.Entry) error
+}
+
+// TransactionProvider provides an interface to run work in a single database transaction.
+type TransactionProvider interface {
+	Transaction(ctx context.Context, fn func(ctx context.Context) error) error
+}
+
+// Controller provides a domain controller.
+type Controller struct {
+	Logger      *logrus.Logger
+	Storage     StorageProvider
+	Ledger      LedgerProvider
+	Transaction TransactionProvider
+}
+
+// New initializes a new Controller.
+func New(logger *logrus.Logger, storage StorageProvider, ledger LedgerProvider, transaction TransactionProvider) Controller {
+	return Controller{
+		Logger:      logger,
+		Storage:     storage,
+		Ledger:      ledger,
+		Transaction: transaction,
+	}
+}
+
+// Create opens a wallet for a player in a currency with an opening balance, recording the opening balance in the
+// ledger.
+func (c Controller) Create(ctx context.Context, model Wallet) (string, error) {
+	model.ID = uuid.New().String()
+	model.Reserved = 0
+
+	var id string
+
+	err := c.Transaction.Transaction(ctx, func(ctx context.Context) error {
+		var err error
+
+		id, err = c.Storage.Create(ctx, model)
+		if err != nil {
+			return err
+		}
+
+		return c.Ledger.Record(ctx, funded(ledger.TypeOpeningBalance, model.PlayerID, model.Currency, model.Balance))
+	})
+	if errors.Is(err, ErrExists) {
+		return "", ErrExists
+	}
+
+	if err != nil {
+		c.Logger.WithFields(logrus.Fields{
+			"error": err.Error(),
+		}).Error(ErrCreate.Error())
+
+		return "", ErrCreate
+	}
+
+	return id, nil
+}
+
+// List returns every wallet held by a player.
+func (c Controller) List(ctx context.Context, playerID string) ([]Wallet, error) {
+	wallets, err := c.Storage.List(ctx, playerID)
+	if err != nil {
+		c.Logger.WithFields(logrus.Fields{
+			"error": err.Error(),
+		}).Error(ErrList.Error())
+
+		return []Wallet{}, ErrList
+	}
+
+	return wallets, nil
+}
+
+// Deposit adds funds to the balance of a player's wallet, recording the deposit in the ledger.
+func (c Controller) Deposit(ctx context.Context, playerID, currency string, amount int64) error {
+	err := c.Transaction.Transaction(ctx, func(ctx context.Context) error {
+		if err := c.Storage.Deposit(ctx, playerID, currency, amount); err != nil {
+			return err
+		}
+
+		return c.Ledger.Record(ctx, funded(ledger.TypeDeposit, playerID, currency, amount))
+	})
+	if errors.Is(err, ErrNotFound) {
+		return ErrNotFound
+	}
+
+	return c.fail(err, ErrDeposit)
+}
+
+// Reserve holds funds of a player's wallet against an open bet.
+func (c Controller) Reserve(ctx context.Context, playerID, currency string, amount int64) error {
+	err := c.Storage.Reserve(ctx, playerID, currency, amount)
+	if errors.Is(err, ErrInsufficientFunds) {
+		return ErrInsufficientFunds
+	}
+
+	return c.fail(err, ErrReserve)
+}
+
+// Release returns reserved funds of a player's wallet to the available balance.
+func (c Controller) Release(ctx context.Context, playerID, currency string, amount int64) error {
+	return c.fail(c.Storage.Release(ctx, playerID, currency, amount), ErrRelease)
+}
+
+// Settle takes a reserved stake from a player's wallet and credits the payout of the bet.
+func (c Controller) Settle(ctx context.Context, playerID, currency string, stake, payout int64) error {
+	return c.fail(c.Storage.Settle(ctx, playerID, currency, stake, payout), ErrSettle)
+}
+
+// funded returns the ledger entry of funds moving from outside the ledger into the available balance of a player.
+func funded(entryType, playerID, currency string, amount int64) ledger.Entry {
+	return ledger.Entry{
+		Type:          entryType,
+		PlayerID:      playerID,
+		Currency:      currency,
+		DebitAccount:  ledger.AccountAvailable,
+		CreditAccount: ledger.AccountExternal,
+		Amount:        amount,
+	}
+}
+
+func (c Controller) fail(err, external error) error {
+	if err == nil {
+		return nil
+	}
+
+	c.Logger.WithFields(logrus.Fields{
+		"error": err.Error(),
+	}).Error(external.Error())
+
+	return external
+}
@@ -0,0 +1,369 @@
+package wallet
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/clarke94/roulette-service/internal/pkg/ledger"
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/sirupsen/logrus"
+)
+
+func TestNew(t *testing.T) {
+	tests := []struct {
+		name string
+		want Controller
+	}{
+		{
+			name: "expect Controller to init",
+			want: Controller{
+				Storage:     mockStorage{},
+				Ledger:      mockLedger{},
+				Transaction: mockTransaction{},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := New(logrus.New(), mockStorage{}, mockLedger{}, mockTransaction{})
+			if !cmp.Equal(got, tt.want, cmpopts.IgnoreFields(Controller{}, "Logger")) {
+				t.Error(cmp.Diff(got, tt.want, cmpopts.IgnoreFields(Controller{}, "Logger")))
+			}
+		})
+	}
+}
+
+func TestController_Create(t *testing.T) {
+	tests := []struct {
+		name    string
+		Storage StorageProvider
+		Ledger  LedgerProvider
+		model   Wallet
+		want    string
+		wantErr error
+	}{
+		{
+			name: "expect success given valid wallet",
+			Storage: mockStorage{
+				GivenID: "foo",
+			},
+			Ledger: mockLedger{},
+			model: Wallet{
+				PlayerID: "8117bb87-148c-4fb1-8971-a2d4373b3f19",
+				Currency: "GBP",
+				Balance:  1000,
+			},
+			want:    "foo",
+			wantErr: nil,
+		},
+		{
+			name: "expect fail given storage error",
+			Storage: mockStorage{
+				GivenError: errors.New("foo"),
+			},
+			Ledger: mockLedger{},
+			model: Wallet{
+				PlayerID: "8117bb87-148c-4fb1-8971-a2d4373b3f19",
+				Currency: "GBP",
+			},
+			want:    "",
+			wantErr: ErrCreate,
+		},
+		{
+			name: "expect fail given wallet already exists for currency",
+			Storage: mockStorage{
+				GivenError: ErrExists,
+			},
+			Ledger: mockLedger{},
+			model: Wallet{
+				PlayerID: "8117bb87-148c-4fb1-8971-a2d4373b3f19",
+				Currency: "GBP",
+			},
+			want:    "",
+			wantErr: ErrExists,
+		},
+		{
+			name:    "expect fail given ledger error",
+			Storage: mockStorage{},
+			Ledger: mockLedger{
+				GivenError: errors.New("foo"),
+			},
+			model: Wallet{
+				PlayerID: "8117bb87-148c-4fb1-8971-a2d4373b3f19",
+				Currency: "GBP",
+				Balance:  1000,
+			},
+			want:    "",
+			wantErr: ErrCreate,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := New(logrus.New(), tt.Storage, tt.Ledger, mockTransaction{})
+
+			got, err := c.Create(context.Background(), tt.model)
+			if !cmp.Equal(err, tt.wantErr, cmpopts.EquateErrors()) {
+				t.Error(cmp.Diff(err, tt.wantErr, cmpopts.EquateErrors()))
+			}
+
+			if !cmp.Equal(got, tt.want) {
+				t.Error(cmp.Diff(got, tt.want))
+			}
+		})
+	}
+}
+
+func TestController_List(t *testing.T) {
+	tests := []struct {
+		name     string
+		Storage  StorageProvider
+		playerID string
+		want     []Wallet
+		wantErr  error
+	}{
+		{
+			name: "expect wallets given player",
+			Storage: mockStorage{
+				GivenList: []Wallet{
+					{
+						ID:       "foo",
+						PlayerID: "8117bb87-148c-4fb1-8971-a2d4373b3f19",
+						Currency: "GBP",
+						Balance:  1000,
+						Reserved: 100,
+					},
+				},
+			},
+			playerID: "8117bb87-148c-4fb1-8971-a2d4373b3f19",
+			want: []Wallet{
+				{
+					ID:       "foo",
+					PlayerID: "8117bb87-148c-4fb1-8971-a2d4373b3f19",
+					Currency: "GBP",
+					Balance:  1000,
+					Reserved: 100,
+				},
+			},
+			wantErr: nil,
+		},
+		{
+			name: "expect fail given storage error",
+			Storage: mockStorage{
+				GivenError: errors.New("foo"),
+			},
+			playerID: "8117bb87-148c-4fb1-8971-a2d4373b3f19",
+			want:     []Wallet{},
+			wantErr:  ErrList,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := New(logrus.New(), tt.Storage, mockLedger{}, mockTransaction{})
+
+			got, err := c.List(context.Background(), tt.playerID)
+			if !cmp.Equal(err, tt.wantErr, cmpopts.EquateErrors()) {
+				t.Error(cmp.Diff(err, tt.wantErr, cmpopts.EquateErrors()))
+			}
+
+			if !cmp.Equal(got, tt.want) {
+				t.Error(cmp.Diff(got, tt.want))
+			}
+		})
+	}
+}
+
+func TestController_Deposit(t *testing.T) {
+	tests := []struct {
+		name    string
+		Storage StorageProvider
+		Ledger  LedgerProvider
+		wantErr error
+	}{
+		{
+			name:    "expect success given existing wallet",
+			Storage: mockStorage{},
+			Ledger:  mockLedger{},
+			wantErr: nil,
+		},
+		{
+			name: "expect not found given missing wallet",
+			Storage: mockStorage{
+				GivenError: ErrNotFound,
+			},
+			Ledger:  mockLedger{},
+			wantErr: ErrNotFound,
+		},
+		{
+			name: "expect fail given storage error",
+			Storage: mockStorage{
+				GivenError: errors.New("foo"),
+			},
+			Ledger:  mockLedger{},
+			wantErr: ErrDeposit,
+		},
+		{
+			name:    "expect fail given ledger error",
+			Storage: mockStorage{},
+			Ledger: mockLedger{
+				GivenError: errors.New("foo"),
+			},
+			wantErr: ErrDeposit,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := New(logrus.New(), tt.Storage, tt.Ledger, mockTransaction{})
+
+			err := c.Deposit(context.Background(), "8117bb87-148c-4fb1-8971-a2d4373b3f19", "GBP", 100)
+			if !cmp.Equal(err, tt.wantErr, cmpopts.EquateErrors()) {
+				t.Error(cmp.Diff(err, tt.wantErr, cmpopts.EquateErrors()))
+			}
+		})
+	}
+}
+
+func TestController_Reserve(t *testing.T) {
+	tests := []struct {
+		name    string
+		Storage StorageProvider
+		wantErr error
+	}{
+		{
+			name:    "expect success given available funds",
+			Storage: mockStorage{},
+			wantErr: nil,
+		},
+		{
+			name: "expect fail given insufficient funds",
+			Storage: mockStorage{
+				GivenError: ErrInsufficientFunds,
+			},
+			wantErr: ErrInsufficientFunds,
+		},
+		{
+			name: "expect fail given storage error",
+			Storage: mockStorage{
+				GivenError: errors.New("foo"),
+			},
+			wantErr: ErrReserve,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := New(logrus.New(), tt.Storage, mockLedger{}, mockTransaction{})
+
+			err := c.Reserve(context.Background(), "8117bb87-148c-4fb1-8971-a2d4373b3f19", "GBP", 100)
+			if !cmp.Equal(err, tt.wantErr, cmpopts.EquateErrors()) {
+				t.Error(cmp.Diff(err, tt.wantErr, cmpopts.EquateErrors()))
+			}
+		})
+	}
+}
+
+func TestController_Release(t *testing.T) {
+	tests := []struct {
+		name    string
+		Storage StorageProvider
+		wantErr error
+	}{
+		{
+			name:    "expect success given reserved funds",
+			Storage: mockStorage{},
+			wantErr: nil,
+		},
+		{
+			name: "expect fail given storage error",
+			Storage: mockStorage{
+				GivenError: errors.New("foo"),
+			},
+			wantErr: ErrRelease,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := New(logrus.New(), tt.Storage, mockLedger{}, mockTransaction{})
+
+			err := c.Release(context.Background(), "8117bb87-148c-4fb1-8971-a2d4373b3f19", "GBP", 100)
+			if !cmp.Equal(err, tt.wantErr, cmpopts.EquateErrors()) {
+				t.Error(cmp.Diff(err, tt.wantErr, cmpopts.EquateErrors()))
+			}
+		})
+	}
+}
+
+func TestController_Settle(t *testing.T) {
+	tests := []struct {
+		name    string
+		Storage StorageProvider
+		wantErr error
+	}{
+		{
+			name:    "expect success given reserved stake",
+			Storage: mockStorage{},
+			wantErr: nil,
+		},
+		{
+			name: "expect fail given storage error",
+			Storage: mockStorage{
+				GivenError: errors.New("foo"),
+			},
+			wantErr: ErrSettle,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := New(logrus.New(), tt.Storage, mockLedger{}, mockTransaction{})
+
+			err := c.Settle(context.Background(), "8117bb87-148c-4fb1-8971-a2d4373b3f19", "GBP", 100, 3600)
+			if !cmp.Equal(err, tt.wantErr, cmpopts.EquateErrors()) {
+				t.Error(cmp.Diff(err, tt.wantErr, cmpopts.EquateErrors()))
+			}
+		})
+	}
+}
+
+type mockStorage struct {
+	GivenID    string
+	GivenList  []Wallet
+	GivenError error
+}
+
+func (m mockStorage) Create(_ context.Context, _ Wallet) (string, error) {
+	return m.GivenID, m.GivenError
+}
+
+func (m mockStorage) List(_ context.Context, _ string) ([]Wallet, error) {
+	return m.GivenList, m.GivenError
+}
+
+func (m mockStorage) Deposit(_ context.Context, _, _ string, _ int64) error {
+	return m.GivenError
+}
+
+func (m mockStorage) Reserve(_ context.Context, _, _ string, _ int64) error {
+	return m.GivenError
+}
+
+func (m mockStorage) Release(_ context.Context, _, _ string, _ int64) error {
+	return m.GivenError
+}
+
+func (m mockStorage) Settle(_ context.Context, _, _ string, _, _ int64) error {
+	return m.GivenError
+}
+
+type mockLedger struct {
+	GivenError error
+}
+
+func (m mockLedger) Record(_ context.Context, _ ...ledger.Entry) error {
+	return m.GivenError
+}
+
+type mockTransaction struct{}
+
+func (m mockTransaction) Transaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	return fn(ctx)
+}
@@ -0,0 +1,16 @@
+package wallet
+
+// Wallet is a domain model.
+// A player holds one Wallet per currency and all amounts are in the smallest currency unit.
+type Wallet struct {
+	ID       string
+	PlayerID string
+	Currency string
+	Balance  int64
+	Reserved int64
+}
+
+// Available returns the balance that is not reserved by open bets.
+func (w Wallet) Available() int64 {
+	return w.Balance - w.Reserved
+}
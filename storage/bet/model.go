@@ -9,36 +9,51 @@ import (
 
 // Bet is a storage model.
 type Bet struct {
-	ID        string `gorm:"primaryKey"`
-	TableID   string
-	Bet       string
-	Type      string
-	Amount    int64
-	Currency  string
-	CreatedAt time.Time
-	UpdatedAt time.Time
-	DeletedAt gorm.DeletedAt `gorm:"index"`
+	ID         string `gorm:"primaryKey"`
+	TableID    string
+	RoundID    string `gorm:"index"`
+	PlayerID   string `gorm:"index"`
+	Bet        string
+	Type       string
+	Amount     int64
+	Currency   string
+	Imprisoned bool
+	ClientSeed string
+	Version    int64 `gorm:"not null;default:1"`
+	CreatedAt  time.Time
+	UpdatedAt  time.Time
+	DeletedAt  gorm.DeletedAt `gorm:"index"`
 }
 
 func domainToStorage(t *bet.Bet) Bet {
 	return Bet{
-		ID:       t.ID,
-		TableID:  t.TableID,
-		Bet:      t.Bet,
-		Type:     t.Type,
-		Amount:   t.Amount,
-		Currency: t.Currency,
+		ID:         t.ID,
+		TableID:    t.TableID,
+		RoundID:    t.RoundID,
+		PlayerID:   t.PlayerID,
+		Bet:        t.Bet,
+		Type:       t.Type,
+		Amount:     t.Amount,
+		Currency:   t.Currency,
+		Imprisoned: t.Imprisoned,
+		ClientSeed: t.ClientSeed,
+		Version:    t.Version,
 	}
 }
 
 func storageToDomain(t *Bet) bet.Bet {
 	return bet.Bet{
-		ID:       t.ID,
-		TableID:  t.TableID,
-		Bet:      t.Bet,
-		Type:     t.Type,
-		Amount:   t.Amount,
-		Currency: t.Currency,
+		ID:         t.ID,
+		TableID:    t.TableID,
+		RoundID:    t.RoundID,
+		PlayerID:   t.PlayerID,
+		Bet:        t.Bet,
+		Type:       t.Type,
+		Amount:     t.Amount,
+		Currency:   t.Currency,
+		Imprisoned: t.Imprisoned,
+		ClientSeed: t.ClientSeed,
+		Version:    t.Version,
 	}
 }
 
@@ -51,13 +66,3 @@ func storageListToDomain(t []Bet) []bet.Bet {
 
 	return bets
 }
-
-func domainListToStorage(t []bet.Bet) []Bet {
-	bets := make([]Bet, len(t))
-
-	for i := range t {
-		bets[i] = domainToStorage(&t[i])
-	}
-
-	return bets
-}
@@ -5,11 +5,12 @@ import (
 	"errors"
 
 	"github.com/clarke94/roulette-service/internal/pkg/bet"
+	"github.com/clarke94/roulette-service/internal/pkg/page"
+	"github.com/clarke94/roulette-service/internal/pkg/round"
+	"github.com/clarke94/roulette-service/storage/transaction"
 	"gorm.io/gorm"
 )
 
-var errNoChange = errors.New("no change")
-
 // Storage provides a Storage layer.
 type Storage struct {
 	DB *gorm.DB
@@ -22,11 +23,12 @@ func New(db *gorm.DB) Storage {
 	}
 }
 
-// Create inserts a new record for the given Bet.
+// Create inserts a new record for the given Bet at its first version.
 func (s Storage) Create(ctx context.Context, model bet.Bet) (string, error) {
 	d := domainToStorage(&model)
+	d.Version = 1
 
-	res := s.DB.WithContext(ctx).Create(&d)
+	res := transaction.DB(ctx, s.DB).Create(&d)
 	if res.Error != nil {
 		return "", res.Error
 	}
@@ -34,56 +36,139 @@ func (s Storage) Create(ctx context.Context, model bet.Bet) (string, error) {
 	return d.ID, nil
 }
 
-// List returns all bets from the database for a given table.
-func (s Storage) List(ctx context.Context, tableID string, filters ...bet.Bet) ([]bet.Bet, error) {
+// List returns a page of bets matching the filter for a given table, or across every table when no table is given,
+// and the cursor of the next page.
+func (s Storage) List(ctx context.Context, tableID string, filter bet.Filter) ([]bet.Bet, string, error) {
 	var bets []Bet
 
-	queryFilters := domainListToStorage(filters)
+	db := transaction.DB(ctx, s.DB).Where(&Bet{
+		TableID:  tableID,
+		RoundID:  filter.RoundID,
+		PlayerID: filter.PlayerID,
+		Type:     filter.Type,
+		Currency: filter.Currency,
+	})
+
+	if filter.MinAmount > 0 {
+		db = db.Where("amount >= ?", filter.MinAmount)
+	}
+
+	if filter.MaxAmount > 0 {
+		db = db.Where("amount <= ?", filter.MaxAmount)
+	}
+
+	if !filter.From.IsZero() {
+		db = db.Where("created_at >= ?", filter.From)
+	}
+
+	if !filter.To.IsZero() {
+		db = db.Where("created_at <= ?", filter.To)
+	}
+
+	seek, order := ">", "created_at, id"
+	if filter.Order == page.OrderDesc {
+		seek, order = "<", "created_at desc, id desc"
+	}
+
+	if filter.Cursor != "" {
+		key, id, err := page.Decode(filter.Cursor)
+		if err != nil {
+			return []bet.Bet{}, "", err
+		}
+
+		db = db.Where("(created_at, id) "+seek+" (?, ?)", key, id)
+	}
+
+	size := filter.Size()
 
-	db := s.DB.WithContext(ctx)
+	res := db.Order(order).Limit(size + 1).Find(&bets)
+	if res.Error != nil {
+		return []bet.Bet{}, "", res.Error
+	}
 
-	queryBuilder := db
-	for _, filter := range queryFilters {
-		queryBuilder = queryBuilder.Or(filter)
+	next := ""
+	if len(bets) > size {
+		bets = bets[:size]
+		last := bets[size-1]
+		next = page.Encode(last.CreatedAt, last.ID)
 	}
 
-	db = db.Where(&Bet{TableID: tableID}, queryBuilder)
+	return storageListToDomain(bets), next, nil
+}
+
+// Get returns the bet for the given table and ID.
+func (s Storage) Get(ctx context.Context, tableID, id string) (bet.Bet, error) {
+	var d Bet
 
-	res := db.Find(&bets)
+	res := transaction.DB(ctx, s.DB).Where(&Bet{TableID: tableID}).First(&d, "id = ?", id)
+	if errors.Is(res.Error, gorm.ErrRecordNotFound) {
+		return bet.Bet{}, bet.ErrNotFound
+	}
 
 	if res.Error != nil {
-		return []bet.Bet{}, res.Error
+		return bet.Bet{}, res.Error
 	}
 
-	return storageListToDomain(bets), nil
+	return storageToDomain(&d), nil
 }
 
-// Update inserts a new record for the given Bet.
+// Update writes the given Bet as its next version, only if it is still at the version the change was based on.
 func (s Storage) Update(ctx context.Context, model bet.Bet) (string, error) {
 	d := domainToStorage(&model)
+	d.Version = model.Version + 1
 
-	res := s.DB.WithContext(ctx).Model(&d).Updates(&d)
+	res := transaction.DB(ctx, s.DB).
+		Model(&d).
+		Where("version = ?", model.Version).
+		Select("RoundID", "PlayerID", "Bet", "Type", "Amount", "Currency", "Imprisoned", "ClientSeed", "Version").
+		Updates(&d)
 	if res.Error != nil {
 		return "", res.Error
 	}
 
 	if res.RowsAffected == 0 {
-		return "", errNoChange
+		return "", s.stale(ctx, model.TableID, d.ID)
 	}
 
 	return d.ID, nil
 }
 
+// stale returns why an update to the bet changed nothing, either it does not exist or it has moved on to another
+// version.
+func (s Storage) stale(ctx context.Context, tableID, id string) error {
+	if _, err := s.Get(ctx, tableID, id); err != nil {
+		return err
+	}
+
+	return bet.ErrVersion
+}
+
 // Delete deletes a bet for the given table and ID.
 func (s Storage) Delete(ctx context.Context, tableID, id string) (string, error) {
-	res := s.DB.WithContext(ctx).Where(&Bet{TableID: tableID}).Delete(&Bet{ID: id})
+	res := transaction.DB(ctx, s.DB).Where(&Bet{TableID: tableID}).Delete(&Bet{ID: id})
 	if res.Error != nil {
 		return "", res.Error
 	}
 
 	if res.RowsAffected == 0 {
-		return "", errNoChange
+		return "", bet.ErrNotFound
 	}
 
 	return id, nil
 }
+
+// InPlay reports whether any bet on the given table is in a round that has not been settled yet.
+func (s Storage) InPlay(ctx context.Context, tableID string) (bool, error) {
+	var count int64
+
+	res := transaction.DB(ctx, s.DB).
+		Model(&Bet{}).
+		Joins("JOIN rounds ON rounds.id = bets.round_id").
+		Where("bets.table_id = ? AND rounds.state <> ?", tableID, round.StateSettled).
+		Count(&count)
+	if res.Error != nil {
+		return false, res.Error
+	}
+
+	return count > 0, nil
+}
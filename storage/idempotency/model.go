@@ -0,0 +1,77 @@
+package idempotency
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/clarke94/roulette-service/internal/pkg/idempotency"
+)
+
+// Key is a storage model.
+type Key struct {
+	ID          string `gorm:"primaryKey"`
+	Claim       string
+	Fingerprint string
+	Status      int
+	ContentType string
+	Headers     []byte
+	Body        []byte
+	ExpiresAt   time.Time `gorm:"index"`
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+}
+
+// TableName returns the name of the table the keys are stored in.
+func (Key) TableName() string {
+	return "idempotency_keys"
+}
+
+func domainToStorage(t *idempotency.Key) Key {
+	return Key{
+		ID:          t.ID,
+		Claim:       t.Claim,
+		Fingerprint: t.Fingerprint,
+		Status:      t.Status,
+		ContentType: t.ContentType,
+		Headers:     headersToStorage(t.Headers),
+		Body:        t.Body,
+		ExpiresAt:   t.ExpiresAt,
+	}
+}
+
+func storageToDomain(t *Key) idempotency.Key {
+	return idempotency.Key{
+		ID:          t.ID,
+		Claim:       t.Claim,
+		Fingerprint: t.Fingerprint,
+		Status:      t.Status,
+		ContentType: t.ContentType,
+		Headers:     headersToDomain(t.Headers),
+		Body:        t.Body,
+		ExpiresAt:   t.ExpiresAt,
+	}
+}
+
+// headersToStorage encodes the replayed headers as JSON, storing nothing when there are none.
+func headersToStorage(headers map[string]string) []byte {
+	if len(headers) == 0 {
+		return nil
+	}
+
+	data, _ := json.Marshal(headers)
+
+	return data
+}
+
+// headersToDomain decodes the replayed headers stored as JSON.
+func headersToDomain(data []byte) map[string]string {
+	if len(data) == 0 {
+		return nil
+	}
+
+	var headers map[string]string
+
+	_ = json.Unmarshal(data, &headers)
+
+	return headers
+}
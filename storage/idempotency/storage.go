@@ -0,0 +1,106 @@
+package idempotency
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/clarke94/roulette-service/internal/pkg/idempotency"
+	"github.com/clarke94/roulette-service/storage/transaction"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// Storage provides a Storage layer.
+type Storage struct {
+	DB *gorm.DB
+}
+
+// New initializes Storage.
+func New(db *gorm.DB) Storage {
+	return Storage{
+		DB: db,
+	}
+}
+
+// Get returns the Key with the given ID.
+func (s Storage) Get(ctx context.Context, id string) (idempotency.Key, error) {
+	var d Key
+
+	res := transaction.DB(ctx, s.DB).Where(&Key{ID: id}).First(&d)
+	if errors.Is(res.Error, gorm.ErrRecordNotFound) {
+		return idempotency.Key{}, idempotency.ErrNotFound
+	}
+
+	if res.Error != nil {
+		return idempotency.Key{}, res.Error
+	}
+
+	return storageToDomain(&d), nil
+}
+
+// Create inserts a new record for the given Key, unless another request has already claimed it.
+func (s Storage) Create(ctx context.Context, model idempotency.Key) error {
+	d := domainToStorage(&model)
+
+	res := transaction.DB(ctx, s.DB).Clauses(clause.OnConflict{DoNothing: true}).Create(&d)
+	if res.Error != nil {
+		return res.Error
+	}
+
+	if res.RowsAffected == 0 {
+		return idempotency.ErrInProgress
+	}
+
+	return nil
+}
+
+// Complete records the response for the given Key, unless the claim on it has since been taken over by another
+// request.
+func (s Storage) Complete(ctx context.Context, model idempotency.Key) error {
+	res := transaction.DB(ctx, s.DB).
+		Model(&Key{}).
+		Where("id = ? AND claim = ?", model.ID, model.Claim).
+		Updates(map[string]interface{}{
+			"status":       model.Status,
+			"content_type": model.ContentType,
+			"headers":      headersToStorage(model.Headers),
+			"body":         model.Body,
+			"expires_at":   model.ExpiresAt,
+		})
+	if res.Error != nil {
+		return res.Error
+	}
+
+	if res.RowsAffected == 0 {
+		return idempotency.ErrLeaseLost
+	}
+
+	return nil
+}
+
+// DeleteExpired removes the Key with the given ID when it has expired by now, leaving a Key another request has just
+// claimed in place.
+func (s Storage) DeleteExpired(ctx context.Context, id string, now time.Time) error {
+	res := transaction.DB(ctx, s.DB).Where("id = ? AND expires_at <= ?", id, now).Delete(&Key{})
+	if res.Error != nil {
+		return res.Error
+	}
+
+	return nil
+}
+
+// Delete removes the Key with the given ID held by the given claim so it can be claimed again, unless the claim on it
+// has since been taken over by another request.
+func (s Storage) Delete(ctx context.Context, id, claim string) error {
+	res := transaction.DB(ctx, s.DB).Where("id = ? AND claim = ?", id, claim).Delete(&Key{})
+	if res.Error != nil {
+		return res.Error
+	}
+
+	if res.RowsAffected == 0 {
+		return idempotency.ErrLeaseLost
+	}
+
+	return nil
+}
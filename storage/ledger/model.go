@@ -0,0 +1,80 @@
+package ledger
+
+import (
+	"time"
+
+	"github.com/clarke94/roulette-service/internal/pkg/ledger"
+)
+
+// Entry is a storage model.
+// Entries are never updated or deleted, so it has no UpdatedAt or DeletedAt.
+type Entry struct {
+	ID            string `gorm:"primaryKey"`
+	Type          string `gorm:"index"`
+	BetID         string `gorm:"index"`
+	RoundID       string `gorm:"index"`
+	TableID       string `gorm:"index"`
+	PlayerID      string `gorm:"index"`
+	Currency      string
+	DebitAccount  string
+	CreditAccount string
+	Amount        int64
+	CreatedAt     time.Time `gorm:"index"`
+}
+
+// TableName returns the name of the table the entries are stored in.
+func (Entry) TableName() string {
+	return "ledger_entries"
+}
+
+func domainToStorage(t *ledger.Entry) Entry {
+	return Entry{
+		ID:            t.ID,
+		Type:          t.Type,
+		BetID:         t.BetID,
+		RoundID:       t.RoundID,
+		TableID:       t.TableID,
+		PlayerID:      t.PlayerID,
+		Currency:      t.Currency,
+		DebitAccount:  t.DebitAccount,
+		CreditAccount: t.CreditAccount,
+		Amount:        t.Amount,
+		CreatedAt:     t.CreatedAt,
+	}
+}
+
+func storageToDomain(t *Entry) ledger.Entry {
+	return ledger.Entry{
+		ID:            t.ID,
+		Type:          t.Type,
+		BetID:         t.BetID,
+		RoundID:       t.RoundID,
+		TableID:       t.TableID,
+		PlayerID:      t.PlayerID,
+		Currency:      t.Currency,
+		DebitAccount:  t.DebitAccount,
+		CreditAccount: t.CreditAccount,
+		Amount:        t.Amount,
+		CreatedAt:     t.CreatedAt,
+	}
+}
+
+func domainListToStorage(t []ledger.Entry) []Entry {
+	entries := make([]Entry, len(t))
+
+	for i := range t {
+		entries[i] = domainToStorage(&t[i])
+	}
+
+	return entries
+}
+
+func storageListToDomain(t []Entry) []ledger.Entry {
+	entries := make([]ledger.Entry, len(t))
+
+	for i := range t {
+		entries[i] = storageToDomain(&t[i])
+	}
+
+	return entries
+}
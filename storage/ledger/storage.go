@@ -0,0 +1,76 @@
+package ledger
+
+import (
+	"context"
+
+	"github.com/clarke94/roulette-service/internal/pkg/ledger"
+	"github.com/clarke94/roulette-service/internal/pkg/page"
+	"github.com/clarke94/roulette-service/storage/transaction"
+	"gorm.io/gorm"
+)
+
+// Storage provides a Storage layer.
+type Storage struct {
+	DB *gorm.DB
+}
+
+// New initializes Storage.
+func New(db *gorm.DB) Storage {
+	return Storage{
+		DB: db,
+	}
+}
+
+// Create inserts a new record for each of the given entries.
+func (s Storage) Create(ctx context.Context, entries []ledger.Entry) error {
+	d := domainListToStorage(entries)
+
+	return transaction.DB(ctx, s.DB).Create(&d).Error
+}
+
+// List returns a page of entries matching the filter, oldest first, and the cursor of the next page.
+func (s Storage) List(ctx context.Context, filter ledger.Filter) ([]ledger.Entry, string, error) {
+	var entries []Entry
+
+	db := transaction.DB(ctx, s.DB).Where(&Entry{
+		PlayerID: filter.PlayerID,
+		TableID:  filter.TableID,
+		RoundID:  filter.RoundID,
+		BetID:    filter.BetID,
+		Type:     filter.Type,
+		Currency: filter.Currency,
+	})
+
+	if !filter.From.IsZero() {
+		db = db.Where("created_at >= ?", filter.From)
+	}
+
+	if !filter.To.IsZero() {
+		db = db.Where("created_at <= ?", filter.To)
+	}
+
+	if filter.Cursor != "" {
+		key, id, err := page.Decode(filter.Cursor)
+		if err != nil {
+			return []ledger.Entry{}, "", err
+		}
+
+		db = db.Where("(created_at, id) > (?, ?)", key, id)
+	}
+
+	size := filter.Size()
+
+	res := db.Order("created_at, id").Limit(size + 1).Find(&entries)
+	if res.Error != nil {
+		return []ledger.Entry{}, "", res.Error
+	}
+
+	next := ""
+	if len(entries) > size {
+		entries = entries[:size]
+		last := entries[size-1]
+		next = page.Encode(last.CreatedAt, last.ID)
+	}
+
+	return storageListToDomain(entries), next, nil
+}
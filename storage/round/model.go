@@ -0,0 +1,131 @@
+package round
+
+import (
+	"time"
+
+	"github.com/clarke94/roulette-service/internal/pkg/round"
+	"gorm.io/gorm"
+)
+
+// Round is a storage model.
+type Round struct {
+	ID             string `gorm:"primaryKey"`
+	TableID        string `gorm:"index;uniqueIndex:idx_round_in_play,where:state <> 'settled'"`
+	State          string
+	Number         int
+	Color          string
+	TotalStaked    int64
+	TotalPaid      int64
+	Winners        []Winner `gorm:"foreignKey:RoundID"`
+	ServerSeed     string
+	ServerSeedHash string
+	ClientSeed     string
+	Nonce          int64
+	ProvablyFair   bool
+	Wheel          string
+	SpunAt         *time.Time `gorm:"index"`
+	CreatedAt      time.Time  `gorm:"index"`
+	UpdatedAt      time.Time
+	DeletedAt      gorm.DeletedAt `gorm:"index"`
+}
+
+// Winner is a storage model for a winning bet of a Round.
+type Winner struct {
+	BetID      string `gorm:"primaryKey"`
+	RoundID    string `gorm:"index"`
+	Stake      int64
+	Multiplier int64
+	Winnings   int64
+	Return     int64
+	Currency   string
+	Outcome    string
+	CreatedAt  time.Time
+}
+
+func domainToStorage(t *round.Round) Round {
+	d := Round{
+		ID:             t.ID,
+		TableID:        t.TableID,
+		State:          t.State,
+		Number:         t.Number,
+		Color:          t.Color,
+		TotalStaked:    t.TotalStaked,
+		TotalPaid:      t.TotalPaid,
+		Winners:        make([]Winner, len(t.Winners)),
+		ServerSeed:     t.ServerSeed,
+		ServerSeedHash: t.ServerSeedHash,
+		ClientSeed:     t.ClientSeed,
+		Nonce:          t.Nonce,
+		ProvablyFair:   t.ProvablyFair,
+		Wheel:          t.Wheel,
+		CreatedAt:      t.CreatedAt,
+	}
+
+	for i := range t.Winners {
+		d.Winners[i] = Winner{
+			BetID:      t.Winners[i].BetID,
+			RoundID:    t.ID,
+			Stake:      t.Winners[i].Stake,
+			Multiplier: t.Winners[i].Multiplier,
+			Winnings:   t.Winners[i].Winnings,
+			Return:     t.Winners[i].Return,
+			Currency:   t.Winners[i].Currency,
+			Outcome:    t.Winners[i].Outcome,
+		}
+	}
+
+	if !t.SpunAt.IsZero() {
+		spunAt := t.SpunAt
+		d.SpunAt = &spunAt
+	}
+
+	return d
+}
+
+func storageToDomain(t *Round) round.Round {
+	r := round.Round{
+		ID:             t.ID,
+		TableID:        t.TableID,
+		State:          t.State,
+		Number:         t.Number,
+		Color:          t.Color,
+		TotalStaked:    t.TotalStaked,
+		TotalPaid:      t.TotalPaid,
+		Winners:        make([]round.Winner, len(t.Winners)),
+		CreatedAt:      t.CreatedAt,
+		ServerSeed:     t.ServerSeed,
+		ServerSeedHash: t.ServerSeedHash,
+		ClientSeed:     t.ClientSeed,
+		Nonce:          t.Nonce,
+		ProvablyFair:   t.ProvablyFair,
+		Wheel:          t.Wheel,
+	}
+
+	for i := range t.Winners {
+		r.Winners[i] = round.Winner{
+			BetID:      t.Winners[i].BetID,
+			Stake:      t.Winners[i].Stake,
+			Multiplier: t.Winners[i].Multiplier,
+			Winnings:   t.Winners[i].Winnings,
+			Return:     t.Winners[i].Return,
+			Currency:   t.Winners[i].Currency,
+			Outcome:    t.Winners[i].Outcome,
+		}
+	}
+
+	if t.SpunAt != nil {
+		r.SpunAt = *t.SpunAt
+	}
+
+	return r
+}
+
+func storageListToDomain(t []Round) []round.Round {
+	rounds := make([]round.Round, len(t))
+
+	for i := range t {
+		rounds[i] = storageToDomain(&t[i])
+	}
+
+	return rounds
+}
@@ -0,0 +1,147 @@
+package round
+
+import (
+	"context"
+	"errors"
+
+	"github.com/clarke94/roulette-service/internal/pkg/page"
+	"github.com/clarke94/roulette-service/internal/pkg/round"
+	"github.com/clarke94/roulette-service/storage/transaction"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+var errNoChange = errors.New("no change")
+
+// Storage provides a Storage layer.
+type Storage struct {
+	DB *gorm.DB
+}
+
+// New initializes Storage.
+func New(db *gorm.DB) Storage {
+	return Storage{
+		DB: db,
+	}
+}
+
+// Create inserts a new record for the given Round, failing when the table already has a round in play.
+func (s Storage) Create(ctx context.Context, model round.Round) (string, error) {
+	d := domainToStorage(&model)
+
+	res := transaction.DB(ctx, s.DB).Omit("Winners").Clauses(clause.OnConflict{DoNothing: true}).Create(&d)
+	if res.Error != nil {
+		return "", res.Error
+	}
+
+	if res.RowsAffected == 0 {
+		return "", round.ErrInPlay
+	}
+
+	return d.ID, nil
+}
+
+// Current returns the latest round for the given table that has not been settled.
+func (s Storage) Current(ctx context.Context, tableID string) (round.Round, error) {
+	var d Round
+
+	res := transaction.DB(ctx, s.DB).
+		Where(&Round{TableID: tableID}).
+		Where("state <> ?", round.StateSettled).
+		Order("created_at desc").
+		First(&d)
+	if errors.Is(res.Error, gorm.ErrRecordNotFound) {
+		return round.Round{}, round.ErrNotFound
+	}
+
+	if res.Error != nil {
+		return round.Round{}, res.Error
+	}
+
+	return storageToDomain(&d), nil
+}
+
+// List returns a page of rounds for the given table, newest first, and the cursor of the next page.
+func (s Storage) List(ctx context.Context, tableID string, filter round.Filter) ([]round.Round, string, error) {
+	var rounds []Round
+
+	db := transaction.DB(ctx, s.DB).Preload("Winners").Where(&Round{TableID: tableID})
+
+	if !filter.From.IsZero() {
+		db = db.Where("spun_at >= ?", filter.From)
+	}
+
+	if !filter.To.IsZero() {
+		db = db.Where("spun_at <= ?", filter.To)
+	}
+
+	if filter.Cursor != "" {
+		key, id, err := page.Decode(filter.Cursor)
+		if err != nil {
+			return []round.Round{}, "", err
+		}
+
+		db = db.Where("(created_at, id) < (?, ?)", key, id)
+	}
+
+	size := filter.Size()
+
+	res := db.Order("created_at desc, id desc").Limit(size + 1).Find(&rounds)
+	if res.Error != nil {
+		return []round.Round{}, "", res.Error
+	}
+
+	next := ""
+	if len(rounds) > size {
+		rounds = rounds[:size]
+		last := rounds[size-1]
+		next = page.Encode(last.CreatedAt, last.ID)
+	}
+
+	return storageListToDomain(rounds), next, nil
+}
+
+// Get returns the round for the given table and ID.
+func (s Storage) Get(ctx context.Context, tableID, id string) (round.Round, error) {
+	var d Round
+
+	res := transaction.DB(ctx, s.DB).Preload("Winners").Where(&Round{TableID: tableID}).First(&d, "id = ?", id)
+	if errors.Is(res.Error, gorm.ErrRecordNotFound) {
+		return round.Round{}, round.ErrNotFound
+	}
+
+	if res.Error != nil {
+		return round.Round{}, res.Error
+	}
+
+	return storageToDomain(&d), nil
+}
+
+// Update writes the state and result of the given Round along with any winners.
+func (s Storage) Update(ctx context.Context, model round.Round) (string, error) {
+	d := domainToStorage(&model)
+
+	err := transaction.DB(ctx, s.DB).Transaction(func(tx *gorm.DB) error {
+		res := tx.Model(&d).
+			Select("State", "Number", "Color", "TotalStaked", "TotalPaid", "ClientSeed", "Nonce", "ProvablyFair", "Wheel", "SpunAt").
+			Updates(&d)
+		if res.Error != nil {
+			return res.Error
+		}
+
+		if res.RowsAffected == 0 {
+			return errNoChange
+		}
+
+		if len(d.Winners) == 0 {
+			return nil
+		}
+
+		return tx.Create(&d.Winners).Error
+	})
+	if err != nil {
+		return "", err
+	}
+
+	return d.ID, nil
+}
@@ -9,33 +9,60 @@ import (
 
 // Table is a storage model.
 type Table struct {
-	ID         string `gorm:"primaryKey"`
-	Name       string
-	MaximumBet int
-	MinimumBet int
-	Currency   string
-	CreatedAt  time.Time
-	UpdatedAt  time.Time
-	DeletedAt  gorm.DeletedAt `gorm:"index"`
+	ID                 string `gorm:"primaryKey"`
+	Name               string
+	MaximumBet         int
+	MinimumBet         int
+	Currency           string
+	MaximumPlayerStake int
+	MaximumExposure    int
+	Wheel              string `gorm:"default:european"`
+	Rules              string `gorm:"default:standard"`
+	ProvablyFair       bool
+	BettingWindow      int
+	NoMoreBets         int
+	SpinInterval       int
+	Version            int64 `gorm:"not null;default:1"`
+	CreatedAt          time.Time
+	UpdatedAt          time.Time
+	DeletedAt          gorm.DeletedAt `gorm:"index"`
 }
 
 func domainToStorage(t table.Table) Table {
 	return Table{
-		ID:         t.ID,
-		Name:       t.Name,
-		MaximumBet: t.MaximumBet,
-		MinimumBet: t.MinimumBet,
-		Currency:   t.Currency,
+		ID:                 t.ID,
+		Name:               t.Name,
+		MaximumBet:         t.MaximumBet,
+		MinimumBet:         t.MinimumBet,
+		Currency:           t.Currency,
+		MaximumPlayerStake: t.MaximumPlayerStake,
+		MaximumExposure:    t.MaximumExposure,
+		Wheel:              t.Wheel,
+		Rules:              t.Rules,
+		ProvablyFair:       t.ProvablyFair,
+		BettingWindow:      t.BettingWindow,
+		NoMoreBets:         t.NoMoreBets,
+		SpinInterval:       t.SpinInterval,
+		Version:            t.Version,
 	}
 }
 
 func storageToDomain(t *Table) table.Table {
 	return table.Table{
-		ID:         t.ID,
-		Name:       t.Name,
-		MaximumBet: t.MaximumBet,
-		MinimumBet: t.MinimumBet,
-		Currency:   t.Currency,
+		ID:                 t.ID,
+		Name:               t.Name,
+		MaximumBet:         t.MaximumBet,
+		MinimumBet:         t.MinimumBet,
+		Currency:           t.Currency,
+		MaximumPlayerStake: t.MaximumPlayerStake,
+		MaximumExposure:    t.MaximumExposure,
+		Wheel:              t.Wheel,
+		Rules:              t.Rules,
+		ProvablyFair:       t.ProvablyFair,
+		BettingWindow:      t.BettingWindow,
+		NoMoreBets:         t.NoMoreBets,
+		SpinInterval:       t.SpinInterval,
+		Version:            t.Version,
 	}
 }
 
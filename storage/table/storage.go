@@ -4,12 +4,12 @@ import (
 	"context"
 	"errors"
 
+	"github.com/clarke94/roulette-service/internal/pkg/page"
 	"github.com/clarke94/roulette-service/internal/pkg/table"
+	"github.com/clarke94/roulette-service/storage/transaction"
 	"gorm.io/gorm"
 )
 
-var errNoChange = errors.New("no change")
-
 // Storage provides a Storage layer.
 type Storage struct {
 	DB *gorm.DB
@@ -22,11 +22,12 @@ func New(db *gorm.DB) Storage {
 	}
 }
 
-// Create inserts a new record for the given Table.
+// Create inserts a new record for the given Table at its first version.
 func (s Storage) Create(ctx context.Context, model table.Table) (string, error) {
 	d := domainToStorage(model)
+	d.Version = 1
 
-	res := s.DB.WithContext(ctx).Create(&d)
+	res := transaction.DB(ctx, s.DB).Create(&d)
 	if res.Error != nil {
 		return "", res.Error
 	}
@@ -34,43 +35,114 @@ func (s Storage) Create(ctx context.Context, model table.Table) (string, error)
 	return d.ID, nil
 }
 
-// List returns all tables from the database.
-func (s Storage) List(ctx context.Context) ([]table.Table, error) {
+// List returns a page of tables matching the filter and the cursor of the next page.
+func (s Storage) List(ctx context.Context, filter table.Filter) ([]table.Table, string, error) {
 	var tables []Table
 
-	res := s.DB.WithContext(ctx).Find(&tables)
+	db := transaction.DB(ctx, s.DB).Where(&Table{
+		Currency: filter.Currency,
+		Wheel:    filter.Wheel,
+		Rules:    filter.Rules,
+	})
+
+	if !filter.From.IsZero() {
+		db = db.Where("created_at >= ?", filter.From)
+	}
+
+	if !filter.To.IsZero() {
+		db = db.Where("created_at <= ?", filter.To)
+	}
+
+	seek, order := ">", "created_at, id"
+	if filter.Order == page.OrderDesc {
+		seek, order = "<", "created_at desc, id desc"
+	}
+
+	if filter.Cursor != "" {
+		key, id, err := page.Decode(filter.Cursor)
+		if err != nil {
+			return []table.Table{}, "", err
+		}
+
+		db = db.Where("(created_at, id) "+seek+" (?, ?)", key, id)
+	}
+
+	size := filter.Size()
+
+	res := db.Order(order).Limit(size + 1).Find(&tables)
 	if res.Error != nil {
-		return []table.Table{}, res.Error
+		return []table.Table{}, "", res.Error
+	}
+
+	next := ""
+	if len(tables) > size {
+		tables = tables[:size]
+		last := tables[size-1]
+		next = page.Encode(last.CreatedAt, last.ID)
 	}
 
-	return storageListToDomain(tables), nil
+	return storageListToDomain(tables), next, nil
 }
 
-// Update inserts a new record for the given Table.
+// Get returns the table for the given ID.
+func (s Storage) Get(ctx context.Context, id string) (table.Table, error) {
+	var d Table
+
+	res := transaction.DB(ctx, s.DB).First(&d, "id = ?", id)
+	if errors.Is(res.Error, gorm.ErrRecordNotFound) {
+		return table.Table{}, table.ErrNotFound
+	}
+
+	if res.Error != nil {
+		return table.Table{}, res.Error
+	}
+
+	return storageToDomain(&d), nil
+}
+
+// Update writes the given Table as its next version, only if it is still at the version the change was based on.
 func (s Storage) Update(ctx context.Context, model table.Table) (string, error) {
 	d := domainToStorage(model)
-
-	res := s.DB.WithContext(ctx).Model(&d).Updates(&d)
+	d.Version = model.Version + 1
+
+	res := transaction.DB(ctx, s.DB).
+		Model(&d).
+		Where("version = ?", model.Version).
+		Select(
+			"Name", "MaximumBet", "MinimumBet", "Currency", "MaximumPlayerStake", "MaximumExposure",
+			"Wheel", "Rules", "ProvablyFair", "BettingWindow", "NoMoreBets", "SpinInterval", "Version",
+		).
+		Updates(&d)
 	if res.Error != nil {
 		return "", res.Error
 	}
 
 	if res.RowsAffected == 0 {
-		return "", errNoChange
+		return "", s.stale(ctx, d.ID)
 	}
 
 	return d.ID, nil
 }
 
+// stale returns why an update to the table changed nothing, either it does not exist or it has moved on to
+// another version.
+func (s Storage) stale(ctx context.Context, id string) error {
+	if _, err := s.Get(ctx, id); err != nil {
+		return err
+	}
+
+	return table.ErrVersion
+}
+
 // Delete deletes a table for the given ID.
 func (s Storage) Delete(ctx context.Context, id string) (string, error) {
-	res := s.DB.WithContext(ctx).Delete(&Table{ID: id})
+	res := transaction.DB(ctx, s.DB).Delete(&Table{ID: id})
 	if res.Error != nil {
 		return "", res.Error
 	}
 
 	if res.RowsAffected == 0 {
-		return "", errNoChange
+		return "", table.ErrNotFound
 	}
 
 	return id, nil
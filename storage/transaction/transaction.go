@@ -0,0 +1,65 @@
+// Package transaction shares a single database transaction between storage layers through the context.
+package transaction
+
+import (
+	"context"
+
+	"gorm.io/gorm"
+)
+
+type contextKey struct{}
+
+// Storage provides a Storage layer for running work in a database transaction.
+type Storage struct {
+	DB *gorm.DB
+}
+
+// New initializes Storage.
+func New(db *gorm.DB) Storage {
+	return Storage{
+		DB: db,
+	}
+}
+
+// Transaction runs fn in a database transaction that is committed when fn returns nil and rolled back otherwise.
+// Calls made with a context that already holds a transaction join that transaction.
+func (s Storage) Transaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	if _, ok := ctx.Value(contextKey{}).(*gorm.DB); ok {
+		return fn(ctx)
+	}
+
+	return s.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		return fn(context.WithValue(ctx, contextKey{}, tx))
+	})
+}
+
+// DB returns the transaction held by the context, or the given database when there is none.
+func DB(ctx context.Context, db *gorm.DB) *gorm.DB {
+	if tx, ok := ctx.Value(contextKey{}).(*gorm.DB); ok {
+		return tx.WithContext(ctx)
+	}
+
+	return db.WithContext(ctx)
+}
+
+// Lock takes an exclusive lock on the key, waiting for any other transaction holding a lock on it to end.
+// The lock is held until the transaction held by the context ends.
+func (s Storage) Lock(ctx context.Context, key string) error {
+	return DB(ctx, s.DB).Exec("SELECT pg_advisory_xact_lock(hashtext(?))", key).Error
+}
+
+// LockShared takes a lock on the key that is shared with other LockShared callers but not with Lock or TryLock.
+// The lock is held until the transaction held by the context ends.
+func (s Storage) LockShared(ctx context.Context, key string) error {
+	return DB(ctx, s.DB).Exec("SELECT pg_advisory_xact_lock_shared(hashtext(?))", key).Error
+}
+
+// TryLock takes an exclusive lock on the key without waiting, reporting false when another transaction holds a lock
+// on it. The lock is held until the transaction held by the context ends.
+func (s Storage) TryLock(ctx context.Context, key string) (bool, error) {
+	var locked bool
+
+	err := DB(ctx, s.DB).Raw("SELECT pg_try_advisory_xact_lock(hashtext(?))", key).Scan(&locked).Error
+
+	return locked, err
+}
@@ -0,0 +1,50 @@
+package wallet
+
+import (
+	"time"
+
+	"github.com/clarke94/roulette-service/internal/pkg/wallet"
+	"gorm.io/gorm"
+)
+
+// Wallet is a storage model.
+type Wallet struct {
+	ID        string `gorm:"primaryKey"`
+	PlayerID  string `gorm:"uniqueIndex:idx_wallet_player_currency"`
+	Currency  string `gorm:"uniqueIndex:idx_wallet_player_currency"`
+	Balance   int64
+	Reserved  int64
+	CreatedAt time.Time
+	UpdatedAt time.Time
+	DeletedAt gorm.DeletedAt `gorm:"index"`
+}
+
+func domainToStorage(t *wallet.Wallet) Wallet {
+	return Wallet{
+		ID:       t.ID,
+		PlayerID: t.PlayerID,
+		Currency: t.Currency,
+		Balance:  t.Balance,
+		Reserved: t.Reserved,
+	}
+}
+
+func storageToDomain(t *Wallet) wallet.Wallet {
+	return wallet.Wallet{
+		ID:       t.ID,
+		PlayerID: t.PlayerID,
+		Currency: t.Currency,
+		Balance:  t.Balance,
+		Reserved: t.Reserved,
+	}
+}
+
+func storageListToDomain(t []Wallet) []wallet.Wallet {
+	wallets := make([]wallet.Wallet, len(t))
+
+	for i := range t {
+		wallets[i] = storageToDomain(&t[i])
+	}
+
+	return wallets
+}
@@ -0,0 +1,127 @@
+package wallet
+
+import (
+	"context"
+	"errors"
+
+	"github.com/clarke94/roulette-service/internal/pkg/wallet"
+	"github.com/clarke94/roulette-service/storage/transaction"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+var errNoChange = errors.New("no change")
+
+// Storage provides a Storage layer.
+type Storage struct {
+	DB *gorm.DB
+}
+
+// New initializes Storage.
+func New(db *gorm.DB) Storage {
+	return Storage{
+		DB: db,
+	}
+}
+
+// Create inserts a new record for the given Wallet, failing when the player already has a wallet in its currency.
+func (s Storage) Create(ctx context.Context, model wallet.Wallet) (string, error) {
+	d := domainToStorage(&model)
+
+	res := transaction.DB(ctx, s.DB).Clauses(clause.OnConflict{DoNothing: true}).Create(&d)
+	if res.Error != nil {
+		return "", res.Error
+	}
+
+	if res.RowsAffected == 0 {
+		return "", wallet.ErrExists
+	}
+
+	return d.ID, nil
+}
+
+// List returns all wallets from the database for a given player.
+func (s Storage) List(ctx context.Context, playerID string) ([]wallet.Wallet, error) {
+	var wallets []Wallet
+
+	res := transaction.DB(ctx, s.DB).Where(&Wallet{PlayerID: playerID}).Order("currency").Find(&wallets)
+	if res.Error != nil {
+		return []wallet.Wallet{}, res.Error
+	}
+
+	return storageListToDomain(wallets), nil
+}
+
+// Deposit adds the amount to the balance of the player's wallet in the given currency.
+func (s Storage) Deposit(ctx context.Context, playerID, currency string, amount int64) error {
+	res := transaction.DB(ctx, s.DB).
+		Model(&Wallet{}).
+		Where(&Wallet{PlayerID: playerID, Currency: currency}).
+		Update("balance", gorm.Expr("balance + ?", amount))
+	if res.Error != nil {
+		return res.Error
+	}
+
+	if res.RowsAffected == 0 {
+		return wallet.ErrNotFound
+	}
+
+	return nil
+}
+
+// Reserve holds the amount against the available balance of the player's wallet in the given currency.
+func (s Storage) Reserve(ctx context.Context, playerID, currency string, amount int64) error {
+	res := transaction.DB(ctx, s.DB).
+		Model(&Wallet{}).
+		Where(&Wallet{PlayerID: playerID, Currency: currency}).
+		Where("balance - reserved >= ?", amount).
+		Update("reserved", gorm.Expr("reserved + ?", amount))
+	if res.Error != nil {
+		return res.Error
+	}
+
+	if res.RowsAffected == 0 {
+		return wallet.ErrInsufficientFunds
+	}
+
+	return nil
+}
+
+// Release returns the reserved amount of the player's wallet in the given currency to the available balance.
+func (s Storage) Release(ctx context.Context, playerID, currency string, amount int64) error {
+	res := transaction.DB(ctx, s.DB).
+		Model(&Wallet{}).
+		Where(&Wallet{PlayerID: playerID, Currency: currency}).
+		Where("reserved >= ?", amount).
+		Update("reserved", gorm.Expr("reserved - ?", amount))
+	if res.Error != nil {
+		return res.Error
+	}
+
+	if res.RowsAffected == 0 {
+		return errNoChange
+	}
+
+	return nil
+}
+
+// Settle takes the reserved stake from the player's wallet in the given currency and credits the payout.
+func (s Storage) Settle(ctx context.Context, playerID, currency string, stake, payout int64) error {
+	res := transaction.DB(ctx, s.DB).
+		Model(&Wallet{}).
+		Where(&Wallet{PlayerID: playerID, Currency: currency}).
+		Where("reserved >= ?", stake).
+		Updates(map[string]interface{}{
+			"reserved": gorm.Expr("reserved - ?", stake),
+			"balance":  gorm.Expr("balance - ? + ?", stake, payout),
+		})
+	if res.Error != nil {
+		return res.Error
+	}
+
+	if res.RowsAffected == 0 {
+		return errNoChange
+	}
+
+	return nil
+}
@@ -3,10 +3,12 @@ package test
 import (
 	"context"
 	"github.com/clarke94/roulette-service/internal/pkg/bet"
+	"github.com/clarke94/roulette-service/internal/pkg/page"
 	storage "github.com/clarke94/roulette-service/storage/bet"
 	"github.com/google/go-cmp/cmp"
 	"github.com/google/uuid"
 	"testing"
+	"time"
 )
 
 func TestBetStorage_Create(t *testing.T) {
@@ -22,8 +24,8 @@ func TestBetStorage_Create(t *testing.T) {
 			model: bet.Bet{
 				ID:       "8117bb87-148c-4fb1-8971-a2d4373b3f19",
 				TableID:  "8117bb87-148c-4fb1-8971-a2d4373b3f19",
-				Bet:      "foo",
-				Type:     "bar",
+				Bet:      "17",
+				Type:     "straight",
 				Amount:   10,
 				Currency: "GBP",
 			},
@@ -61,52 +63,120 @@ func TestBetStorage_List(t *testing.T) {
 	tests := []struct {
 		name    string
 		tableID string
-		filters []bet.Bet
+		filter  bet.Filter
 		want    []bet.Bet
 		wantErr bool
 	}{
 		{
 			name:    "expect array of bets given valid request",
 			tableID: "bbbbbbbb-bbbb-bbbb-bbbb-bbbbbbbbbbbb",
-			filters: nil,
+			filter:  bet.Filter{},
 			want: []bet.Bet{
 				{
 					ID:       "aaaaaaaa-aaaa-aaaa-aaaa-aaaaaaaaaaaa",
 					TableID:  "bbbbbbbb-bbbb-bbbb-bbbb-bbbbbbbbbbbb",
-					Bet:      "foo",
-					Type:     "bar",
+					RoundID:  "dddddddd-dddd-dddd-dddd-dddddddddddd",
+					PlayerID: "aaaaaaaa-0000-0000-0000-aaaaaaaaaaaa",
+					Bet:      "17",
+					Type:     "straight",
 					Amount:   10,
 					Currency: "GBP",
+					Version:  1,
 				},
 			},
 			wantErr: false,
 		},
 		{
-			name:    "expect array of bets given valid request with filter",
+			name:    "expect array of bets given type, currency and amount filters",
 			tableID: "bbbbbbbb-bbbb-bbbb-bbbb-bbbbbbbbbbbb",
-			filters: []bet.Bet{
+			filter:  bet.Filter{Type: "straight", Currency: "GBP", MinAmount: 10, MaxAmount: 10},
+			want: []bet.Bet{
+				{
+					ID:       "aaaaaaaa-aaaa-aaaa-aaaa-aaaaaaaaaaaa",
+					TableID:  "bbbbbbbb-bbbb-bbbb-bbbb-bbbbbbbbbbbb",
+					RoundID:  "dddddddd-dddd-dddd-dddd-dddddddddddd",
+					PlayerID: "aaaaaaaa-0000-0000-0000-aaaaaaaaaaaa",
+					Bet:      "17",
+					Type:     "straight",
+					Amount:   10,
+					Currency: "GBP",
+					Version:  1,
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name:    "expect array of bets given round filter",
+			tableID: "bbbbbbbb-bbbb-bbbb-bbbb-bbbbbbbbbbbb",
+			filter:  bet.Filter{RoundID: "dddddddd-dddd-dddd-dddd-dddddddddddd"},
+			want: []bet.Bet{
 				{
-					ID: "aaaaaaaa-aaaa-aaaa-aaaa-aaaaaaaaaaaa",
+					ID:       "aaaaaaaa-aaaa-aaaa-aaaa-aaaaaaaaaaaa",
+					TableID:  "bbbbbbbb-bbbb-bbbb-bbbb-bbbbbbbbbbbb",
+					RoundID:  "dddddddd-dddd-dddd-dddd-dddddddddddd",
+					PlayerID: "aaaaaaaa-0000-0000-0000-aaaaaaaaaaaa",
+					Bet:      "17",
+					Type:     "straight",
+					Amount:   10,
+					Currency: "GBP",
+					Version:  1,
 				},
 			},
+			wantErr: false,
+		},
+		{
+			name:    "expect array of bets given player filter across all tables",
+			tableID: "",
+			filter:  bet.Filter{PlayerID: "aaaaaaaa-0000-0000-0000-aaaaaaaaaaaa"},
 			want: []bet.Bet{
 				{
 					ID:       "aaaaaaaa-aaaa-aaaa-aaaa-aaaaaaaaaaaa",
 					TableID:  "bbbbbbbb-bbbb-bbbb-bbbb-bbbbbbbbbbbb",
-					Bet:      "foo",
-					Type:     "bar",
+					RoundID:  "dddddddd-dddd-dddd-dddd-dddddddddddd",
+					PlayerID: "aaaaaaaa-0000-0000-0000-aaaaaaaaaaaa",
+					Bet:      "17",
+					Type:     "straight",
 					Amount:   10,
 					Currency: "GBP",
+					Version:  1,
 				},
 			},
 			wantErr: false,
 		},
+		{
+			name:    "expect no bets given another round",
+			tableID: "bbbbbbbb-bbbb-bbbb-bbbb-bbbbbbbbbbbb",
+			filter:  bet.Filter{RoundID: "eeeeeeee-eeee-eeee-eeee-eeeeeeeeeeee"},
+			want:    []bet.Bet{},
+			wantErr: false,
+		},
+		{
+			name:    "expect no bets given amount above the range",
+			tableID: "bbbbbbbb-bbbb-bbbb-bbbb-bbbbbbbbbbbb",
+			filter:  bet.Filter{MinAmount: 11},
+			want:    []bet.Bet{},
+			wantErr: false,
+		},
+		{
+			name:    "expect no bets given created before the range",
+			tableID: "bbbbbbbb-bbbb-bbbb-bbbb-bbbbbbbbbbbb",
+			filter:  bet.Filter{To: time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)},
+			want:    []bet.Bet{},
+			wantErr: false,
+		},
+		{
+			name:    "expect fail given invalid cursor",
+			tableID: "bbbbbbbb-bbbb-bbbb-bbbb-bbbbbbbbbbbb",
+			filter:  bet.Filter{Page: page.Page{Cursor: "foo"}},
+			want:    []bet.Bet{},
+			wantErr: true,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			s := storage.New(db)
 
-			got, err := s.List(context.Background(), tt.tableID, tt.filters...)
+			got, _, err := s.List(context.Background(), tt.tableID, tt.filter)
 
 			if !cmp.Equal(err != nil, tt.wantErr) {
 				t.Fatal(cmp.Diff(err != nil, tt.wantErr))
@@ -132,8 +202,8 @@ func TestBetStorage_Update(t *testing.T) {
 			model: bet.Bet{
 				ID:       uuid.New().String(),
 				TableID:  uuid.New().String(),
-				Bet:      "foo",
-				Type:     "bar",
+				Bet:      "17",
+				Type:     "straight",
 				Amount:   10,
 				Currency: "GBP",
 			},
@@ -146,15 +216,31 @@ func TestBetStorage_Update(t *testing.T) {
 			model: bet.Bet{
 				ID:       "aaaaaaaa-aaaa-aaaa-aaaa-aaaaaaaaaaaa",
 				TableID:  "bbbbbbbb-bbbb-bbbb-bbbb-bbbbbbbbbbbb",
-				Bet:      "foo",
-				Type:     "bar",
+				Bet:      "17",
+				Type:     "straight",
 				Amount:   10,
 				Currency: "GBP",
+				Version:  1,
 			},
 			ctx:     context.Background(),
 			want:    "aaaaaaaa-aaaa-aaaa-aaaa-aaaaaaaaaaaa",
 			wantErr: false,
 		},
+		{
+			name: "expect fail given bet has changed since the version",
+			model: bet.Bet{
+				ID:       "aaaaaaaa-aaaa-aaaa-aaaa-aaaaaaaaaaaa",
+				TableID:  "bbbbbbbb-bbbb-bbbb-bbbb-bbbbbbbbbbbb",
+				Bet:      "17",
+				Type:     "straight",
+				Amount:   10,
+				Currency: "GBP",
+				Version:  1,
+			},
+			ctx:     context.Background(),
+			want:    "",
+			wantErr: true,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -172,6 +258,97 @@ func TestBetStorage_Update(t *testing.T) {
 	}
 }
 
+func TestBetStorage_UpdateZeroValues(t *testing.T) {
+	s := storage.New(db)
+	ctx := context.Background()
+
+	model := bet.Bet{
+		ID:         uuid.New().String(),
+		TableID:    "bbbbbbbb-bbbb-bbbb-bbbb-bbbbbbbbbbbb",
+		RoundID:    "dddddddd-dddd-dddd-dddd-dddddddddddd",
+		PlayerID:   "aaaaaaaa-0000-0000-0000-aaaaaaaaaaaa",
+		Bet:        "red",
+		Type:       "red/black",
+		Amount:     10,
+		Currency:   "GBP",
+		Imprisoned: true,
+	}
+
+	if _, err := s.Create(ctx, model); err != nil {
+		t.Fatal(err)
+	}
+
+	t.Cleanup(func() {
+		_, _ = s.Delete(ctx, model.TableID, model.ID)
+	})
+
+	model.Imprisoned = false
+	model.Version = 1
+
+	if _, err := s.Update(ctx, model); err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := s.Get(ctx, model.TableID, model.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	model.Version = 2
+
+	if !cmp.Equal(got, model) {
+		t.Fatal(cmp.Diff(got, model))
+	}
+}
+
+func TestBetStorage_InPlay(t *testing.T) {
+	inPlay := uuid.New().String()
+
+	if _, err := storage.New(db).Create(context.Background(), bet.Bet{
+		ID:       uuid.New().String(),
+		TableID:  inPlay,
+		RoundID:  "dddddddd-dddd-dddd-dddd-dddddddddddd",
+		PlayerID: uuid.New().String(),
+		Bet:      "17",
+		Type:     bet.TypeStraight,
+		Amount:   10,
+		Currency: "GBP",
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name    string
+		tableID string
+		want    bool
+	}{
+		{
+			name:    "expect true given bet on an open round",
+			tableID: inPlay,
+			want:    true,
+		},
+		{
+			name:    "expect false given no bets",
+			tableID: uuid.New().String(),
+			want:    false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := storage.New(db)
+
+			got, err := s.InPlay(context.Background(), tt.tableID)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if !cmp.Equal(got, tt.want) {
+				t.Error(cmp.Diff(got, tt.want))
+			}
+		})
+	}
+}
+
 func TestBetStorage_Delete(t *testing.T) {
 	tests := []struct {
 		name    string
@@ -6,8 +6,10 @@ var BetData = []bet.Bet{
 	{
 		ID:       "aaaaaaaa-aaaa-aaaa-aaaa-aaaaaaaaaaaa",
 		TableID:  "bbbbbbbb-bbbb-bbbb-bbbb-bbbbbbbbbbbb",
-		Bet:      "foo",
-		Type:     "bar",
+		RoundID:  "dddddddd-dddd-dddd-dddd-dddddddddddd",
+		PlayerID: "aaaaaaaa-0000-0000-0000-aaaaaaaaaaaa",
+		Bet:      "17",
+		Type:     "straight",
 		Amount:   10,
 		Currency: "GBP",
 	},
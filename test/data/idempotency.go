@@ -0,0 +1,19 @@
+package data
+
+import (
+	"time"
+
+	"github.com/clarke94/roulette-service/storage/idempotency"
+)
+
+var IdempotencyData = []idempotency.Key{
+	{
+		ID:          "a1b2c3d4-0000-0000-0000-000000000001",
+		Claim:       "foo",
+		Fingerprint: "foo",
+		Status:      201,
+		ContentType: "application/json; charset=utf-8",
+		Body:        []byte(`{"id":"bar"}`),
+		ExpiresAt:   time.Date(2100, 1, 1, 0, 0, 0, 0, time.UTC),
+	},
+}
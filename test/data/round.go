@@ -0,0 +1,11 @@
+package data
+
+import "github.com/clarke94/roulette-service/storage/round"
+
+var RoundData = []round.Round{
+	{
+		ID:      "dddddddd-dddd-dddd-dddd-dddddddddddd",
+		TableID: "bbbbbbbb-bbbb-bbbb-bbbb-bbbbbbbbbbbb",
+		State:   "open",
+	},
+}
@@ -0,0 +1,12 @@
+package data
+
+import "github.com/clarke94/roulette-service/storage/wallet"
+
+var WalletData = []wallet.Wallet{
+	{
+		ID:       "eeeeeeee-eeee-eeee-eeee-eeeeeeeeeeee",
+		PlayerID: "aaaaaaaa-0000-0000-0000-aaaaaaaaaaaa",
+		Currency: "GBP",
+		Balance:  1000,
+	},
+}
@@ -0,0 +1,203 @@
+package test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/clarke94/roulette-service/internal/pkg/idempotency"
+	storage "github.com/clarke94/roulette-service/storage/idempotency"
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+)
+
+func TestIdempotencyStorage_Get(t *testing.T) {
+	tests := []struct {
+		name    string
+		id      string
+		want    int
+		wantErr error
+	}{
+		{
+			name:    "expect key given existing key",
+			id:      "a1b2c3d4-0000-0000-0000-000000000001",
+			want:    201,
+			wantErr: nil,
+		},
+		{
+			name:    "expect not found given missing key",
+			id:      "foo",
+			want:    0,
+			wantErr: idempotency.ErrNotFound,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := storage.New(db)
+
+			got, err := s.Get(context.Background(), tt.id)
+			if !cmp.Equal(err, tt.wantErr, cmpopts.EquateErrors()) {
+				t.Fatal(cmp.Diff(err, tt.wantErr, cmpopts.EquateErrors()))
+			}
+
+			if !cmp.Equal(got.Status, tt.want) {
+				t.Fatal(cmp.Diff(got.Status, tt.want))
+			}
+		})
+	}
+}
+
+func TestIdempotencyStorage_Create(t *testing.T) {
+	tests := []struct {
+		name    string
+		model   idempotency.Key
+		wantErr error
+	}{
+		{
+			name:    "expect success given new key",
+			model:   idempotency.Key{ID: "a1b2c3d4-0000-0000-0000-000000000002", Fingerprint: "foo"},
+			wantErr: nil,
+		},
+		{
+			name:    "expect in progress given key already claimed",
+			model:   idempotency.Key{ID: "a1b2c3d4-0000-0000-0000-000000000001", Fingerprint: "foo"},
+			wantErr: idempotency.ErrInProgress,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := storage.New(db)
+
+			err := s.Create(context.Background(), tt.model)
+			if !cmp.Equal(err, tt.wantErr, cmpopts.EquateErrors()) {
+				t.Fatal(cmp.Diff(err, tt.wantErr, cmpopts.EquateErrors()))
+			}
+		})
+	}
+}
+
+func TestIdempotencyStorage_Complete(t *testing.T) {
+	tests := []struct {
+		name    string
+		model   idempotency.Key
+		wantErr error
+	}{
+		{
+			name: "expect success given claimed key",
+			model: idempotency.Key{
+				ID:          "a1b2c3d4-0000-0000-0000-000000000001",
+				Claim:       "foo",
+				Status:      200,
+				ContentType: "application/json; charset=utf-8",
+				Headers:     map[string]string{"ETag": `"1"`},
+				Body:        []byte(`{}`),
+				ExpiresAt:   time.Date(2100, 1, 2, 0, 0, 0, 0, time.UTC),
+			},
+			wantErr: nil,
+		},
+		{
+			name:    "expect lease lost given key claimed by another request",
+			model:   idempotency.Key{ID: "a1b2c3d4-0000-0000-0000-000000000001", Claim: "bar", Status: 500},
+			wantErr: idempotency.ErrLeaseLost,
+		},
+		{
+			name:    "expect lease lost given missing key",
+			model:   idempotency.Key{ID: "foo", Claim: "foo", Status: 200},
+			wantErr: idempotency.ErrLeaseLost,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := storage.New(db)
+
+			err := s.Complete(context.Background(), tt.model)
+			if !cmp.Equal(err, tt.wantErr, cmpopts.EquateErrors()) {
+				t.Fatal(cmp.Diff(err, tt.wantErr, cmpopts.EquateErrors()))
+			}
+
+			if err != nil {
+				return
+			}
+
+			got, err := s.Get(context.Background(), tt.model.ID)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if !cmp.Equal(got, tt.model, cmpopts.IgnoreFields(idempotency.Key{}, "Fingerprint")) {
+				t.Error(cmp.Diff(got, tt.model, cmpopts.IgnoreFields(idempotency.Key{}, "Fingerprint")))
+			}
+		})
+	}
+}
+
+func TestIdempotencyStorage_Delete(t *testing.T) {
+	s := storage.New(db)
+
+	if err := s.Create(context.Background(), idempotency.Key{ID: "a1b2c3d4-0000-0000-0000-000000000003", Claim: "foo"}); err != nil {
+		t.Fatal(err)
+	}
+
+	err := s.Delete(context.Background(), "a1b2c3d4-0000-0000-0000-000000000003", "bar")
+	if !cmp.Equal(err, idempotency.ErrLeaseLost, cmpopts.EquateErrors()) {
+		t.Fatal(cmp.Diff(err, idempotency.ErrLeaseLost, cmpopts.EquateErrors()))
+	}
+
+	if err := s.Delete(context.Background(), "a1b2c3d4-0000-0000-0000-000000000003", "foo"); err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = s.Get(context.Background(), "a1b2c3d4-0000-0000-0000-000000000003")
+	if !cmp.Equal(err, idempotency.ErrNotFound, cmpopts.EquateErrors()) {
+		t.Fatal(cmp.Diff(err, idempotency.ErrNotFound, cmpopts.EquateErrors()))
+	}
+}
+
+func TestIdempotencyStorage_DeleteExpired(t *testing.T) {
+	now := time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name      string
+		model     idempotency.Key
+		wantErr   error
+		wantFound bool
+	}{
+		{
+			name: "expect key deleted given key expired",
+			model: idempotency.Key{
+				ID:        "a1b2c3d4-0000-0000-0000-000000000004",
+				ExpiresAt: now.Add(-time.Minute),
+			},
+			wantErr:   nil,
+			wantFound: false,
+		},
+		{
+			name: "expect key kept given key not expired",
+			model: idempotency.Key{
+				ID:        "a1b2c3d4-0000-0000-0000-000000000005",
+				ExpiresAt: now.Add(time.Minute),
+			},
+			wantErr:   nil,
+			wantFound: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := storage.New(db)
+
+			if err := s.Create(context.Background(), tt.model); err != nil {
+				t.Fatal(err)
+			}
+
+			err := s.DeleteExpired(context.Background(), tt.model.ID, now)
+			if !cmp.Equal(err, tt.wantErr, cmpopts.EquateErrors()) {
+				t.Fatal(cmp.Diff(err, tt.wantErr, cmpopts.EquateErrors()))
+			}
+
+			_, err = s.Get(context.Background(), tt.model.ID)
+			if !cmp.Equal(err == nil, tt.wantFound) {
+				t.Error(cmp.Diff(err == nil, tt.wantFound))
+			}
+		})
+	}
+}
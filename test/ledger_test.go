@@ -0,0 +1,123 @@
+package test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/clarke94/roulette-service/internal/pkg/ledger"
+	"github.com/clarke94/roulette-service/internal/pkg/page"
+	storage "github.com/clarke94/roulette-service/storage/ledger"
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+)
+
+func TestLedgerStorage_CreateAndList(t *testing.T) {
+	const betID = "b1b1b1b1-0000-0000-0000-b1b1b1b1b1b1"
+
+	created := time.Date(2021, 6, 21, 21, 0, 0, 0, time.UTC)
+	entries := []ledger.Entry{
+		{
+			ID:            "b1b1b1b1-1111-1111-1111-b1b1b1b1b1b1",
+			Type:          ledger.TypeStakeReserved,
+			BetID:         betID,
+			RoundID:       "b2b2b2b2-0000-0000-0000-b2b2b2b2b2b2",
+			TableID:       "b3b3b3b3-0000-0000-0000-b3b3b3b3b3b3",
+			PlayerID:      "b4b4b4b4-0000-0000-0000-b4b4b4b4b4b4",
+			Currency:      "GBP",
+			DebitAccount:  ledger.AccountReserved,
+			CreditAccount: ledger.AccountAvailable,
+			Amount:        100,
+			CreatedAt:     created,
+		},
+		{
+			ID:            "b1b1b1b1-2222-2222-2222-b1b1b1b1b1b1",
+			Type:          ledger.TypeStakeReturned,
+			BetID:         betID,
+			RoundID:       "b2b2b2b2-0000-0000-0000-b2b2b2b2b2b2",
+			TableID:       "b3b3b3b3-0000-0000-0000-b3b3b3b3b3b3",
+			PlayerID:      "b4b4b4b4-0000-0000-0000-b4b4b4b4b4b4",
+			Currency:      "GBP",
+			DebitAccount:  ledger.AccountAvailable,
+			CreditAccount: ledger.AccountReserved,
+			Amount:        100,
+			CreatedAt:     created.Add(time.Minute),
+		},
+		{
+			ID:            "b1b1b1b1-3333-3333-3333-b1b1b1b1b1b1",
+			Type:          ledger.TypeWinningsPaid,
+			BetID:         betID,
+			RoundID:       "b2b2b2b2-0000-0000-0000-b2b2b2b2b2b2",
+			TableID:       "b3b3b3b3-0000-0000-0000-b3b3b3b3b3b3",
+			PlayerID:      "b4b4b4b4-0000-0000-0000-b4b4b4b4b4b4",
+			Currency:      "GBP",
+			DebitAccount:  ledger.AccountAvailable,
+			CreditAccount: ledger.AccountHouse,
+			Amount:        3500,
+			CreatedAt:     created.Add(time.Minute),
+		},
+	}
+
+	s := storage.New(db)
+
+	if err := s.Create(context.Background(), entries); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name     string
+		filter   ledger.Filter
+		want     []ledger.Entry
+		wantNext bool
+		wantErr  error
+	}{
+		{
+			name:   "expect all entries of the bet oldest first",
+			filter: ledger.Filter{BetID: betID},
+			want:   entries,
+		},
+		{
+			name:   "expect entries of the given type",
+			filter: ledger.Filter{BetID: betID, Type: ledger.TypeWinningsPaid},
+			want:   entries[2:],
+		},
+		{
+			name:   "expect entries within the given times",
+			filter: ledger.Filter{BetID: betID, From: created.Add(time.Second), To: created.Add(time.Hour)},
+			want:   entries[1:],
+		},
+		{
+			name:     "expect first page given limit",
+			filter:   ledger.Filter{Page: page.Page{Limit: 2}, BetID: betID},
+			want:     entries[:2],
+			wantNext: true,
+		},
+		{
+			name:   "expect next page given cursor",
+			filter: ledger.Filter{Page: page.Page{Limit: 2, Cursor: page.Encode(entries[1].CreatedAt, entries[1].ID)}, BetID: betID},
+			want:   entries[2:],
+		},
+		{
+			name:    "expect fail given invalid cursor",
+			filter:  ledger.Filter{Page: page.Page{Cursor: "foo"}, BetID: betID},
+			want:    []ledger.Entry{},
+			wantErr: page.ErrCursor,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, next, err := s.List(context.Background(), tt.filter)
+			if !cmp.Equal(err, tt.wantErr, cmpopts.EquateErrors()) {
+				t.Fatal(cmp.Diff(err, tt.wantErr, cmpopts.EquateErrors()))
+			}
+
+			if !cmp.Equal(got, tt.want, cmpopts.EquateApproxTime(time.Second)) {
+				t.Error(cmp.Diff(got, tt.want, cmpopts.EquateApproxTime(time.Second)))
+			}
+
+			if !cmp.Equal(next != "", tt.wantNext) {
+				t.Error(cmp.Diff(next != "", tt.wantNext))
+			}
+		})
+	}
+}
@@ -2,7 +2,11 @@ package test
 
 import (
 	"github.com/clarke94/roulette-service/storage/bet"
+	"github.com/clarke94/roulette-service/storage/idempotency"
+	"github.com/clarke94/roulette-service/storage/ledger"
+	"github.com/clarke94/roulette-service/storage/round"
 	"github.com/clarke94/roulette-service/storage/table"
+	"github.com/clarke94/roulette-service/storage/wallet"
 	"github.com/clarke94/roulette-service/test/data"
 	"github.com/ory/dockertest/v3"
 	"github.com/ory/dockertest/v3/docker"
@@ -71,13 +75,16 @@ func TestMain(m *testing.M) {
 		log.Fatalf("Could not connect to docker: %s", err)
 	}
 
-	err = db.AutoMigrate(table.Table{}, bet.Bet{})
+	err = db.AutoMigrate(table.Table{}, bet.Bet{}, round.Round{}, round.Winner{}, wallet.Wallet{}, idempotency.Key{}, ledger.Entry{})
 	if err != nil {
 		log.Fatalf("Could not migrate data: %s", err)
 	}
 
 	db.Create(data.TableData)
 	db.Create(data.BetData)
+	db.Create(data.RoundData)
+	db.Create(data.WalletData)
+	db.Create(data.IdempotencyData)
 
 	code := m.Run()
 
@@ -0,0 +1,373 @@
+package test
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/clarke94/roulette-service/internal/pkg/bet"
+	"github.com/clarke94/roulette-service/internal/pkg/event"
+	"github.com/clarke94/roulette-service/internal/pkg/ledger"
+	"github.com/clarke94/roulette-service/internal/pkg/rng"
+	"github.com/clarke94/roulette-service/internal/pkg/round"
+	"github.com/clarke94/roulette-service/internal/pkg/table"
+	"github.com/clarke94/roulette-service/internal/pkg/wallet"
+	betStorage "github.com/clarke94/roulette-service/storage/bet"
+	ledgerStorage "github.com/clarke94/roulette-service/storage/ledger"
+	roundStorage "github.com/clarke94/roulette-service/storage/round"
+	tableStorage "github.com/clarke94/roulette-service/storage/table"
+	"github.com/clarke94/roulette-service/storage/transaction"
+	walletStorage "github.com/clarke94/roulette-service/storage/wallet"
+	"github.com/google/go-cmp/cmp"
+	"github.com/sirupsen/logrus"
+)
+
+// defaultMaxIdleConns is the number of idle connections database/sql keeps by default.
+const defaultMaxIdleConns = 2
+
+func TestBetController_PlayConcurrently(t *testing.T) {
+	const (
+		tableID = "a9a9a9a9-0000-0000-0000-a9a9a9a9a9a9"
+		plays   = 10
+	)
+
+	ctx := context.Background()
+	logger := logrus.New()
+
+	tables := table.New(logger, tableStorage.New(db), betStorage.New(db), transaction.New(db))
+	rounds := round.New(logger, roundStorage.New(db), tables)
+	entries := ledger.New(logger, ledgerStorage.New(db))
+	wallets := wallet.New(logger, walletStorage.New(db), entries, transaction.New(db))
+	c := bet.New(logger, betStorage.New(db), tables, rounds, wallets, entries, transaction.New(db), rng.NewCrypto(), event.NewHub())
+
+	if _, err := tableStorage.New(db).Create(ctx, table.Table{
+		ID:         tableID,
+		MinimumBet: 10,
+		MaximumBet: 1000,
+		Currency:   "GBP",
+		Wheel:      table.WheelEuropean,
+		Rules:      table.RulesStandard,
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	// remove the table once played so it does not show up when listing the seeded tables
+	t.Cleanup(func() {
+		_, _ = tableStorage.New(db).Delete(ctx, tableID)
+	})
+
+	if _, err := rounds.Current(ctx, tableID); err != nil {
+		t.Fatal(err)
+	}
+
+	// hold the play lock of the table in another transaction so that every play finds the table in play
+	holder := db.Begin()
+	if err := holder.Exec("SELECT pg_advisory_xact_lock(hashtext(?))", "play:"+tableID).Error; err != nil {
+		t.Fatal(err)
+	}
+
+	played, rejected := playConcurrently(t, c, tableID, plays)
+
+	holder.Rollback()
+
+	if !cmp.Equal(played, 0) {
+		t.Error(cmp.Diff(played, 0))
+	}
+
+	if !cmp.Equal(rejected, plays) {
+		t.Error(cmp.Diff(rejected, plays))
+	}
+
+	// hold the lock of the table as a bet being placed does, so that the play waits for the bet rather than being
+	// rejected
+	holder = db.Begin()
+	if err := holder.Exec("SELECT pg_advisory_xact_lock_shared(hashtext(?))", tableID).Error; err != nil {
+		t.Fatal(err)
+	}
+
+	time.AfterFunc(500*time.Millisecond, func() {
+		holder.Rollback()
+	})
+
+	played, rejected = playConcurrently(t, c, tableID, plays)
+
+	if played == 0 {
+		t.Error("expect a play to wait for the bet in progress")
+	}
+
+	if !cmp.Equal(played+rejected, plays) {
+		t.Error(cmp.Diff(played+rejected, plays))
+	}
+
+	total := played
+
+	// once released the plays race for the lock, and each play that wins it settles exactly one round
+	played, rejected = playConcurrently(t, c, tableID, plays)
+
+	if played == 0 {
+		t.Error("expect at least one play to win the lock")
+	}
+
+	if !cmp.Equal(played+rejected, plays) {
+		t.Error(cmp.Diff(played+rejected, plays))
+	}
+
+	total += played
+
+	settled, _, err := roundStorage.New(db).List(ctx, tableID, round.Filter{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	count := 0
+	for i := range settled {
+		if settled[i].State == round.StateSettled {
+			count++
+		}
+	}
+
+	if !cmp.Equal(count, total) {
+		t.Error(cmp.Diff(count, total))
+	}
+}
+
+// playConcurrently plays the table from the given number of goroutines at once, returning how many played and how
+// many were rejected as the table was already in play.
+func playConcurrently(t *testing.T, c bet.Controller, tableID string, plays int) (int, int) {
+	t.Helper()
+
+	// open a connection for every play up front so that they all reach the database together
+	d, err := db.DB()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	d.SetMaxIdleConns(plays)
+	defer d.SetMaxIdleConns(defaultMaxIdleConns)
+
+	var warm sync.WaitGroup
+	for i := 0; i < plays; i++ {
+		warm.Add(1)
+
+		go func() {
+			defer warm.Done()
+
+			db.Exec("SELECT pg_sleep(0.1)")
+		}()
+	}
+
+	warm.Wait()
+
+	var (
+		wg       sync.WaitGroup
+		mu       sync.Mutex
+		start    = make(chan struct{})
+		played   int
+		rejected int
+	)
+
+	for i := 0; i < plays; i++ {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			<-start
+
+			_, err := c.Play(context.Background(), tableID)
+
+			mu.Lock()
+			defer mu.Unlock()
+
+			switch {
+			case err == nil:
+				played++
+			case errors.Is(err, bet.ErrInPlay):
+				rejected++
+			default:
+				t.Error(err)
+			}
+		}()
+	}
+
+	close(start)
+	wg.Wait()
+
+	return played, rejected
+}
+
+func TestBetController_CreateConcurrently(t *testing.T) {
+	const (
+		tableID  = "c7c7c7c7-0000-0000-0000-c7c7c7c7c7c7"
+		playerID = "c7c7c7c7-1111-1111-1111-c7c7c7c7c7c7"
+		bets     = 10
+		limit    = 3
+	)
+
+	ctx := context.Background()
+	logger := logrus.New()
+
+	tables := table.New(logger, tableStorage.New(db), betStorage.New(db), transaction.New(db))
+	rounds := round.New(logger, roundStorage.New(db), tables)
+	entries := ledger.New(logger, ledgerStorage.New(db))
+	wallets := wallet.New(logger, walletStorage.New(db), entries, transaction.New(db))
+	c := bet.New(logger, betStorage.New(db), tables, rounds, wallets, entries, transaction.New(db), rng.NewCrypto(), event.NewHub())
+
+	if _, err := tableStorage.New(db).Create(ctx, table.Table{
+		ID:                 tableID,
+		MinimumBet:         10,
+		MaximumBet:         1000,
+		MaximumPlayerStake: 100 * limit,
+		Currency:           "GBP",
+		Wheel:              table.WheelEuropean,
+		Rules:              table.RulesStandard,
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	// remove the table once played so it does not show up when listing the seeded tables
+	t.Cleanup(func() {
+		_, _ = tableStorage.New(db).Delete(ctx, tableID)
+	})
+
+	if _, err := wallets.Create(ctx, wallet.Wallet{PlayerID: playerID, Currency: "GBP", Balance: 100 * bets}); err != nil {
+		t.Fatal(err)
+	}
+
+	var (
+		wg       sync.WaitGroup
+		mu       sync.Mutex
+		start    = make(chan struct{})
+		placed   int
+		rejected int
+	)
+
+	for i := 0; i < bets; i++ {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			<-start
+
+			_, err := c.Create(ctx, bet.Bet{
+				TableID:  tableID,
+				PlayerID: playerID,
+				Bet:      "17",
+				Type:     bet.TypeStraight,
+				Amount:   100,
+				Currency: "GBP",
+			})
+
+			mu.Lock()
+			defer mu.Unlock()
+
+			switch {
+			case err == nil:
+				placed++
+			case errors.Is(err, bet.ErrPlayerStake):
+				rejected++
+			default:
+				t.Error(err)
+			}
+		}()
+	}
+
+	close(start)
+	wg.Wait()
+
+	if !cmp.Equal(placed, limit) {
+		t.Error(cmp.Diff(placed, limit))
+	}
+
+	if !cmp.Equal(rejected, bets-limit) {
+		t.Error(cmp.Diff(rejected, bets-limit))
+	}
+}
+
+func TestBetController_DeleteTable(t *testing.T) {
+	const (
+		tableID  = "b8b8b8b8-0000-0000-0000-b8b8b8b8b8b8"
+		playerID = "b8b8b8b8-1111-1111-1111-b8b8b8b8b8b8"
+	)
+
+	ctx := context.Background()
+	logger := logrus.New()
+
+	tables := table.New(logger, tableStorage.New(db), betStorage.New(db), transaction.New(db))
+	rounds := round.New(logger, roundStorage.New(db), tables)
+	entries := ledger.New(logger, ledgerStorage.New(db))
+	wallets := wallet.New(logger, walletStorage.New(db), entries, transaction.New(db))
+	c := bet.New(logger, betStorage.New(db), tables, rounds, wallets, entries, transaction.New(db), rng.NewCrypto(), event.NewHub())
+
+	if _, err := tableStorage.New(db).Create(ctx, table.Table{
+		ID:         tableID,
+		MinimumBet: 10,
+		MaximumBet: 1000,
+		Currency:   "GBP",
+		Wheel:      table.WheelEuropean,
+		Rules:      table.RulesStandard,
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	// remove the table should a step fail so it does not show up when listing the seeded tables
+	t.Cleanup(func() {
+		_, _ = tableStorage.New(db).Delete(ctx, tableID)
+	})
+
+	if _, err := wallets.Create(ctx, wallet.Wallet{PlayerID: playerID, Currency: "GBP", Balance: 1000}); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := c.Create(ctx, bet.Bet{
+		TableID:  tableID,
+		PlayerID: playerID,
+		Bet:      "17",
+		Type:     bet.TypeStraight,
+		Amount:   100,
+		Currency: "GBP",
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := c.DeleteTable(ctx, tableID, false); !errors.Is(err, bet.ErrOpenBets) {
+		t.Fatal(cmp.Diff(err, bet.ErrOpenBets))
+	}
+
+	if _, err := c.DeleteTable(ctx, tableID, true); err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := wallets.List(ctx, playerID)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(got) != 1 {
+		t.Fatal(cmp.Diff(len(got), 1))
+	}
+
+	want := []wallet.Wallet{{ID: got[0].ID, PlayerID: playerID, Currency: "GBP", Balance: 1000}}
+
+	if !cmp.Equal(got, want) {
+		t.Error(cmp.Diff(got, want))
+	}
+
+	if _, err := c.Create(ctx, bet.Bet{
+		TableID:  tableID,
+		PlayerID: playerID,
+		Bet:      "17",
+		Type:     bet.TypeStraight,
+		Amount:   100,
+		Currency: "GBP",
+	}); !errors.Is(err, bet.ErrTable) {
+		t.Error(cmp.Diff(err, bet.ErrTable))
+	}
+
+	if _, err := c.Play(ctx, tableID); !errors.Is(err, bet.ErrTable) {
+		t.Error(cmp.Diff(err, bet.ErrTable))
+	}
+}
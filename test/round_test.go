@@ -0,0 +1,315 @@
+package test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/clarke94/roulette-service/internal/pkg/page"
+	"github.com/clarke94/roulette-service/internal/pkg/round"
+	storage "github.com/clarke94/roulette-service/storage/round"
+	tableStorage "github.com/clarke94/roulette-service/storage/table"
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+)
+
+func TestRoundStorage_Create(t *testing.T) {
+	tests := []struct {
+		name    string
+		model   round.Round
+		ctx     context.Context
+		want    string
+		wantErr bool
+	}{
+		{
+			name: "expect success given valid round",
+			model: round.Round{
+				ID:      "ffffffff-ffff-ffff-ffff-ffffffffffff",
+				TableID: "cccccccc-cccc-cccc-cccc-cccccccccccc",
+				State:   round.StateOpen,
+			},
+			ctx:     context.Background(),
+			want:    "ffffffff-ffff-ffff-ffff-ffffffffffff",
+			wantErr: false,
+		},
+		{
+			name: "expect fail given id already exists",
+			model: round.Round{
+				ID: "dddddddd-dddd-dddd-dddd-dddddddddddd",
+			},
+			ctx:     context.Background(),
+			want:    "",
+			wantErr: true,
+		},
+		{
+			name: "expect fail given table already has a round in play",
+			model: round.Round{
+				ID:      uuid.New().String(),
+				TableID: "bbbbbbbb-bbbb-bbbb-bbbb-bbbbbbbbbbbb",
+				State:   round.StateOpen,
+			},
+			ctx:     context.Background(),
+			want:    "",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := storage.New(db)
+
+			got, err := s.Create(tt.ctx, tt.model)
+			if !cmp.Equal(err != nil, tt.wantErr) {
+				t.Fatal(cmp.Diff(err != nil, tt.wantErr))
+			}
+
+			if !cmp.Equal(got, tt.want) {
+				t.Fatal(cmp.Diff(got, tt.want))
+			}
+		})
+	}
+}
+
+func TestRoundStorage_Current(t *testing.T) {
+	tests := []struct {
+		name    string
+		tableID string
+		want    round.Round
+		wantErr error
+	}{
+		{
+			name:    "expect round given table with open round",
+			tableID: "bbbbbbbb-bbbb-bbbb-bbbb-bbbbbbbbbbbb",
+			want: round.Round{
+				ID:      "dddddddd-dddd-dddd-dddd-dddddddddddd",
+				TableID: "bbbbbbbb-bbbb-bbbb-bbbb-bbbbbbbbbbbb",
+				State:   round.StateOpen,
+			},
+			wantErr: nil,
+		},
+		{
+			name:    "expect not found given table without a round",
+			tableID: uuid.New().String(),
+			want:    round.Round{},
+			wantErr: round.ErrNotFound,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := storage.New(db)
+
+			got, err := s.Current(context.Background(), tt.tableID)
+			if err != tt.wantErr {
+				t.Fatal(err, tt.wantErr)
+			}
+
+			if !cmp.Equal(got, tt.want) {
+				t.Fatal(cmp.Diff(got, tt.want))
+			}
+		})
+	}
+}
+
+func TestRoundStorage_Update(t *testing.T) {
+	tests := []struct {
+		name    string
+		model   round.Round
+		want    string
+		wantErr bool
+	}{
+		{
+			name: "expect fail given round doesnt exist",
+			model: round.Round{
+				ID:    uuid.New().String(),
+				State: round.StateClosed,
+			},
+			want:    "",
+			wantErr: true,
+		},
+		{
+			name: "expect success given spin on zero",
+			model: round.Round{
+				ID:      "ffffffff-ffff-ffff-ffff-ffffffffffff",
+				TableID: "cccccccc-cccc-cccc-cccc-cccccccccccc",
+				State:   round.StateSpun,
+				Number:  0,
+				Color:   "green",
+			},
+			want:    "ffffffff-ffff-ffff-ffff-ffffffffffff",
+			wantErr: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := storage.New(db)
+
+			got, err := s.Update(context.Background(), tt.model)
+			if !cmp.Equal(err != nil, tt.wantErr) {
+				t.Fatal(cmp.Diff(err != nil, tt.wantErr))
+			}
+
+			if !cmp.Equal(got, tt.want) {
+				t.Fatal(cmp.Diff(got, tt.want))
+			}
+		})
+	}
+}
+
+func TestRoundStorage_Settle(t *testing.T) {
+	tests := []struct {
+		name    string
+		model   round.Round
+		want    round.Round
+		wantErr bool
+	}{
+		{
+			name: "expect winners and totals given settled round",
+			model: round.Round{
+				ID:          "ffffffff-ffff-ffff-ffff-ffffffffffff",
+				TableID:     "cccccccc-cccc-cccc-cccc-cccccccccccc",
+				State:       round.StateSettled,
+				Number:      0,
+				Color:       "green",
+				TotalStaked: 20,
+				TotalPaid:   360,
+				Winners: []round.Winner{
+					{
+						BetID:      "aaaaaaaa-aaaa-aaaa-aaaa-aaaaaaaaaaaa",
+						Stake:      10,
+						Multiplier: 35,
+						Winnings:   350,
+						Return:     360,
+						Currency:   "GBP",
+					},
+				},
+				SpunAt: time.Date(2021, 6, 21, 21, 4, 0, 0, time.UTC),
+			},
+			want: round.Round{
+				ID:          "ffffffff-ffff-ffff-ffff-ffffffffffff",
+				TableID:     "cccccccc-cccc-cccc-cccc-cccccccccccc",
+				State:       round.StateSettled,
+				Number:      0,
+				Color:       "green",
+				TotalStaked: 20,
+				TotalPaid:   360,
+				Winners: []round.Winner{
+					{
+						BetID:      "aaaaaaaa-aaaa-aaaa-aaaa-aaaaaaaaaaaa",
+						Stake:      10,
+						Multiplier: 35,
+						Winnings:   350,
+						Return:     360,
+						Currency:   "GBP",
+					},
+				},
+				SpunAt: time.Date(2021, 6, 21, 21, 4, 0, 0, time.UTC),
+			},
+			wantErr: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := storage.New(db)
+
+			_, err := s.Update(context.Background(), tt.model)
+			if !cmp.Equal(err != nil, tt.wantErr) {
+				t.Fatal(cmp.Diff(err != nil, tt.wantErr))
+			}
+
+			got, err := s.Get(context.Background(), tt.model.TableID, tt.model.ID)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			got.SpunAt = got.SpunAt.UTC()
+			got.CreatedAt = time.Time{}
+
+			if !cmp.Equal(got, tt.want) {
+				t.Fatal(cmp.Diff(got, tt.want))
+			}
+		})
+	}
+}
+
+func TestRoundStorage_List(t *testing.T) {
+	tests := []struct {
+		name     string
+		tableID  string
+		filter   round.Filter
+		wantIDs  []string
+		wantNext bool
+		wantErr  bool
+	}{
+		{
+			name:    "expect rounds given table with rounds",
+			tableID: "cccccccc-cccc-cccc-cccc-cccccccccccc",
+			filter:  round.Filter{},
+			wantIDs: []string{"ffffffff-ffff-ffff-ffff-ffffffffffff"},
+		},
+		{
+			name:    "expect rounds given spin within dates",
+			tableID: "cccccccc-cccc-cccc-cccc-cccccccccccc",
+			filter: round.Filter{
+				From: time.Date(2021, 6, 21, 21, 0, 0, 0, time.UTC),
+				To:   time.Date(2021, 6, 21, 21, 5, 0, 0, time.UTC),
+			},
+			wantIDs: []string{"ffffffff-ffff-ffff-ffff-ffffffffffff"},
+		},
+		{
+			name:    "expect no rounds given spin outside dates",
+			tableID: "cccccccc-cccc-cccc-cccc-cccccccccccc",
+			filter: round.Filter{
+				From: time.Date(2021, 6, 22, 0, 0, 0, 0, time.UTC),
+			},
+			wantIDs: []string{},
+		},
+		{
+			name:     "expect no next cursor given rounds within the limit",
+			tableID:  "bbbbbbbb-bbbb-bbbb-bbbb-bbbbbbbbbbbb",
+			filter:   round.Filter{Page: page.Page{Limit: 1}},
+			wantIDs:  []string{"dddddddd-dddd-dddd-dddd-dddddddddddd"},
+			wantNext: false,
+		},
+		{
+			name:    "expect fail given invalid cursor",
+			tableID: "cccccccc-cccc-cccc-cccc-cccccccccccc",
+			filter:  round.Filter{Page: page.Page{Cursor: "foo"}},
+			wantIDs: []string{},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := storage.New(db)
+
+			got, next, err := s.List(context.Background(), tt.tableID, tt.filter)
+			if !cmp.Equal(err != nil, tt.wantErr) {
+				t.Fatal(cmp.Diff(err != nil, tt.wantErr))
+			}
+
+			ids := make([]string, len(got))
+			for i := range got {
+				ids[i] = got[i].ID
+			}
+
+			if !cmp.Equal(ids, tt.wantIDs) {
+				t.Fatal(cmp.Diff(ids, tt.wantIDs))
+			}
+
+			if !cmp.Equal(next != "", tt.wantNext) {
+				t.Fatal(cmp.Diff(next != "", tt.wantNext))
+			}
+		})
+	}
+}
+
+func TestRoundController_List(t *testing.T) {
+	c := round.New(logrus.New(), storage.New(db), tableStorage.New(db))
+
+	_, _, err := c.List(context.Background(), "cccccccc-cccc-cccc-cccc-cccccccccccc", round.Filter{Page: page.Page{Cursor: "foo"}})
+	if !errors.Is(err, page.ErrCursor) {
+		t.Error(cmp.Diff(err, page.ErrCursor, cmpopts.EquateErrors()))
+	}
+}
@@ -2,9 +2,11 @@ package test
 
 import (
 	"context"
+	"github.com/clarke94/roulette-service/internal/pkg/page"
 	"github.com/clarke94/roulette-service/internal/pkg/table"
 	storage "github.com/clarke94/roulette-service/storage/table"
 	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
 	"github.com/google/uuid"
 	"testing"
 )
@@ -58,9 +60,11 @@ func TestTableStorage_Create(t *testing.T) {
 
 func TestTableStorage_List(t *testing.T) {
 	tests := []struct {
-		name    string
-		want    []table.Table
-		wantErr bool
+		name     string
+		filter   table.Filter
+		want     []table.Table
+		wantNext bool
+		wantErr  bool
 	}{
 		{
 			name: "expect array of tables given valid request",
@@ -71,6 +75,9 @@ func TestTableStorage_List(t *testing.T) {
 					MaximumBet: 0,
 					MinimumBet: 0,
 					Currency:   "GBP",
+					Wheel:      table.WheelEuropean,
+					Rules:      table.RulesStandard,
+					Version:    1,
 				},
 				{
 					ID:         "cccccccc-cccc-cccc-cccc-cccccccccccc",
@@ -78,12 +85,16 @@ func TestTableStorage_List(t *testing.T) {
 					MaximumBet: 0,
 					MinimumBet: 0,
 					Currency:   "GBP",
+					Wheel:      table.WheelEuropean,
+					Rules:      table.RulesStandard,
+					Version:    1,
 				},
 			},
 			wantErr: false,
 		},
 		{
-			name: "expect array of tables given valid request with filter",
+			name:   "expect array of tables given valid request with filter",
+			filter: table.Filter{Currency: "GBP", Wheel: table.WheelEuropean, Rules: table.RulesStandard},
 			want: []table.Table{
 				{
 					ID:         "aaaaaaaa-aaaa-aaaa-aaaa-aaaaaaaaaaaa",
@@ -91,6 +102,9 @@ func TestTableStorage_List(t *testing.T) {
 					MaximumBet: 0,
 					MinimumBet: 0,
 					Currency:   "GBP",
+					Wheel:      table.WheelEuropean,
+					Rules:      table.RulesStandard,
+					Version:    1,
 				},
 				{
 					ID:         "cccccccc-cccc-cccc-cccc-cccccccccccc",
@@ -98,16 +112,48 @@ func TestTableStorage_List(t *testing.T) {
 					MaximumBet: 0,
 					MinimumBet: 0,
 					Currency:   "GBP",
+					Wheel:      table.WheelEuropean,
+					Rules:      table.RulesStandard,
+					Version:    1,
 				},
 			},
 			wantErr: false,
 		},
+		{
+			name:   "expect first page of tables given limit",
+			filter: table.Filter{Page: page.Page{Limit: 1}},
+			want: []table.Table{
+				{
+					ID:         "aaaaaaaa-aaaa-aaaa-aaaa-aaaaaaaaaaaa",
+					Name:       "",
+					MaximumBet: 0,
+					MinimumBet: 0,
+					Currency:   "GBP",
+					Wheel:      table.WheelEuropean,
+					Rules:      table.RulesStandard,
+					Version:    1,
+				},
+			},
+			wantNext: true,
+			wantErr:  false,
+		},
+		{
+			name:   "expect no tables given another currency",
+			filter: table.Filter{Currency: "EUR"},
+			want:   []table.Table{},
+		},
+		{
+			name:    "expect fail given invalid cursor",
+			filter:  table.Filter{Page: page.Page{Cursor: "foo"}},
+			want:    []table.Table{},
+			wantErr: true,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			s := storage.New(db)
 
-			got, err := s.List(context.Background())
+			got, next, err := s.List(context.Background(), tt.filter)
 
 			if !cmp.Equal(err != nil, tt.wantErr) {
 				t.Fatal(cmp.Diff(err != nil, tt.wantErr))
@@ -116,6 +162,55 @@ func TestTableStorage_List(t *testing.T) {
 			if !cmp.Equal(got, tt.want) {
 				t.Fatal(cmp.Diff(got, tt.want))
 			}
+
+			if !cmp.Equal(next != "", tt.wantNext) {
+				t.Fatal(cmp.Diff(next != "", tt.wantNext))
+			}
+		})
+	}
+}
+
+func TestTableStorage_Get(t *testing.T) {
+	tests := []struct {
+		name    string
+		id      string
+		want    table.Table
+		wantErr error
+	}{
+		{
+			name: "expect table given existing table",
+			id:   "aaaaaaaa-aaaa-aaaa-aaaa-aaaaaaaaaaaa",
+			want: table.Table{
+				ID:         "aaaaaaaa-aaaa-aaaa-aaaa-aaaaaaaaaaaa",
+				Name:       "",
+				MaximumBet: 0,
+				MinimumBet: 0,
+				Currency:   "GBP",
+				Wheel:      table.WheelEuropean,
+				Rules:      table.RulesStandard,
+				Version:    1,
+			},
+			wantErr: nil,
+		},
+		{
+			name:    "expect not found given missing table",
+			id:      uuid.New().String(),
+			want:    table.Table{},
+			wantErr: table.ErrNotFound,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := storage.New(db)
+
+			got, err := s.Get(context.Background(), tt.id)
+			if !cmp.Equal(err, tt.wantErr, cmpopts.EquateErrors()) {
+				t.Fatal(cmp.Diff(err, tt.wantErr, cmpopts.EquateErrors()))
+			}
+
+			if !cmp.Equal(got, tt.want) {
+				t.Fatal(cmp.Diff(got, tt.want))
+			}
 		})
 	}
 }
@@ -136,6 +231,8 @@ func TestTableStorage_Update(t *testing.T) {
 				MaximumBet: 0,
 				MinimumBet: 0,
 				Currency:   "GBP",
+				Wheel:      table.WheelEuropean,
+				Rules:      table.RulesStandard,
 			},
 			ctx:     context.Background(),
 			want:    "",
@@ -149,11 +246,30 @@ func TestTableStorage_Update(t *testing.T) {
 				MaximumBet: 0,
 				MinimumBet: 0,
 				Currency:   "GBP",
+				Wheel:      table.WheelEuropean,
+				Rules:      table.RulesStandard,
+				Version:    1,
 			},
 			ctx:     context.Background(),
 			want:    "aaaaaaaa-aaaa-aaaa-aaaa-aaaaaaaaaaaa",
 			wantErr: false,
 		},
+		{
+			name: "expect fail given table has changed since the version",
+			model: table.Table{
+				ID:         "aaaaaaaa-aaaa-aaaa-aaaa-aaaaaaaaaaaa",
+				Name:       "",
+				MaximumBet: 0,
+				MinimumBet: 0,
+				Currency:   "GBP",
+				Wheel:      table.WheelEuropean,
+				Rules:      table.RulesStandard,
+				Version:    1,
+			},
+			ctx:     context.Background(),
+			want:    "",
+			wantErr: true,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -0,0 +1,124 @@
+package test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/clarke94/roulette-service/internal/pkg/wallet"
+	storage "github.com/clarke94/roulette-service/storage/wallet"
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/google/uuid"
+)
+
+func TestWalletStorage_Create(t *testing.T) {
+	tests := []struct {
+		name    string
+		model   wallet.Wallet
+		want    string
+		wantErr error
+	}{
+		{
+			name: "expect success given new currency",
+			model: wallet.Wallet{
+				ID:       "ffffffff-eeee-eeee-eeee-ffffffffffff",
+				PlayerID: "aaaaaaaa-0000-0000-0000-aaaaaaaaaaaa",
+				Currency: "EUR",
+				Balance:  500,
+			},
+			want:    "ffffffff-eeee-eeee-eeee-ffffffffffff",
+			wantErr: nil,
+		},
+		{
+			name: "expect fail given wallet already exists for currency",
+			model: wallet.Wallet{
+				ID:       uuid.New().String(),
+				PlayerID: "aaaaaaaa-0000-0000-0000-aaaaaaaaaaaa",
+				Currency: "GBP",
+			},
+			want:    "",
+			wantErr: wallet.ErrExists,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := storage.New(db)
+
+			got, err := s.Create(context.Background(), tt.model)
+			if err != tt.wantErr {
+				t.Fatal(err, tt.wantErr)
+			}
+
+			if !cmp.Equal(got, tt.want) {
+				t.Fatal(cmp.Diff(got, tt.want))
+			}
+		})
+	}
+}
+
+func TestWalletStorage_Reserve(t *testing.T) {
+	tests := []struct {
+		name     string
+		playerID string
+		amount   int64
+		wantErr  error
+	}{
+		{
+			name:     "expect success given available funds",
+			playerID: "aaaaaaaa-0000-0000-0000-aaaaaaaaaaaa",
+			amount:   100,
+			wantErr:  nil,
+		},
+		{
+			name:     "expect fail given insufficient funds",
+			playerID: "aaaaaaaa-0000-0000-0000-aaaaaaaaaaaa",
+			amount:   100000,
+			wantErr:  wallet.ErrInsufficientFunds,
+		},
+		{
+			name:     "expect fail given missing wallet",
+			playerID: uuid.New().String(),
+			amount:   100,
+			wantErr:  wallet.ErrInsufficientFunds,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := storage.New(db)
+
+			err := s.Reserve(context.Background(), tt.playerID, "GBP", tt.amount)
+			if !cmp.Equal(err, tt.wantErr, cmpopts.EquateErrors()) {
+				t.Fatal(cmp.Diff(err, tt.wantErr, cmpopts.EquateErrors()))
+			}
+		})
+	}
+}
+
+func TestWalletStorage_Deposit(t *testing.T) {
+	tests := []struct {
+		name     string
+		playerID string
+		wantErr  error
+	}{
+		{
+			name:     "expect success given existing wallet",
+			playerID: "aaaaaaaa-0000-0000-0000-aaaaaaaaaaaa",
+			wantErr:  nil,
+		},
+		{
+			name:     "expect not found given missing wallet",
+			playerID: uuid.New().String(),
+			wantErr:  wallet.ErrNotFound,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := storage.New(db)
+
+			err := s.Deposit(context.Background(), tt.playerID, "GBP", 100)
+			if !cmp.Equal(err, tt.wantErr, cmpopts.EquateErrors()) {
+				t.Fatal(cmp.Diff(err, tt.wantErr, cmpopts.EquateErrors()))
+			}
+		})
+	}
+}